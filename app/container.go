@@ -0,0 +1,251 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/config"
+	"github.com/IrvanWijayaSardam/SelfBank/fx"
+	"github.com/IrvanWijayaSardam/SelfBank/health"
+	"github.com/IrvanWijayaSardam/SelfBank/llm"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
+	"github.com/IrvanWijayaSardam/SelfBank/tracing"
+	"github.com/go-redis/redis"
+	"gorm.io/gorm"
+)
+
+type Repositories struct {
+	User          repository.UserRepository
+	Deposit       repository.DepositRepository
+	Withdrawal    repository.WithdrawalRepository
+	Transaction   repository.TransactionRepository
+	Conversation  repository.ChatConversationRepository
+	ChatUsage     repository.ChatUsageRepository
+	Faq           repository.FaqRepository
+	Audit         repository.AuditRepository
+	Verification  repository.VerificationRepository
+	TransferQuote repository.TransferQuoteRepository
+	FeeLimit      repository.FeeLimitRepository
+	Kyc           repository.KycRepository
+	Wallet        repository.WalletRepository
+	Ledger        repository.LedgerRepository
+}
+
+type Services struct {
+	Auth         service.AuthService
+	JWT          service.JWTService
+	FeeLimit     service.FeeLimitService
+	Kyc          service.KycService
+	Admin        service.AdminService
+	Account      service.AccountService
+	Audit        service.AuditService
+	Deposit      service.DepositService
+	Withdrawal   service.WithdrawalService
+	User         service.UserService
+	MediaUpload  service.MediaUpload
+	Transaction  service.TransactionService
+	Wallet       service.WalletService
+	Chatbot      service.ChatbotService
+	Faq          service.FaqService
+	Verification service.VerificationService
+	Ledger       service.LedgerService
+}
+
+// Container builds the dependencies shared by the commands. Connections are
+// only opened on first use, so a command like migrate never needs Redis or
+// OpenAI.
+type Container struct {
+	config       *config.Config
+	db           *gorm.DB
+	redis        *redis.Client
+	llmProvider  llm.Provider
+	blobStore    storage.BlobStore
+	urlSigner    *storage.URLSigner
+	rateProvider fx.RateProvider
+	repositories *Repositories
+	services     *Services
+	checker      *health.Checker
+
+	workerCtx   context.Context
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
+}
+
+// healthCheckTimeout bounds each dependency probe of the readiness check.
+const healthCheckTimeout = 3 * time.Second
+
+func NewContainer(cfg *config.Config) *Container {
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	return &Container{config: cfg, workerCtx: workerCtx, stopWorkers: stopWorkers}
+}
+
+func (c *Container) Config() *config.Config {
+	return c.config
+}
+
+func (c *Container) DB() *gorm.DB {
+	if c.db == nil {
+		c.db = config.SetupDatabaseConnection(c.config.Database)
+	}
+	return c.db
+}
+
+func (c *Container) Redis() *redis.Client {
+	if c.redis == nil {
+		c.redis = config.ConnectRedis(c.config.Redis)
+	}
+	return c.redis
+}
+
+func (c *Container) LLMProvider() llm.Provider {
+	if c.llmProvider == nil {
+		c.llmProvider = config.SetupLLMProvider(c.config.Chatbot, c.config.OpenAI, tracing.Client(nil))
+	}
+	return c.llmProvider
+}
+
+func (c *Container) BlobStore() (storage.BlobStore, *storage.URLSigner) {
+	if c.blobStore == nil {
+		c.blobStore, c.urlSigner = config.SetupBlobStore(c.config.Storage, c.config.BaseURL)
+	}
+	return c.blobStore, c.urlSigner
+}
+
+func (c *Container) RateProvider() fx.RateProvider {
+	if c.rateProvider == nil {
+		c.rateProvider = config.SetupRateProvider(c.config.FX)
+	}
+	return c.rateProvider
+}
+
+func (c *Container) Repositories() *Repositories {
+	if c.repositories != nil {
+		return c.repositories
+	}
+
+	db := c.DB()
+	c.repositories = &Repositories{
+		User:          repository.NewUserRepository(db, c.config.Accounts.NumberPrefix),
+		Deposit:       repository.NewDepositRepository(db),
+		Withdrawal:    repository.NewWithdrawalRepository(db),
+		Transaction:   repository.NewTransactionRepository(db),
+		Conversation:  repository.NewChatConversationRepository(db),
+		ChatUsage:     repository.NewChatUsageRepository(c.Redis(), db),
+		Faq:           repository.NewFaqRepository(db),
+		Audit:         repository.NewAuditRepository(db),
+		Verification:  repository.NewVerificationRepository(c.Redis(), db),
+		TransferQuote: repository.NewTransferQuoteRepository(c.Redis()),
+		FeeLimit:      repository.NewFeeLimitRepository(db),
+		Kyc:           repository.NewKycRepository(db),
+		Wallet:        repository.NewWalletRepository(db),
+		Ledger:        repository.NewLedgerRepository(db),
+	}
+	return c.repositories
+}
+
+func (c *Container) Services() *Services {
+	if c.services != nil {
+		return c.services
+	}
+
+	repos := c.Repositories()
+	blobStore, _ := c.BlobStore()
+
+	feeLimitService := service.NewFeeLimitService(repos.FeeLimit, repos.User)
+	userService := service.NewUserService(repos.User)
+	depositService := service.NewDepositService(repos.Deposit, feeLimitService, blobStore)
+	transactionService := service.NewTransactionService(repos.Transaction, repos.TransferQuote, feeLimitService)
+	accountService := service.NewAccountService(repos.User, repos.Wallet, userService)
+	faqService := service.NewFaqService(repos.Faq, c.LLMProvider(), c.config.Chatbot)
+	c.services = &Services{
+		Auth:         service.NewAuthService(repos.User),
+		JWT:          service.NewJWTService(c.config.JWT),
+		FeeLimit:     feeLimitService,
+		Kyc:          service.NewKycService(repos.Kyc, blobStore),
+		Admin:        service.NewAdminService(repos.User, accountService),
+		Account:      accountService,
+		Audit:        service.NewAuditService(repos.Audit),
+		Deposit:      depositService,
+		Withdrawal:   service.NewWithdrawalService(repos.Withdrawal, feeLimitService, blobStore),
+		User:         userService,
+		MediaUpload:  service.NewMediaUpload(blobStore),
+		Transaction:  transactionService,
+		Wallet:       service.NewWalletService(repos.Wallet, repos.Transaction, userService, feeLimitService, c.RateProvider()),
+		Chatbot:      service.NewChatbotService(c.LLMProvider(), repos.Conversation, repos.ChatUsage, userService, transactionService, depositService, faqService, c.config.Chatbot),
+		Faq:          faqService,
+		Verification: service.NewVerificationService(repos.Verification, c.config.SMTP),
+		Ledger:       service.NewLedgerService(repos.Ledger, repos.Wallet, userService),
+	}
+	return c.services
+}
+
+// HealthChecker probes MySQL, which the API cannot work without, and Redis
+// plus the configured external providers, which only degrade it.
+func (c *Container) HealthChecker() *health.Checker {
+	if c.checker != nil {
+		return c.checker
+	}
+
+	blobStore, _ := c.BlobStore()
+	checks := []health.Check{
+		{Name: "mysql", Critical: true, Probe: func(ctx context.Context) error {
+			sqlDB, err := c.DB().DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		{Name: "redis", Probe: func(ctx context.Context) error {
+			return c.Redis().WithContext(ctx).Ping().Err()
+		}},
+		{Name: "storage", Probe: blobStore.Ping},
+	}
+	if c.config.Chatbot.Provider != config.ChatbotOpenAI || c.config.OpenAI.Key != "" {
+		checks = append(checks, health.Check{Name: "chatbot", Probe: c.LLMProvider().Ping})
+	}
+
+	c.checker = health.NewChecker(healthCheckTimeout, checks...)
+	return c.checker
+}
+
+// Go runs a background worker. Its context is cancelled by Shutdown, which
+// then waits for the worker to return.
+func (c *Container) Go(worker func(ctx context.Context)) {
+	c.workers.Add(1)
+	go func() {
+		defer c.workers.Done()
+		worker(c.workerCtx)
+	}()
+}
+
+// Shutdown stops the background workers and waits for them until ctx is
+// done.
+func (c *Container) Shutdown(ctx context.Context) error {
+	c.stopWorkers()
+
+	done := make(chan struct{})
+	go func() {
+		c.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close releases the connections that were opened.
+func (c *Container) Close() {
+	if c.db != nil {
+		config.CloseDatabaseConnection(c.db)
+	}
+	if c.redis != nil {
+		c.redis.Close()
+	}
+}
@@ -0,0 +1,106 @@
+package apperror
+
+import "net/http"
+
+// Generic errors.
+var (
+	ErrInvalidRequest     = define("INVALID_REQUEST", http.StatusBadRequest)
+	ErrValidation         = define("VALIDATION_FAILED", http.StatusBadRequest)
+	ErrInvalidID          = define("INVALID_ID", http.StatusBadRequest)
+	ErrInvalidDate        = define("INVALID_DATE", http.StatusBadRequest)
+	ErrInvalidPagination  = define("INVALID_PAGINATION", http.StatusBadRequest)
+	ErrInvalidToken       = define("INVALID_TOKEN", http.StatusUnauthorized)
+	ErrForbidden          = define("FORBIDDEN", http.StatusForbidden)
+	ErrNotFound           = define("NOT_FOUND", http.StatusNotFound)
+	ErrMethodNotAllowed   = define("METHOD_NOT_ALLOWED", http.StatusMethodNotAllowed)
+	ErrPayloadTooLarge    = define("PAYLOAD_TOO_LARGE", http.StatusRequestEntityTooLarge)
+	ErrTooManyRequests    = define("TOO_MANY_REQUESTS", http.StatusTooManyRequests)
+	ErrInternal           = define("INTERNAL_ERROR", http.StatusInternalServerError)
+	ErrServiceUnavailable = define("SERVICE_UNAVAILABLE", http.StatusServiceUnavailable)
+	ErrInvalidFile        = define("INVALID_FILE", http.StatusBadRequest)
+)
+
+// Accounts and verification.
+var (
+	ErrInvalidCredentials  = define("INVALID_CREDENTIALS", http.StatusUnauthorized)
+	ErrEmailTaken          = define("EMAIL_TAKEN", http.StatusConflict)
+	ErrOTPExpired          = define("OTP_EXPIRED", http.StatusBadRequest)
+	ErrEmailDeliveryFailed = define("EMAIL_DELIVERY_FAILED", http.StatusBadGateway)
+	ErrAccountFrozen       = define("ACCOUNT_FROZEN", http.StatusForbidden)
+	ErrAccountDormant      = define("ACCOUNT_DORMANT", http.StatusForbidden)
+	ErrAccountClosed       = define("ACCOUNT_CLOSED", http.StatusForbidden)
+	ErrAccountNotDormant   = define("ACCOUNT_NOT_DORMANT", http.StatusConflict)
+	ErrBalanceNotZero      = define("BALANCE_NOT_ZERO", http.StatusConflict)
+	ErrWalletNotEmpty      = define("WALLET_NOT_EMPTY", http.StatusConflict)
+	ErrPendingDeposits     = define("PENDING_DEPOSITS", http.StatusConflict)
+)
+
+// Transfers, withdrawals and wallets.
+var (
+	ErrAccountNotFound         = define("ACCOUNT_NOT_FOUND", http.StatusNotFound)
+	ErrInsufficientFunds       = define("INSUFFICIENT_FUNDS", http.StatusUnprocessableEntity)
+	ErrInvalidAmount           = define("INVALID_AMOUNT", http.StatusBadRequest)
+	ErrSameAccount             = define("SAME_ACCOUNT", http.StatusBadRequest)
+	ErrNoteTooLong             = define("NOTE_TOO_LONG", http.StatusBadRequest)
+	ErrCategoryTooLong         = define("CATEGORY_TOO_LONG", http.StatusBadRequest)
+	ErrQuoteExpired            = define("QUOTE_EXPIRED", http.StatusNotFound)
+	ErrCurrencyNotSupported    = define("CURRENCY_NOT_SUPPORTED", http.StatusBadRequest)
+	ErrWalletExists            = define("WALLET_EXISTS", http.StatusConflict)
+	ErrWalletNotFound          = define("WALLET_NOT_FOUND", http.StatusNotFound)
+	ErrRecipientWalletNotFound = define("RECIPIENT_WALLET_NOT_FOUND", http.StatusNotFound)
+	ErrCurrencyMismatch        = define("CURRENCY_MISMATCH", http.StatusBadRequest)
+	ErrSameWallet              = define("SAME_WALLET", http.StatusBadRequest)
+	ErrAmountTooSmall          = define("AMOUNT_TOO_SMALL", http.StatusBadRequest)
+)
+
+// Transaction limits, the message tells how much is left.
+var (
+	ErrAmountBelowMinimum   = define("AMOUNT_BELOW_MINIMUM", http.StatusUnprocessableEntity)
+	ErrAmountAboveMaximum   = define("AMOUNT_ABOVE_MAXIMUM", http.StatusUnprocessableEntity)
+	ErrDailyLimitExceeded   = define("DAILY_LIMIT_EXCEEDED", http.StatusUnprocessableEntity)
+	ErrMonthlyLimitExceeded = define("MONTHLY_LIMIT_EXCEEDED", http.StatusUnprocessableEntity)
+)
+
+// KYC.
+var (
+	ErrKycPending          = define("KYC_PENDING", http.StatusConflict)
+	ErrKycAlreadyVerified  = define("KYC_ALREADY_VERIFIED", http.StatusConflict)
+	ErrKycNotSubmitted     = define("KYC_NOT_SUBMITTED", http.StatusNotFound)
+	ErrKycAlreadyReviewed  = define("KYC_ALREADY_REVIEWED", http.StatusConflict)
+	ErrKycReasonRequired   = define("KYC_REASON_REQUIRED", http.StatusBadRequest)
+	ErrKycDocumentRequired = define("KYC_DOCUMENT_REQUIRED", http.StatusBadRequest)
+	ErrKycDocumentInvalid  = define("KYC_DOCUMENT_INVALID", http.StatusBadRequest)
+	ErrNIKTaken            = define("NIK_TAKEN", http.StatusConflict)
+	ErrInvalidNIK          = define("INVALID_NIK", http.StatusBadRequest)
+	ErrInvalidDateOfBirth  = define("INVALID_DATE_OF_BIRTH", http.StatusBadRequest)
+	ErrUnderage            = define("UNDERAGE", http.StatusUnprocessableEntity)
+	ErrAddressRequired     = define("ADDRESS_REQUIRED", http.StatusBadRequest)
+)
+
+// Payments and other providers.
+var (
+	ErrUnsupportedPaymentType = define("UNSUPPORTED_PAYMENT_TYPE", http.StatusBadRequest)
+	ErrPaymentProvider        = define("PAYMENT_PROVIDER_ERROR", http.StatusBadGateway)
+	ErrChatbotUnavailable     = define("CHATBOT_UNAVAILABLE", http.StatusBadGateway)
+)
+
+// Chatbot conversations.
+var (
+	ErrConversationNotFound = define("CONVERSATION_NOT_FOUND", http.StatusNotFound)
+	ErrChatbotRateLimited   = define("CHATBOT_RATE_LIMITED", http.StatusTooManyRequests)
+	ErrChatbotQuotaExceeded = define("CHATBOT_QUOTA_EXCEEDED", http.StatusTooManyRequests)
+	ErrFaqArticleNotFound   = define("FAQ_ARTICLE_NOT_FOUND", http.StatusNotFound)
+	ErrFaqIndexFailed       = define("FAQ_INDEX_FAILED", http.StatusBadGateway)
+)
+
+// User administration.
+var (
+	ErrUserNotFound      = define("USER_NOT_FOUND", http.StatusNotFound)
+	ErrUserAlreadyFrozen = define("USER_ALREADY_FROZEN", http.StatusConflict)
+	ErrUserNotFrozen     = define("USER_NOT_FROZEN", http.StatusConflict)
+	ErrUserClosed        = define("USER_CLOSED", http.StatusConflict)
+	ErrUserNotClosed     = define("USER_NOT_CLOSED", http.StatusConflict)
+	ErrUserChanged       = define("USER_CHANGED", http.StatusConflict)
+	ErrOwnAccount        = define("OWN_ACCOUNT", http.StatusForbidden)
+	ErrRoleNotFound      = define("ROLE_NOT_FOUND", http.StatusBadRequest)
+)
@@ -0,0 +1,145 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Params fill the placeholders of a message, such as {limit}.
+type Params map[string]string
+
+// FieldError is a request field that broke one of its validation rules.
+// Param is the rule's argument, such as the minimum length.
+type FieldError struct {
+	Field string
+	Rule  string
+	Param string
+}
+
+// Error is a failure the API reports to its clients. Code is stable and
+// meant for clients to switch on, Status is the HTTP status it is answered
+// with and the message shown is looked up by code in the caller's language.
+type Error struct {
+	Code   string
+	Status int
+	Params Params
+	// Detail is extra information in English, for validation failures
+	// coming from outside the catalog.
+	Detail string
+	// Fields lists the request fields that failed validation.
+	Fields []FieldError
+	// Err is the underlying cause. It is logged but never shown.
+	Err error
+}
+
+func define(code string, status int) *Error {
+	return &Error{Code: code, Status: status}
+}
+
+// Error returns the English message, for logs.
+func (e *Error) Error() string {
+	message := e.Localize(English)
+	if e.Detail != "" {
+		message += ": " + e.Detail
+	}
+	if len(e.Fields) > 0 {
+		fields := make([]string, 0, len(e.Fields))
+		for _, field := range e.Fields {
+			fields = append(fields, field.Localize(English))
+		}
+		message += ": " + strings.Join(fields, ", ")
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches on the code, so errors.Is(err, apperror.ErrNotFound) holds for
+// any not found error whatever its params.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// With returns a copy of e carrying params.
+func (e *Error) With(params Params) *Error {
+	copied := *e
+	copied.Params = params
+	return &copied
+}
+
+// WithDetail returns a copy of e carrying detail.
+func (e *Error) WithDetail(detail string) *Error {
+	copied := *e
+	copied.Detail = detail
+	return &copied
+}
+
+// WithFields returns a copy of e carrying the fields that failed validation.
+func (e *Error) WithFields(fields []FieldError) *Error {
+	copied := *e
+	copied.Fields = fields
+	return &copied
+}
+
+// Wrap returns a copy of e caused by err.
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// As returns the *Error in err's chain, if any.
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	ok := errors.As(err, &appErr)
+	return appErr, ok
+}
+
+// Invalid passes errors that already carry a code through and reports any
+// other as an invalid request, keeping its text as the detail.
+func Invalid(err error) error {
+	if _, ok := As(err); ok {
+		return err
+	}
+	return ErrInvalidRequest.WithDetail(err.Error())
+}
+
+// Internal passes errors that already carry a code through and hides any
+// other behind an internal error.
+func Internal(err error) error {
+	if _, ok := As(err); ok {
+		return err
+	}
+	return ErrInternal.Wrap(err)
+}
+
+// FromStatus returns the generic error for an HTTP status, used for the
+// errors Echo raises itself such as unknown routes.
+func FromStatus(status int) *Error {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrInvalidRequest
+	case http.StatusUnauthorized:
+		return ErrInvalidToken
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrMethodNotAllowed
+	case http.StatusRequestEntityTooLarge:
+		return ErrPayloadTooLarge
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case http.StatusServiceUnavailable:
+		return ErrServiceUnavailable
+	}
+	return ErrInternal
+}
@@ -0,0 +1,252 @@
+package apperror
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Languages messages are available in.
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Indonesian})
+
+// Language picks the message language for an Accept-Language header,
+// English unless Indonesian is preferred.
+func Language(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return English
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No || index != 1 {
+		return English
+	}
+	return Indonesian
+}
+
+// Localize returns the message for e in lang with its params filled in,
+// falling back to English and then to the code itself.
+func (e *Error) Localize(lang string) string {
+	return localize(lang, e.Code, e.Params)
+}
+
+// Localize returns the message for a field error in lang, rules without a
+// message of their own get a generic one.
+func (f FieldError) Localize(lang string) string {
+	key := "field." + f.Rule
+	if _, ok := messages[English][key]; !ok {
+		key = "field.invalid"
+	}
+	return localize(lang, key, Params{"field": f.Field, "param": f.Param})
+}
+
+func localize(lang string, key string, params Params) string {
+	message, ok := messages[lang][key]
+	if !ok {
+		message, ok = messages[English][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(params) == 0 {
+		return message
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		pairs = append(pairs, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+var messages = map[string]map[string]string{
+	English: {
+		"INVALID_REQUEST":     "The request is not valid",
+		"VALIDATION_FAILED":   "Some fields are not valid",
+		"INVALID_ID":          "The ID is not valid",
+		"INVALID_DATE":        "The date must use the YYYY-MM-DD format",
+		"INVALID_PAGINATION":  "page and pageSize must be greater than zero",
+		"INVALID_TOKEN":       "Your session is not valid, please log in again",
+		"FORBIDDEN":           "You are not allowed to do this",
+		"NOT_FOUND":           "Data not found",
+		"METHOD_NOT_ALLOWED":  "Method not allowed",
+		"PAYLOAD_TOO_LARGE":   "The request is too large",
+		"TOO_MANY_REQUESTS":   "Too many requests, please try again later",
+		"INTERNAL_ERROR":      "Something went wrong, please try again later",
+		"SERVICE_UNAVAILABLE": "This feature is temporarily unavailable, please try again later",
+		"INVALID_FILE":        "The file is not valid",
+
+		"INVALID_CREDENTIALS":   "Invalid email or password",
+		"EMAIL_TAKEN":           "The email is already registered",
+		"OTP_EXPIRED":           "The OTP is incorrect or has expired",
+		"EMAIL_DELIVERY_FAILED": "Failed to send the verification email",
+		"ACCOUNT_FROZEN":        "Your account is frozen, please contact support",
+		"ACCOUNT_DORMANT":       "Your account is dormant after a long time without transactions, reactivate it first",
+		"ACCOUNT_CLOSED":        "Your account has been closed",
+		"ACCOUNT_NOT_DORMANT":   "The account is not dormant",
+		"BALANCE_NOT_ZERO":      "The balance of {balance} must be paid out before the account is closed",
+		"WALLET_NOT_EMPTY":      "Empty your {currency} wallet before closing the account",
+		"PENDING_DEPOSITS":      "Wait until your pending deposits are paid or cancelled before closing the account",
+
+		"ACCOUNT_NOT_FOUND":          "The destination account number is not valid",
+		"INSUFFICIENT_FUNDS":         "Your balance is insufficient",
+		"INVALID_AMOUNT":             "The amount must be greater than zero",
+		"SAME_ACCOUNT":               "You cannot transfer to your own account",
+		"NOTE_TOO_LONG":              "The note must be at most {max} characters",
+		"CATEGORY_TOO_LONG":          "The category must be at most {max} characters",
+		"QUOTE_EXPIRED":              "The transfer quote was not found or has expired",
+		"CURRENCY_NOT_SUPPORTED":     "Currency {currency} is not supported",
+		"WALLET_EXISTS":              "You already have a {currency} wallet",
+		"WALLET_NOT_FOUND":           "You have no {currency} wallet",
+		"RECIPIENT_WALLET_NOT_FOUND": "The recipient has no {currency} wallet",
+		"CURRENCY_MISMATCH":          "The wallets have different currencies, set convert to transfer with a conversion",
+		"SAME_WALLET":                "You cannot transfer to the same wallet",
+		"AMOUNT_TOO_SMALL":           "The amount is too small to convert",
+
+		"AMOUNT_BELOW_MINIMUM":   "The minimum {type} amount is {limit}",
+		"AMOUNT_ABOVE_MAXIMUM":   "The maximum {type} amount is {limit}",
+		"DAILY_LIMIT_EXCEEDED":   "The daily {type} limit of {limit} is exceeded, remaining {remaining}",
+		"MONTHLY_LIMIT_EXCEEDED": "The monthly {type} limit of {limit} is exceeded, remaining {remaining}",
+
+		"KYC_PENDING":           "Your KYC submission is still being reviewed",
+		"KYC_ALREADY_VERIFIED":  "Your account is already verified",
+		"KYC_NOT_SUBMITTED":     "The KYC submission was not found",
+		"KYC_ALREADY_REVIEWED":  "The KYC submission has already been reviewed",
+		"KYC_REASON_REQUIRED":   "A reason is required to reject a submission",
+		"KYC_DOCUMENT_REQUIRED": "The {document} photo is required",
+		"KYC_DOCUMENT_INVALID":  "The {document} photo is not valid",
+		"NIK_TAKEN":             "The NIK is already registered to another account",
+		"INVALID_NIK":           "The NIK must be 16 digits",
+		"INVALID_DATE_OF_BIRTH": "The date of birth must use the YYYY-MM-DD format",
+		"UNDERAGE":              "You must be at least {min_age} years old",
+		"ADDRESS_REQUIRED":      "The address is required",
+
+		"UNSUPPORTED_PAYMENT_TYPE": "The payment type is not supported",
+		"PAYMENT_PROVIDER_ERROR":   "The payment provider could not process the request",
+		"CHATBOT_UNAVAILABLE":      "The assistant cannot answer right now, please try again later",
+
+		"CONVERSATION_NOT_FOUND": "Conversation not found",
+		"CHATBOT_RATE_LIMITED":   "You can send up to {limit} messages a minute, please wait a moment",
+		"CHATBOT_QUOTA_EXCEEDED": "You have used up today's chatbot quota, please come back tomorrow",
+		"FAQ_ARTICLE_NOT_FOUND":  "FAQ article not found",
+		"FAQ_INDEX_FAILED":       "The article could not be indexed for the chatbot, please try again later",
+
+		"USER_NOT_FOUND":      "User not found",
+		"USER_ALREADY_FROZEN": "The account is already frozen",
+		"USER_NOT_FROZEN":     "The account is not frozen",
+		"USER_CLOSED":         "The account is closed, reactivate it first",
+		"USER_NOT_CLOSED":     "The account is neither closed nor dormant",
+		"USER_CHANGED":        "The account was changed in the meantime, reload it and try again",
+		"OWN_ACCOUNT":         "You cannot do this to your own account",
+		"ROLE_NOT_FOUND":      "The role does not exist",
+
+		"field.invalid":         "{field} is not valid",
+		"field.required":        "{field} is required",
+		"field.email":           "{field} must be a valid email address",
+		"field.url":             "{field} must be a valid URL",
+		"field.min":             "{field} must be at least {param} characters",
+		"field.max":             "{field} must be at most {param} characters",
+		"field.len":             "{field} must be {param} characters",
+		"field.numeric":         "{field} must only contain digits",
+		"field.oneof":           "{field} must be one of {param}",
+		"field.phone_id":        "{field} must be an Indonesian mobile number, such as 081234567890",
+		"field.account_number":  "{field} is not a valid account number, please check it for typos",
+		"field.positive_amount": "{field} must be greater than zero",
+	},
+	Indonesian: {
+		"INVALID_REQUEST":     "Permintaan tidak valid",
+		"VALIDATION_FAILED":   "Beberapa isian tidak valid",
+		"INVALID_ID":          "ID tidak valid",
+		"INVALID_DATE":        "Tanggal harus menggunakan format YYYY-MM-DD",
+		"INVALID_PAGINATION":  "page dan pageSize harus lebih dari nol",
+		"INVALID_TOKEN":       "Sesi Anda tidak valid, silakan masuk kembali",
+		"FORBIDDEN":           "Anda tidak diizinkan melakukan ini",
+		"NOT_FOUND":           "Data tidak ditemukan",
+		"METHOD_NOT_ALLOWED":  "Metode tidak diizinkan",
+		"PAYLOAD_TOO_LARGE":   "Permintaan terlalu besar",
+		"TOO_MANY_REQUESTS":   "Terlalu banyak permintaan, silakan coba lagi nanti",
+		"INTERNAL_ERROR":      "Terjadi kesalahan, silakan coba lagi nanti",
+		"SERVICE_UNAVAILABLE": "Fitur ini sementara tidak tersedia, silakan coba lagi nanti",
+		"INVALID_FILE":        "Berkas tidak valid",
+
+		"INVALID_CREDENTIALS":   "Email atau kata sandi salah",
+		"EMAIL_TAKEN":           "Email sudah terdaftar",
+		"OTP_EXPIRED":           "OTP salah atau sudah kedaluwarsa",
+		"EMAIL_DELIVERY_FAILED": "Gagal mengirim email verifikasi",
+		"ACCOUNT_FROZEN":        "Akun Anda dibekukan, silakan hubungi layanan pelanggan",
+		"ACCOUNT_DORMANT":       "Akun Anda tidak aktif karena lama tidak bertransaksi, aktifkan kembali terlebih dahulu",
+		"ACCOUNT_CLOSED":        "Akun Anda telah ditutup",
+		"ACCOUNT_NOT_DORMANT":   "Akun tidak dalam status tidak aktif",
+		"BALANCE_NOT_ZERO":      "Saldo sebesar {balance} harus dicairkan sebelum akun ditutup",
+		"WALLET_NOT_EMPTY":      "Kosongkan dompet {currency} Anda sebelum menutup akun",
+		"PENDING_DEPOSITS":      "Tunggu hingga deposit Anda yang tertunda dibayar atau dibatalkan sebelum menutup akun",
+
+		"ACCOUNT_NOT_FOUND":          "Nomor rekening tujuan tidak valid",
+		"INSUFFICIENT_FUNDS":         "Saldo Anda tidak mencukupi",
+		"INVALID_AMOUNT":             "Nominal harus lebih dari nol",
+		"SAME_ACCOUNT":               "Anda tidak dapat mentransfer ke rekening sendiri",
+		"NOTE_TOO_LONG":              "Catatan maksimal {max} karakter",
+		"CATEGORY_TOO_LONG":          "Kategori maksimal {max} karakter",
+		"QUOTE_EXPIRED":              "Penawaran transfer tidak ditemukan atau sudah kedaluwarsa",
+		"CURRENCY_NOT_SUPPORTED":     "Mata uang {currency} tidak didukung",
+		"WALLET_EXISTS":              "Anda sudah memiliki dompet {currency}",
+		"WALLET_NOT_FOUND":           "Anda tidak memiliki dompet {currency}",
+		"RECIPIENT_WALLET_NOT_FOUND": "Penerima tidak memiliki dompet {currency}",
+		"CURRENCY_MISMATCH":          "Mata uang dompet berbeda, aktifkan convert untuk transfer dengan konversi",
+		"SAME_WALLET":                "Anda tidak dapat mentransfer ke dompet yang sama",
+		"AMOUNT_TOO_SMALL":           "Nominal terlalu kecil untuk dikonversi",
+
+		"AMOUNT_BELOW_MINIMUM":   "Nominal {type} minimal {limit}",
+		"AMOUNT_ABOVE_MAXIMUM":   "Nominal {type} maksimal {limit}",
+		"DAILY_LIMIT_EXCEEDED":   "Batas {type} harian sebesar {limit} terlampaui, sisa {remaining}",
+		"MONTHLY_LIMIT_EXCEEDED": "Batas {type} bulanan sebesar {limit} terlampaui, sisa {remaining}",
+
+		"KYC_PENDING":           "Pengajuan KYC Anda masih ditinjau",
+		"KYC_ALREADY_VERIFIED":  "Akun Anda sudah terverifikasi",
+		"KYC_NOT_SUBMITTED":     "Pengajuan KYC tidak ditemukan",
+		"KYC_ALREADY_REVIEWED":  "Pengajuan KYC sudah ditinjau",
+		"KYC_REASON_REQUIRED":   "Alasan wajib diisi untuk menolak pengajuan",
+		"KYC_DOCUMENT_REQUIRED": "Foto {document} wajib diunggah",
+		"KYC_DOCUMENT_INVALID":  "Foto {document} tidak valid",
+		"NIK_TAKEN":             "NIK sudah terdaftar pada akun lain",
+		"INVALID_NIK":           "NIK harus 16 digit",
+		"INVALID_DATE_OF_BIRTH": "Tanggal lahir harus menggunakan format YYYY-MM-DD",
+		"UNDERAGE":              "Usia Anda minimal {min_age} tahun",
+		"ADDRESS_REQUIRED":      "Alamat wajib diisi",
+
+		"UNSUPPORTED_PAYMENT_TYPE": "Jenis pembayaran tidak didukung",
+		"PAYMENT_PROVIDER_ERROR":   "Penyedia pembayaran tidak dapat memproses permintaan",
+		"CHATBOT_UNAVAILABLE":      "Asisten tidak dapat menjawab saat ini, silakan coba lagi nanti",
+
+		"CONVERSATION_NOT_FOUND": "Percakapan tidak ditemukan",
+		"CHATBOT_RATE_LIMITED":   "Anda dapat mengirim hingga {limit} pesan per menit, mohon tunggu sebentar",
+		"CHATBOT_QUOTA_EXCEEDED": "Kuota chatbot Anda hari ini sudah habis, silakan kembali besok",
+		"FAQ_ARTICLE_NOT_FOUND":  "Artikel FAQ tidak ditemukan",
+		"FAQ_INDEX_FAILED":       "Artikel tidak dapat diindeks untuk chatbot, silakan coba lagi nanti",
+
+		"USER_NOT_FOUND":      "Pengguna tidak ditemukan",
+		"USER_ALREADY_FROZEN": "Akun sudah dibekukan",
+		"USER_NOT_FROZEN":     "Akun tidak sedang dibekukan",
+		"USER_CLOSED":         "Akun telah ditutup, aktifkan kembali terlebih dahulu",
+		"USER_NOT_CLOSED":     "Akun tidak ditutup maupun tidak aktif",
+		"USER_CHANGED":        "Akun telah diubah sementara itu, muat ulang lalu coba lagi",
+		"OWN_ACCOUNT":         "Anda tidak dapat melakukan ini pada akun Anda sendiri",
+		"ROLE_NOT_FOUND":      "Peran tidak ditemukan",
+
+		"field.invalid":         "{field} tidak valid",
+		"field.required":        "{field} wajib diisi",
+		"field.email":           "{field} harus berupa alamat email yang valid",
+		"field.url":             "{field} harus berupa URL yang valid",
+		"field.min":             "{field} minimal {param} karakter",
+		"field.max":             "{field} maksimal {param} karakter",
+		"field.len":             "{field} harus {param} karakter",
+		"field.numeric":         "{field} hanya boleh berisi angka",
+		"field.oneof":           "{field} harus salah satu dari {param}",
+		"field.phone_id":        "{field} harus berupa nomor ponsel Indonesia, seperti 081234567890",
+		"field.account_number":  "{field} bukan nomor rekening yang valid, periksa kembali penulisannya",
+		"field.positive_amount": "{field} harus lebih dari nol",
+	},
+}
@@ -0,0 +1,51 @@
+// Package audit records who changed what. Every row GORM creates, updates or
+// deletes is written to an append-only, hash-chained log along with the
+// actor of the request.
+package audit
+
+import (
+	"context"
+	"strings"
+)
+
+// Actor is the caller behind a change. ID is 0 for changes made by the
+// system, such as commands, scheduled jobs and payment notifications.
+type Actor struct {
+	ID        uint64
+	IP        string
+	RequestID string
+}
+
+type actorKey struct{}
+
+type actionKey struct{}
+
+type action struct {
+	name   string
+	reason string
+}
+
+// NewContext returns a copy of ctx carrying actor.
+func NewContext(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor of ctx, the system when there is none.
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+// WithAction names the changes made with ctx after what the user did, such
+// as "user.freeze", instead of create, update or delete, and keeps the reason
+// given for them.
+func WithAction(ctx context.Context, name string, reason string) context.Context {
+	return context.WithValue(ctx, actionKey{}, action{name: name, reason: strings.TrimSpace(reason)})
+}
+
+func actionFromContext(ctx context.Context, fallback string) (string, string) {
+	if action, ok := ctx.Value(actionKey{}).(action); ok {
+		return action.name, action.reason
+	}
+	return fallback, ""
+}
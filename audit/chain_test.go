@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+)
+
+func TestHash(t *testing.T) {
+	log := entity.AuditLog{
+		ID:        7,
+		ActorID:   1,
+		Action:    ActionUpdate,
+		Entity:    "users",
+		EntityID:  "2",
+		Before:    `{"status":1}`,
+		After:     `{"status":3}`,
+		Reason:    "Suspicious activity",
+		IP:        "10.0.0.1",
+		RequestID: "req-1",
+		CreatedAt: 1700000000,
+		PrevHash:  "previous",
+	}
+	hash := Hash(log)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, Hash(log))
+
+	stored := log
+	stored.ID = 8
+	stored.Hash = hash
+	assert.Equal(t, hash, Hash(stored), "the ID and the hash itself are not hashed")
+
+	changes := map[string]func(log *entity.AuditLog){
+		"PrevHash":  func(log *entity.AuditLog) { log.PrevHash = "other" },
+		"ActorID":   func(log *entity.AuditLog) { log.ActorID = 2 },
+		"Action":    func(log *entity.AuditLog) { log.Action = ActionDelete },
+		"Entity":    func(log *entity.AuditLog) { log.Entity = "transactions" },
+		"EntityID":  func(log *entity.AuditLog) { log.EntityID = "3" },
+		"Before":    func(log *entity.AuditLog) { log.Before = `{"status":2}` },
+		"After":     func(log *entity.AuditLog) { log.After = `{"status":1}` },
+		"Reason":    func(log *entity.AuditLog) { log.Reason = "" },
+		"IP":        func(log *entity.AuditLog) { log.IP = "10.0.0.2" },
+		"RequestID": func(log *entity.AuditLog) { log.RequestID = "req-2" },
+		"CreatedAt": func(log *entity.AuditLog) { log.CreatedAt++ },
+	}
+	for field, change := range changes {
+		t.Run(field, func(t *testing.T) {
+			altered := log
+			change(&altered)
+			assert.NotEqual(t, hash, Hash(altered))
+		})
+	}
+}
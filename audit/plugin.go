@@ -0,0 +1,278 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+
+	beforeKey = "selfbank:audit_before"
+	redacted  = "[REDACTED]"
+)
+
+// untrackedTables are not audited: the log itself, the migrations, and
+// chatbot data that changes with every message and holds no account state.
+var untrackedTables = map[string]bool{
+	"audit_logs":         true,
+	"audit_heads":        true,
+	"schema_migrations":  true,
+	"chat_conversations": true,
+	"chat_messages":      true,
+	"chat_usages":        true,
+	"faq_chunks":         true,
+}
+
+// Plugin writes an audit log for every row created, updated or deleted
+// through GORM. The log is written in the transaction of the change, and a
+// change whose log cannot be written is rolled back.
+type Plugin struct{}
+
+func NewPlugin() Plugin {
+	return Plugin{}
+}
+
+func (p Plugin) Name() string {
+	return "selfbank:audit"
+}
+
+func (p Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("selfbank:audit_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:begin_transaction").Before("gorm:update").Register("selfbank:audit_before_update", p.loadBefore); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("selfbank:audit_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:begin_transaction").Before("gorm:delete").Register("selfbank:audit_before_delete", p.loadBefore); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("selfbank:audit_delete", p.afterDelete)
+}
+
+func (p Plugin) afterCreate(db *gorm.DB) {
+	if !tracked(db) {
+		return
+	}
+
+	var logs []entity.AuditLog
+	for _, row := range rowsOf(db.Statement, db.Statement.ReflectValue) {
+		logs = append(logs, newLog(db.Statement, ActionCreate, row, nil, row))
+	}
+	p.append(db, logs)
+}
+
+// loadBefore reads, and locks, the rows the statement is about to change.
+func (p Plugin) loadBefore(db *gorm.DB) {
+	if !tracked(db) {
+		return
+	}
+
+	query, ok := affectedRows(db)
+	if !ok {
+		return
+	}
+	rows, err := findRows(query.Clauses(clause.Locking{Strength: "UPDATE"}), db.Statement)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(beforeKey, rows)
+}
+
+func (p Plugin) afterUpdate(db *gorm.DB) {
+	before, ok := loadedBefore(db)
+	if !ok || len(before) == 0 {
+		return
+	}
+
+	primaryKey := db.Statement.Schema.PrioritizedPrimaryField
+	ids := make([]interface{}, 0, len(before))
+	for _, row := range before {
+		ids = append(ids, row[primaryKey.DBName])
+	}
+	query := db.Session(&gorm.Session{NewDB: true}).Table(db.Statement.Table).Where(clause.IN{Column: clause.Column{Name: primaryKey.DBName}, Values: ids})
+	after, err := findRows(query, db.Statement)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	afterByID := map[string]map[string]interface{}{}
+	for _, row := range after {
+		afterByID[fmt.Sprint(row[primaryKey.DBName])] = row
+	}
+
+	var logs []entity.AuditLog
+	for _, row := range before {
+		changedBefore, changedAfter := diff(row, afterByID[fmt.Sprint(row[primaryKey.DBName])])
+		if len(changedAfter) == 0 {
+			continue
+		}
+		logs = append(logs, newLog(db.Statement, ActionUpdate, row, changedBefore, changedAfter))
+	}
+	p.append(db, logs)
+}
+
+func (p Plugin) afterDelete(db *gorm.DB) {
+	before, ok := loadedBefore(db)
+	if !ok || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	var logs []entity.AuditLog
+	for _, row := range before {
+		logs = append(logs, newLog(db.Statement, ActionDelete, row, row, nil))
+	}
+	p.append(db, logs)
+}
+
+func (p Plugin) append(db *gorm.DB, logs []entity.AuditLog) {
+	if err := Append(db.Session(&gorm.Session{NewDB: true}), logs); err != nil {
+		db.AddError(fmt.Errorf("Failed to write the audit log: %w", err))
+	}
+}
+
+func tracked(db *gorm.DB) bool {
+	statement := db.Statement
+	return db.Error == nil && statement.Schema != nil && statement.Schema.PrioritizedPrimaryField != nil && !untrackedTables[statement.Table]
+}
+
+func loadedBefore(db *gorm.DB) ([]map[string]interface{}, bool) {
+	if db.Error != nil {
+		return nil, false
+	}
+	rows, ok := db.InstanceGet(beforeKey)
+	if !ok {
+		return nil, false
+	}
+	return rows.([]map[string]interface{}), true
+}
+
+// affectedRows builds a query for the rows matching the statement: its where
+// clause, and the primary key of the model when it is set, as Save and
+// Delete with a model add it later on.
+func affectedRows(db *gorm.DB) (*gorm.DB, bool) {
+	statement := db.Statement
+	query := db.Session(&gorm.Session{NewDB: true}).Table(statement.Table)
+	conditions := false
+
+	if where, ok := statement.Clauses["WHERE"].Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+		query = query.Clauses(where)
+		conditions = true
+	}
+
+	if statement.ReflectValue.Kind() == reflect.Struct {
+		for _, field := range statement.Schema.PrimaryFields {
+			if value, zero := field.ValueOf(statement.Context, statement.ReflectValue); !zero {
+				query = query.Where(clause.Eq{Column: clause.Column{Name: field.DBName}, Value: value})
+				conditions = true
+			}
+		}
+	}
+	return query, conditions
+}
+
+// findRows loads the rows of query as column values of the statement's
+// model.
+func findRows(query *gorm.DB, statement *gorm.Statement) ([]map[string]interface{}, error) {
+	models := reflect.New(reflect.SliceOf(statement.Schema.ModelType))
+	if err := query.Find(models.Interface()).Error; err != nil {
+		return nil, err
+	}
+	return rowsOf(statement, models.Elem()), nil
+}
+
+// rowsOf turns a model or a slice of models into column values.
+func rowsOf(statement *gorm.Statement, value reflect.Value) []map[string]interface{} {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		rows := make([]map[string]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			rows = append(rows, rowsOf(statement, value.Index(i))...)
+		}
+		return rows
+	case reflect.Struct:
+		return []map[string]interface{}{columns(statement, statement.Schema.Fields, value)}
+	}
+	return nil
+}
+
+func columns(statement *gorm.Statement, fields []*schema.Field, value reflect.Value) map[string]interface{} {
+	row := map[string]interface{}{}
+	for _, field := range fields {
+		if field.DBName == "" {
+			continue
+		}
+		fieldValue, _ := field.ValueOf(statement.Context, value)
+		if valuer, ok := fieldValue.(driver.Valuer); ok {
+			fieldValue, _ = valuer.Value()
+		}
+		row[field.DBName] = fieldValue
+	}
+	return row
+}
+
+// diff returns the columns whose value changed, as they were and as they
+// are.
+func diff(before map[string]interface{}, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for column, value := range after {
+		old, _ := json.Marshal(before[column])
+		current, _ := json.Marshal(value)
+		if string(old) != string(current) {
+			changedBefore[column] = before[column]
+			changedAfter[column] = value
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+func newLog(statement *gorm.Statement, fallbackAction string, row map[string]interface{}, before map[string]interface{}, after map[string]interface{}) entity.AuditLog {
+	actor := ActorFromContext(statement.Context)
+	action, reason := actionFromContext(statement.Context, fallbackAction)
+	return entity.AuditLog{
+		ActorID:   actor.ID,
+		Action:    action,
+		Entity:    statement.Table,
+		EntityID:  fmt.Sprint(row[statement.Schema.PrioritizedPrimaryField.DBName]),
+		Before:    encode(before),
+		After:     encode(after),
+		Reason:    reason,
+		IP:        actor.IP,
+		RequestID: actor.RequestID,
+	}
+}
+
+// encode marshals the columns, hiding passwords, tokens and other secrets.
+// The log shows that they changed, not what they are.
+func encode(row map[string]interface{}) string {
+	if row == nil {
+		return ""
+	}
+	shown := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		if logging.IsSensitive(column) {
+			value = redacted
+		}
+		shown[column] = value
+	}
+	encoded, _ := json.Marshal(shown)
+	return string(encoded)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the audit log",
+}
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that no audit log was altered or removed, exits with 1 when one was",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verification, err := container.Services().Audit.Verify(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if !verification.Valid {
+			return fmt.Errorf("Audit log broken at log %d after %d valid logs: %s", verification.BrokenAt, verification.Checked, verification.Problem)
+		}
+		cmd.Printf("Audit log is intact, %d logs checked\n", verification.Checked)
+		return nil
+	},
+}
+
+func init() {
+	auditCmd.AddCommand(auditVerifyCmd)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var faqCmd = &cobra.Command{
+	Use:   "faq",
+	Short: "Manage the chatbot knowledge base",
+}
+
+var faqReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Embed every FAQ article again, run it after changing the embedding model",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		indexed, err := container.Services().Faq.Reindex(cmd.Context())
+		if err != nil {
+			cmd.Printf("Reindexed %d articles before failing\n", indexed)
+			return err
+		}
+		cmd.Printf("Reindexed %d articles\n", indexed)
+		return nil
+	},
+}
+
+func init() {
+	faqCmd.AddCommand(faqReindexCmd)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var reconcileUser uint64
+
+var ledgerCmd = &cobra.Command{
+	Use:   "ledger",
+	Short: "Inspect the ledger",
+}
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Check balances and fee postings, exits with 1 when something is off",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issues, err := container.Services().Ledger.Reconcile(cmd.Context(), reconcileUser)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			cmd.Printf("user %d  %-16s %s\n", issue.IDUser, issue.Check, issue.Message)
+		}
+		if len(issues) > 0 {
+			return fmt.Errorf("%d ledger issues found", len(issues))
+		}
+		cmd.Println("Ledger is consistent")
+		return nil
+	},
+}
+
+func init() {
+	reconcileCmd.Flags().Uint64Var(&reconcileUser, "user", 0, "only reconcile this user id")
+	ledgerCmd.AddCommand(reconcileCmd)
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/IrvanWijayaSardam/SelfBank/config"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/migration"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply or roll back database migrations",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up [steps]",
+	Short: "Apply pending migrations, all of them by default",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps, err := parseSteps(args, 0)
+		if err != nil {
+			return err
+		}
+		migrator, err := migration.NewMigrator(migrationDB(), migration.All)
+		if err != nil {
+			return err
+		}
+
+		done, err := migrator.Up(steps)
+		for _, m := range done {
+			cmd.Printf("applied     %d %s\n", m.Version, m.Name)
+		}
+		if err == nil && len(done) == 0 {
+			cmd.Println("Nothing to migrate")
+		}
+		return err
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [steps]",
+	Short: "Roll back the latest migrations, one by default",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps, err := parseSteps(args, 1)
+		if err != nil {
+			return err
+		}
+		migrator, err := migration.NewMigrator(migrationDB(), migration.All)
+		if err != nil {
+			return err
+		}
+
+		done, err := migrator.Down(steps)
+		for _, m := range done {
+			cmd.Printf("rolled back %d %s\n", m.Version, m.Name)
+		}
+		return err
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List migrations and whether they are applied",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := migration.NewMigrator(migrationDB(), migration.All)
+		if err != nil {
+			return err
+		}
+
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + helper.ConvertUnixtime(status.AppliedAt).Format("2006-01-02 15:04:05")
+			}
+			cmd.Printf("%4d %-30s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd)
+}
+
+func parseSteps(args []string, fallback int) (int, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps < 0 {
+		return 0, fmt.Errorf("steps must be a positive number, got %q", args[0])
+	}
+	return steps, nil
+}
+
+// warnPendingMigrations reminds whoever starts the API that the schema is
+// behind, since it is no longer migrated on boot.
+func warnPendingMigrations() {
+	migrator, err := migration.NewMigrator(migrationDB(), migration.All)
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		logrus.Error("Failed to check migrations: ", err)
+		return
+	}
+	if pending > 0 {
+		logrus.Warnf("%d migrations are pending, run \"selfbank migrate up\"", pending)
+	}
+}
+
+// migrationDB lifts the query timeout, altering a large table takes longer
+// than any API query should.
+func migrationDB() *gorm.DB {
+	return container.DB().WithContext(config.WithoutQueryTimeout(context.Background()))
+}
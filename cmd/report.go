@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+	"github.com/spf13/cobra"
+)
+
+const reportPageSize = 100
+
+var (
+	reportType   string
+	reportUser   uint64
+	reportOut    string
+	reportFormat string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports",
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export transactions, deposits or withdrawals to a file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reportFormat != "pdf" {
+			return fmt.Errorf("Unsupported format %q, only pdf is available", reportFormat)
+		}
+
+		services := container.Services()
+
+		var pdf *bytes.Buffer
+		var count int
+		var err error
+		switch reportType {
+		case "transactions":
+			pdf, count, err = exportTransactions(cmd.Context(), services.Transaction)
+		case "deposits":
+			pdf, count, err = exportDeposits(cmd.Context(), services.Deposit)
+		case "withdrawals":
+			pdf, count, err = exportWithdrawals(cmd.Context(), services.Withdrawal)
+		default:
+			return fmt.Errorf("Unknown report type %q, use transactions, deposits or withdrawals", reportType)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(reportOut, pdf.Bytes(), 0644); err != nil {
+			return err
+		}
+		cmd.Printf("Exported %d %s to %s\n", count, reportType, reportOut)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&reportType, "type", "", "transactions, deposits or withdrawals")
+	exportCmd.Flags().Uint64Var(&reportUser, "user", 0, "only export this user id")
+	exportCmd.Flags().StringVar(&reportOut, "out", "", "file to write")
+	exportCmd.Flags().StringVar(&reportFormat, "format", "pdf", "output format")
+	exportCmd.MarkFlagRequired("type")
+	exportCmd.MarkFlagRequired("out")
+	reportCmd.AddCommand(exportCmd)
+}
+
+// fetchAll keeps requesting pages until one comes back short.
+func fetchAll[T any](fetch func(page int, pageSize int) ([]T, error)) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		rows, err := fetch(page, reportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, rows...)
+		if len(rows) < reportPageSize {
+			return all, nil
+		}
+	}
+}
+
+func exportTransactions(ctx context.Context, transactionService service.TransactionService) (*bytes.Buffer, int, error) {
+	transactions, err := fetchAll(func(page int, pageSize int) ([]entity.Transaction, error) {
+		if reportUser != 0 {
+			return transactionService.FindTransactionByIDUser(ctx, reportUser, page, pageSize)
+		}
+		return transactionService.All(ctx, page, pageSize)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pdf, err := transactionService.GenerateTransactionPDF(transactions)
+	return pdf, len(transactions), err
+}
+
+func exportWithdrawals(ctx context.Context, withdrawalService service.WithdrawalService) (*bytes.Buffer, int, error) {
+	withdrawals, err := fetchAll(func(page int, pageSize int) ([]entity.Withdrawal, error) {
+		if reportUser != 0 {
+			return withdrawalService.FindWithdrawalByIDUser(ctx, reportUser, page, pageSize)
+		}
+		return withdrawalService.All(ctx, page, pageSize)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pdf, err := withdrawalService.GenerateWithdrawalPDF(withdrawals)
+	return pdf, len(withdrawals), err
+}
+
+func exportDeposits(ctx context.Context, depositService service.DepositService) (*bytes.Buffer, int, error) {
+	deposits, err := fetchAll(func(page int, pageSize int) ([]entity.Deposit, error) {
+		if reportUser != 0 {
+			return depositService.FindDepositByIDUser(ctx, reportUser, page, pageSize)
+		}
+		return depositService.All(ctx, page, pageSize)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var depositResponses []dto.DepositResponse
+	for _, deposit := range deposits {
+		depositResponse := dto.DepositResponse{
+			Id_deposit: deposit.ID,
+			Id_user:    deposit.ID_User,
+			Amount:     deposit.Amount,
+			Fee:        deposit.Fee,
+			Status:     depositStatus(deposit.Status),
+			Date:       helper.ConvertUnixtime(deposit.Date).Format("2006-01-02 15:04:05"),
+		}
+		if paymentInfo := depositService.FindPaymentInfoById(ctx, deposit.ID); paymentInfo != nil {
+			depositResponse.Virtual_account = paymentInfo.VirtualAcc
+			depositResponse.Url_callback = paymentInfo.CallbackUrl
+		}
+		depositResponses = append(depositResponses, depositResponse)
+	}
+
+	pdf, err := depositService.GenerateDepositPDF(depositResponses)
+	return pdf, len(deposits), err
+}
+
+func depositStatus(status uint64) string {
+	switch status {
+	case 2:
+		return "Pending"
+	case 3:
+		return "Cancelled"
+	case 4:
+		return "Denied"
+	case 5:
+		return "Paid"
+	default:
+		return "Created"
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/app"
+	"github.com/IrvanWijayaSardam/SelfBank/config"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+	"github.com/IrvanWijayaSardam/SelfBank/tracing"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var configFile string
+
+// container is shared by all commands so they wire services the same way
+// the API does. It is built once the configuration is loaded.
+var container *app.Container
+
+// shutdownTracing flushes the spans still buffered when the command ends.
+var shutdownTracing func(context.Context) error
+
+// tracingFlushTimeout bounds how long exiting waits for the collector.
+const tracingFlushTimeout = 5 * time.Second
+
+var rootCmd = &cobra.Command{
+	Use:          "selfbank",
+	Short:        "SelfBank API server and operations tools",
+	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(configFile)
+		if err != nil {
+			return err
+		}
+		logging.Setup(cfg.Profile)
+		shutdownTracing, err = tracing.Setup(cmd.Context(), cfg.Tracing, cfg.Profile)
+		if err != nil {
+			return err
+		}
+		container = app.NewContainer(cfg)
+		return nil
+	},
+}
+
+// Execute runs the command given on the command line and closes whatever
+// connections it opened.
+func Execute() error {
+	defer func() {
+		if container != nil {
+			container.Close()
+		}
+		if shutdownTracing != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				logrus.WithError(err).Warn("Failed to flush traces")
+			}
+		}
+	}()
+	return rootCmd.Execute()
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", os.Getenv("CONFIG_FILE"), "YAML config file, CONFIG_FILE by default")
+	rootCmd.AddCommand(serveCmd, migrateCmd, seedCmd, userCmd, ledgerCmd, reportCmd, faqCmd, auditCmd)
+}
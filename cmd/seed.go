@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/IrvanWijayaSardam/SelfBank/migration"
+	"github.com/spf13/cobra"
+)
+
+var seedEmail, seedPassword string
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Create the first admin account, ADMIN_EMAIL and ADMIN_PASSWORD by default",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		admin := container.Config().Admin
+		if seedEmail == "" {
+			seedEmail = admin.Email
+		}
+		if seedPassword == "" {
+			seedPassword = admin.Password
+		}
+
+		created, err := migration.SeedAdmin(container.DB(), seedEmail, seedPassword, container.Config().Accounts.NumberPrefix)
+		if err != nil {
+			return err
+		}
+		if created {
+			cmd.Println("Admin account created")
+		} else {
+			cmd.Println("Admin account already exists")
+		}
+		return nil
+	},
+}
+
+func init() {
+	seedCmd.Flags().StringVar(&seedEmail, "email", "", "admin email, ADMIN_EMAIL when empty")
+	seedCmd.Flags().StringVar(&seedPassword, "password", "", "admin password, ADMIN_PASSWORD when empty")
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/config"
+	"github.com/IrvanWijayaSardam/SelfBank/controller"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
+	"github.com/IrvanWijayaSardam/SelfBank/middleware"
+	"github.com/IrvanWijayaSardam/SelfBank/routes"
+	"github.com/IrvanWijayaSardam/SelfBank/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the HTTP API",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		warnPendingMigrations()
+
+		services := container.Services()
+		blobStore, urlSigner := container.BlobStore()
+
+		e := echo.New()
+		e.Debug = container.Config().Profile == config.ProfileDev
+		e.HideBanner = true
+		e.HTTPErrorHandler = controller.HTTPErrorHandler
+		e.Validator = validation.New()
+		e.Use(middleware.Tracing(container.Config().Tracing.ServiceName), middleware.RequestID(), middleware.RequestLogger(), middleware.Metrics())
+
+		sqlDB, err := container.DB().DB()
+		if err != nil {
+			return err
+		}
+		metrics.RegisterDBStats(sqlDB)
+		metrics.RegisterRedisStats(container.Redis())
+		jwtMiddleware := middleware.AuthorizeJWT(services.JWT, services.User)
+		activeAccount := middleware.ActiveAccount(services.Account)
+
+		authController := controller.NewAuthController(services.Auth, services.JWT)
+		depositController := controller.NewDepositController(services.Deposit, services.FeeLimit, services.JWT, container.Config().Midtrans)
+		withdrawalController := controller.NewWithdrawalController(services.Withdrawal, services.User, services.FeeLimit, services.JWT)
+		userController := controller.NewUserController(services.User, services.MediaUpload, services.JWT)
+		transactionController := controller.NewTransactionController(services.Transaction, services.User, services.FeeLimit, services.JWT)
+		chatbotController := controller.NewChatbotController(services.Chatbot, services.JWT)
+		faqController := controller.NewFaqController(services.Faq, services.JWT)
+		verificationController := controller.NewVerificationController(services.Verification, services.JWT)
+		feeLimitController := controller.NewFeeLimitController(services.FeeLimit, services.JWT)
+		kycController := controller.NewKycController(services.Kyc, services.JWT)
+		auditController := controller.NewAuditController(services.Audit, services.JWT)
+		accountController := controller.NewAccountController(services.Account, services.JWT)
+		adminController := controller.NewAdminController(services.Admin, services.User, services.Kyc, services.Wallet, services.Transaction, services.Deposit, services.Withdrawal, services.JWT)
+		fileController := controller.NewFileController(blobStore, urlSigner)
+		walletController := controller.NewWalletController(services.Wallet, services.JWT)
+		healthController := controller.NewHealthController(container.HealthChecker())
+
+		routes.RegisterRoutes(e, services.JWT, authController)
+		routes.DepositRoutes(e, services.Deposit, depositController, jwtMiddleware, activeAccount)
+		routes.MidtransRoutes(e, services.Deposit, depositController, jwtMiddleware)
+		routes.WithdrawalRoutes(e, services.Withdrawal, withdrawalController, jwtMiddleware, activeAccount)
+		routes.UserRoutes(e, services.User, userController, jwtMiddleware)
+		routes.ProfileRoutes(e, services.User, userController, jwtMiddleware)
+		routes.TransactionRoutes(e, services.Transaction, transactionController, jwtMiddleware, activeAccount)
+		routes.ImageRoutes(e, userController, jwtMiddleware)
+		routes.ChatbotRoutes(e, chatbotController, jwtMiddleware)
+		routes.FaqRoutes(e, faqController, jwtMiddleware)
+		routes.VerificationRoutes(e, services.Verification, verificationController, jwtMiddleware)
+		routes.FeeLimitRoutes(e, feeLimitController, jwtMiddleware)
+		routes.KycRoutes(e, kycController, jwtMiddleware)
+		routes.AccountRoutes(e, accountController, jwtMiddleware)
+		routes.AdminRoutes(e, adminController, jwtMiddleware)
+		routes.AuditRoutes(e, auditController, jwtMiddleware)
+		routes.HealthRoutes(e, healthController)
+		routes.MetricsRoutes(e, container.Config().Server.MetricsToken)
+		routes.FileRoutes(e, fileController)
+		routes.WalletRoutes(e, walletController, jwtMiddleware, activeAccount)
+
+		if accounts := container.Config().Accounts; accounts.DormantAfter > 0 {
+			container.Go(func(ctx context.Context) {
+				markDormantAccounts(ctx, accounts)
+			})
+		}
+
+		logrus.Print(helper.GetCurrentTimeInLocation())
+		addr := serveAddr
+		if addr == "" {
+			addr = container.Config().Server.Addr
+		}
+		return run(e, addr)
+	},
+}
+
+// run serves until SIGINT or SIGTERM, then stops accepting connections and
+// gives in-flight requests and workers the shutdown timeout to finish.
+func run(e *echo.Echo, addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- e.Start(addr)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	logrus.Info("Shutting down, draining in-flight requests")
+	container.HealthChecker().Drain()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), container.Config().Server.ShutdownTimeout)
+	defer cancel()
+
+	err := e.Shutdown(shutdownCtx)
+	if workerErr := container.Shutdown(shutdownCtx); err == nil {
+		err = workerErr
+	}
+	if err != nil {
+		return err
+	}
+
+	logrus.Info("Shutdown complete")
+	return nil
+}
+
+// markDormantAccounts runs the dormancy job every interval until ctx is
+// done. Running it on several instances at once is harmless.
+func markDormantAccounts(ctx context.Context, accounts config.AccountsConfig) {
+	ticker := time.NewTicker(accounts.DormancyInterval)
+	defer ticker.Stop()
+
+	for {
+		marked, err := container.Services().Account.MarkDormant(ctx, accounts.DormantAfter)
+		if err != nil && ctx.Err() == nil {
+			logrus.Error("Failed to mark dormant accounts: ", err)
+		} else if marked > 0 {
+			logrus.Info(marked, " accounts marked dormant")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "", "address the API listens on, SERVER_ADDR by default")
+}
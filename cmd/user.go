@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/migration"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var userEmail, userPassword string
+
+var dormantAfter time.Duration
+
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage user accounts",
+}
+
+var createAdminCmd = &cobra.Command{
+	Use:   "create-admin",
+	Short: "Create an admin account",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		created, err := migration.SeedAdmin(container.DB(), userEmail, userPassword, container.Config().Accounts.NumberPrefix)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return fmt.Errorf("A user with email %s already exists", userEmail)
+		}
+		cmd.Printf("Admin %s created\n", userEmail)
+		return nil
+	},
+}
+
+var resetPasswordCmd = &cobra.Command{
+	Use:   "reset-password",
+	Short: "Set a new password, a random one is generated when --password is empty",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		userRepository := container.Repositories().User
+		user, err := userRepository.FindByEmailAnyStatus(cmd.Context(), userEmail)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("No user with email %s", userEmail)
+		}
+		if err != nil {
+			return err
+		}
+
+		password := userPassword
+		generated := password == ""
+		if generated {
+			if password, err = helper.GenerateRandomPassword(); err != nil {
+				return err
+			}
+		}
+
+		user.Password = helper.HashAndSalt([]byte(password))
+		if err := userRepository.SaveUser(cmd.Context(), user); err != nil {
+			return fmt.Errorf("Failed to save the password of %s: %w", userEmail, err)
+		}
+
+		if generated {
+			cmd.Printf("Password of %s reset to %s\n", userEmail, password)
+		} else {
+			cmd.Printf("Password of %s reset\n", userEmail)
+		}
+		return nil
+	},
+}
+
+var markDormantCmd = &cobra.Command{
+	Use:   "mark-dormant",
+	Short: "Mark customers without activity as dormant, like the API does every ACCOUNT_DORMANCY_INTERVAL",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		after := dormantAfter
+		if after == 0 {
+			after = container.Config().Accounts.DormantAfter
+		}
+		if after <= 0 {
+			return fmt.Errorf("Dormancy is turned off, pass --after")
+		}
+
+		marked, err := container.Services().Account.MarkDormant(cmd.Context(), after)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("%d accounts marked dormant\n", marked)
+		return nil
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{createAdminCmd, resetPasswordCmd} {
+		c.Flags().StringVar(&userEmail, "email", "", "account email")
+		c.Flags().StringVar(&userPassword, "password", "", "new password")
+		c.MarkFlagRequired("email")
+	}
+	markDormantCmd.Flags().DurationVar(&dormantAfter, "after", 0, "time without activity, ACCOUNT_DORMANT_AFTER by default")
+	userCmd.AddCommand(createAdminCmd, resetPasswordCmd, markDormantCmd)
+}
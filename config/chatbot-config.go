@@ -0,0 +1,51 @@
+package config
+
+import (
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/IrvanWijayaSardam/SelfBank/llm"
+)
+
+// SetupLLMProvider builds the chat model selected by the chatbot provider,
+// retrying the requests that failed for a passing reason. Requests to OpenAI
+// and compatible servers go through httpClient.
+func SetupLLMProvider(cfg ChatbotConfig, openAI OpenAIConfig, httpClient *http.Client) llm.Provider {
+	options := llm.Options{
+		Model:          cfg.Model,
+		EmbeddingModel: cfg.EmbeddingModel,
+		Temperature:    float32(cfg.Temperature),
+		Timeout:        cfg.RequestTimeout,
+	}
+
+	var provider llm.Provider
+	switch cfg.Provider {
+	case ChatbotFake:
+		var rules []llm.FakeRule
+		if cfg.FakeScript != "" {
+			var err error
+			rules, err = llm.LoadFakeRules(cfg.FakeScript)
+			if err != nil {
+				panic("Failed to load the fake chatbot script: " + err.Error())
+			}
+		}
+		return llm.NewFakeProvider(rules)
+	case ChatbotCompatible:
+		clientConfig := openai.DefaultConfig(openAI.Key)
+		clientConfig.BaseURL = cfg.BaseURL
+		clientConfig.HTTPClient = httpClient
+		provider = llm.NewOpenAIProvider(openai.NewClientWithConfig(clientConfig), options)
+	default:
+		clientConfig := openai.DefaultConfig(openAI.Key)
+		clientConfig.HTTPClient = httpClient
+		provider = llm.NewOpenAIProvider(openai.NewClientWithConfig(clientConfig), options)
+	}
+	return llm.WithRetry(provider, cfg.MaxRetries, cfg.RetryBackoff)
+}
+
+func supportedEmbeddingModel(name string) bool {
+	var model openai.EmbeddingModel
+	model.UnmarshalText([]byte(name))
+	return model != openai.Unknown
+}
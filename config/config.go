@@ -0,0 +1,462 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/midtrans/midtrans-go"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+)
+
+const (
+	ProfileDev     = "dev"
+	ProfileStaging = "staging"
+	ProfileProd    = "prod"
+
+	MidtransSandbox    = "sandbox"
+	MidtransProduction = "production"
+)
+
+// Config holds every setting of the application. It is loaded once by Load
+// and handed to the constructors that need it.
+type Config struct {
+	Profile  string         `yaml:"profile" env:"APP_ENV"`
+	BaseURL  string         `yaml:"base_url" env:"BASE_URL"`
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Redis    RedisConfig    `yaml:"redis"`
+	JWT      JWTConfig      `yaml:"jwt"`
+	SMTP     SMTPConfig     `yaml:"smtp"`
+	Midtrans MidtransConfig `yaml:"midtrans"`
+	OpenAI   OpenAIConfig   `yaml:"openai"`
+	Chatbot  ChatbotConfig  `yaml:"chatbot"`
+	Storage  StorageConfig  `yaml:"storage"`
+	FX       FXConfig       `yaml:"fx"`
+	Admin    AdminConfig    `yaml:"admin"`
+	Accounts AccountsConfig `yaml:"accounts"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+}
+
+type ServerConfig struct {
+	Addr string `yaml:"addr" env:"SERVER_ADDR"`
+	// ShutdownTimeout bounds how long in-flight requests and workers may
+	// take to finish once a stop signal arrives.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	// MetricsToken protects /metrics with a bearer token when set.
+	MetricsToken string `yaml:"metrics_token" env:"METRICS_TOKEN"`
+}
+
+type DatabaseConfig struct {
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASS"`
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" env:"DB_PORT"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	// QueryTimeout bounds each statement, on top of the request context.
+	QueryTimeout time.Duration `yaml:"query_timeout" env:"DB_QUERY_TIMEOUT"`
+}
+
+type RedisConfig struct {
+	Host     string `yaml:"host" env:"REDIS_HOST"`
+	Port     int    `yaml:"port" env:"REDIS_PORT"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db" env:"REDIS_DB"`
+}
+
+type JWTConfig struct {
+	Secret string `yaml:"secret" env:"JWT_SECRET"`
+	Issuer string `yaml:"issuer" env:"JWT_ISSUER"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     int    `yaml:"port" env:"SMTP_PORT"`
+	Mail     string `yaml:"mail" env:"SMTP_MAIL"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+}
+
+type MidtransConfig struct {
+	ServerKey   string `yaml:"server_key" env:"MT_SERVER_KEY"`
+	ClientKey   string `yaml:"client_key" env:"MT_CLIENT_KEY"`
+	Environment string `yaml:"environment" env:"MT_ENVIRONMENT"`
+}
+
+type OpenAIConfig struct {
+	Key string `yaml:"key" env:"OPEN_AI_KEY"`
+}
+
+const (
+	ChatbotOpenAI     = "openai"
+	ChatbotCompatible = "compatible"
+	ChatbotFake       = "fake"
+)
+
+// ChatbotConfig selects the chat model. Provider is openai, compatible for a
+// self-hosted server with an OpenAI compatible API reached at BaseURL, or
+// fake for scripted answers read from FakeScript.
+type ChatbotConfig struct {
+	Provider string `yaml:"provider" env:"CHATBOT_PROVIDER"`
+	BaseURL  string `yaml:"base_url" env:"CHATBOT_BASE_URL"`
+	Model    string `yaml:"model" env:"CHATBOT_MODEL"`
+	// EmbeddingModel indexes the FAQ articles. It must be a model the
+	// OpenAI client knows, self-hosted servers have to serve it under
+	// that name.
+	EmbeddingModel string  `yaml:"embedding_model" env:"CHATBOT_EMBEDDING_MODEL"`
+	Temperature    float64 `yaml:"temperature" env:"CHATBOT_TEMPERATURE"`
+	// RequestTimeout bounds a single request to the model, ReplyTimeout a
+	// whole reply with its tool calls and retries.
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"CHATBOT_REQUEST_TIMEOUT"`
+	ReplyTimeout   time.Duration `yaml:"reply_timeout" env:"CHATBOT_REPLY_TIMEOUT"`
+	MaxRetries     int           `yaml:"max_retries" env:"CHATBOT_MAX_RETRIES"`
+	RetryBackoff   time.Duration `yaml:"retry_backoff" env:"CHATBOT_RETRY_BACKOFF"`
+	FakeScript     string        `yaml:"fake_script" env:"CHATBOT_FAKE_SCRIPT"`
+	// RequestsPerMinute and DailyTokens are the quotas of each user, 0
+	// turns them off.
+	RequestsPerMinute int `yaml:"requests_per_minute" env:"CHATBOT_REQUESTS_PER_MINUTE"`
+	DailyTokens       int `yaml:"daily_tokens" env:"CHATBOT_DAILY_TOKENS"`
+	// FaqMinScore is the similarity a FAQ passage needs to be given to the
+	// model. Good values depend on the embedding model.
+	FaqMinScore float64 `yaml:"faq_min_score" env:"CHATBOT_FAQ_MIN_SCORE"`
+}
+
+type StorageConfig struct {
+	Driver     string           `yaml:"driver" env:"STORAGE_DRIVER"`
+	LocalRoot  string           `yaml:"local_root" env:"STORAGE_LOCAL_ROOT"`
+	SigningKey string           `yaml:"signing_key" env:"STORAGE_SIGNING_KEY"`
+	S3         S3Config         `yaml:"s3"`
+	Cloudinary CloudinaryConfig `yaml:"cloudinary"`
+}
+
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	Region    string `yaml:"region" env:"S3_REGION"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY"`
+	Bucket    string `yaml:"bucket" env:"S3_BUCKET"`
+	UseSSL    bool   `yaml:"use_ssl" env:"S3_USE_SSL"`
+}
+
+type CloudinaryConfig struct {
+	CloudName string `yaml:"cloud_name" env:"CLOUDINARY_CLOUD_NAME"`
+	APIKey    string `yaml:"api_key" env:"CLOUDINARY_API_KEY"`
+	APISecret string `yaml:"api_secret" env:"CLOUDINARY_API_SECRET"`
+	Folder    string `yaml:"folder" env:"CLOUDINARY_UPLOAD_FOLDER"`
+}
+
+type FXConfig struct {
+	RatesFile string `yaml:"rates_file" env:"FX_RATES_FILE"`
+}
+
+type AdminConfig struct {
+	Email    string `yaml:"email" env:"ADMIN_EMAIL"`
+	Password string `yaml:"password" env:"ADMIN_PASSWORD"`
+}
+
+// AccountsConfig sets when customers without deposits, withdrawals or
+// transfers turn dormant. A DormantAfter of 0 turns the dormancy job off.
+// NumberPrefix is the branch code new account numbers start with.
+type AccountsConfig struct {
+	NumberPrefix     string        `yaml:"number_prefix" env:"ACCOUNT_NUMBER_PREFIX"`
+	DormantAfter     time.Duration `yaml:"dormant_after" env:"ACCOUNT_DORMANT_AFTER"`
+	DormancyInterval time.Duration `yaml:"dormancy_interval" env:"ACCOUNT_DORMANCY_INTERVAL"`
+}
+
+type TracingConfig struct {
+	Enabled     bool   `yaml:"enabled" env:"TRACING_ENABLED"`
+	Endpoint    string `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	Insecure    bool   `yaml:"insecure" env:"OTEL_EXPORTER_OTLP_INSECURE"`
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+	// SampleRatio is the share of new traces that are recorded, requests
+	// joining a sampled trace are always recorded.
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// Load reads the configuration in order of precedence: environment
+// variables, the .env file, the YAML file at path (skipped when empty) and
+// finally the defaults of the selected profile.
+func Load(path string) (*Config, error) {
+	// Deployments may pass the settings as real environment variables, the
+	// .env file is only a convenience for development.
+	if err := godotenv.Load(); err != nil {
+		logrus.Warn("No .env file loaded, using the process environment")
+	}
+
+	var fileConfig []byte
+	profile := os.Getenv("APP_ENV")
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read config file: %w", err)
+		}
+		fileConfig = content
+
+		if profile == "" {
+			var header struct {
+				Profile string `yaml:"profile"`
+			}
+			if err := yaml.Unmarshal(content, &header); err != nil {
+				return nil, fmt.Errorf("Failed to parse config file: %w", err)
+			}
+			profile = header.Profile
+		}
+	}
+	if profile == "" {
+		profile = ProfileDev
+	}
+
+	cfg := Defaults(profile)
+	if fileConfig != nil {
+		if err := yaml.Unmarshal(fileConfig, cfg); err != nil {
+			return nil, fmt.Errorf("Failed to parse config file: %w", err)
+		}
+	}
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if cfg.Storage.SigningKey == "" {
+		cfg.Storage.SigningKey = cfg.JWT.Secret
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Defaults returns the settings a profile starts from. Only prod talks to the
+// Midtrans production environment.
+func Defaults(profile string) *Config {
+	cfg := &Config{
+		Profile:  profile,
+		Server:   ServerConfig{Addr: ":8000", ShutdownTimeout: 15 * time.Second},
+		Database: DatabaseConfig{Port: 3306, QueryTimeout: 5 * time.Second},
+		Redis:    RedisConfig{Host: "localhost", Port: 6379, DB: 1},
+		JWT:      JWTConfig{Issuer: "aminivan"},
+		SMTP:     SMTPConfig{Port: 587},
+		Midtrans: MidtransConfig{Environment: MidtransSandbox},
+		Chatbot: ChatbotConfig{
+			Provider:       ChatbotOpenAI,
+			Model:          "gpt-3.5-turbo",
+			EmbeddingModel: "text-embedding-ada-002",
+			Temperature:    0.2,
+			RequestTimeout: 20 * time.Second,
+			ReplyTimeout:   30 * time.Second,
+			MaxRetries:     2,
+			RetryBackoff:   500 * time.Millisecond,
+
+			RequestsPerMinute: 10,
+			DailyTokens:       50000,
+		},
+		Storage: StorageConfig{
+			Driver:    "local",
+			LocalRoot: "uploads",
+			S3:        S3Config{UseSSL: true},
+		},
+		Accounts: AccountsConfig{
+			NumberPrefix:     helper.DefaultAccountNumberPrefix,
+			DormantAfter:     365 * 24 * time.Hour,
+			DormancyInterval: 24 * time.Hour,
+		},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			ServiceName: "selfbank",
+			SampleRatio: 1,
+		},
+	}
+	if profile == ProfileDev {
+		cfg.BaseURL = "http://localhost:8000"
+	}
+	if profile == ProfileProd {
+		cfg.Midtrans.Environment = MidtransProduction
+	}
+	return cfg
+}
+
+// Validate reports every invalid setting at once.
+func (cfg *Config) Validate() error {
+	var problems []string
+	require := func(value string, name string) {
+		if value == "" {
+			problems = append(problems, name+" is required")
+		}
+	}
+
+	switch cfg.Profile {
+	case ProfileDev, ProfileStaging, ProfileProd:
+	default:
+		problems = append(problems, fmt.Sprintf("APP_ENV must be dev, staging or prod, got %q", cfg.Profile))
+	}
+
+	require(cfg.Server.Addr, "SERVER_ADDR")
+	if cfg.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "SERVER_SHUTDOWN_TIMEOUT must be positive")
+	}
+	require(cfg.Database.Host, "DB_HOST")
+	require(cfg.Database.User, "DB_USER")
+	require(cfg.Database.Name, "DB_NAME")
+	if cfg.Database.QueryTimeout <= 0 {
+		problems = append(problems, "DB_QUERY_TIMEOUT must be positive")
+	}
+	if cfg.Redis.DB < 0 || cfg.Redis.DB > 15 {
+		problems = append(problems, "REDIS_DB must be between 0 and 15")
+	}
+
+	switch cfg.Midtrans.Environment {
+	case MidtransSandbox, MidtransProduction:
+	default:
+		problems = append(problems, fmt.Sprintf("MT_ENVIRONMENT must be sandbox or production, got %q", cfg.Midtrans.Environment))
+	}
+
+	switch cfg.Chatbot.Provider {
+	case ChatbotOpenAI, ChatbotFake:
+	case ChatbotCompatible:
+		require(cfg.Chatbot.BaseURL, "CHATBOT_BASE_URL")
+	default:
+		problems = append(problems, fmt.Sprintf("CHATBOT_PROVIDER must be openai, compatible or fake, got %q", cfg.Chatbot.Provider))
+	}
+	require(cfg.Chatbot.Model, "CHATBOT_MODEL")
+	if cfg.Chatbot.Provider != ChatbotFake && !supportedEmbeddingModel(cfg.Chatbot.EmbeddingModel) {
+		problems = append(problems, fmt.Sprintf("CHATBOT_EMBEDDING_MODEL %q is not supported", cfg.Chatbot.EmbeddingModel))
+	}
+	if cfg.Chatbot.Temperature < 0 || cfg.Chatbot.Temperature > 2 {
+		problems = append(problems, "CHATBOT_TEMPERATURE must be between 0 and 2")
+	}
+	if cfg.Chatbot.RequestTimeout <= 0 || cfg.Chatbot.ReplyTimeout <= 0 {
+		problems = append(problems, "CHATBOT_REQUEST_TIMEOUT and CHATBOT_REPLY_TIMEOUT must be positive")
+	}
+	if cfg.Chatbot.MaxRetries < 0 {
+		problems = append(problems, "CHATBOT_MAX_RETRIES must not be negative")
+	}
+	if cfg.Chatbot.RequestsPerMinute < 0 || cfg.Chatbot.DailyTokens < 0 {
+		problems = append(problems, "CHATBOT_REQUESTS_PER_MINUTE and CHATBOT_DAILY_TOKENS must not be negative")
+	}
+	if cfg.Chatbot.FaqMinScore < 0 || cfg.Chatbot.FaqMinScore > 1 {
+		problems = append(problems, "CHATBOT_FAQ_MIN_SCORE must be between 0 and 1")
+	}
+	if !helper.ValidAccountNumberPrefix(cfg.Accounts.NumberPrefix) {
+		problems = append(problems, fmt.Sprintf("ACCOUNT_NUMBER_PREFIX must be 1 to 4 digits not starting with 0, got %q", cfg.Accounts.NumberPrefix))
+	}
+	if cfg.Accounts.DormantAfter < 0 {
+		problems = append(problems, "ACCOUNT_DORMANT_AFTER must not be negative")
+	}
+	if cfg.Accounts.DormantAfter > 0 && cfg.Accounts.DormancyInterval <= 0 {
+		problems = append(problems, "ACCOUNT_DORMANCY_INTERVAL must be positive")
+	}
+
+	switch cfg.Storage.Driver {
+	case "local":
+		require(cfg.Storage.LocalRoot, "STORAGE_LOCAL_ROOT")
+	case "s3":
+		require(cfg.Storage.S3.Endpoint, "S3_ENDPOINT")
+		require(cfg.Storage.S3.Bucket, "S3_BUCKET")
+		require(cfg.Storage.S3.AccessKey, "S3_ACCESS_KEY")
+		require(cfg.Storage.S3.SecretKey, "S3_SECRET_KEY")
+	case "cloudinary":
+		require(cfg.Storage.Cloudinary.CloudName, "CLOUDINARY_CLOUD_NAME")
+		require(cfg.Storage.Cloudinary.APIKey, "CLOUDINARY_API_KEY")
+		require(cfg.Storage.Cloudinary.APISecret, "CLOUDINARY_API_SECRET")
+	default:
+		problems = append(problems, fmt.Sprintf("STORAGE_DRIVER must be local, s3 or cloudinary, got %q", cfg.Storage.Driver))
+	}
+
+	if cfg.Tracing.Enabled {
+		require(cfg.Tracing.Endpoint, "OTEL_EXPORTER_OTLP_ENDPOINT")
+		require(cfg.Tracing.ServiceName, "OTEL_SERVICE_NAME")
+		if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+			problems = append(problems, "TRACING_SAMPLE_RATIO must be between 0 and 1")
+		}
+	}
+
+	if cfg.Profile != ProfileDev {
+		require(cfg.BaseURL, "BASE_URL")
+		require(cfg.JWT.Secret, "JWT_SECRET")
+		require(cfg.Midtrans.ServerKey, "MT_SERVER_KEY")
+		require(cfg.Midtrans.ClientKey, "MT_CLIENT_KEY")
+		require(cfg.SMTP.Host, "SMTP_HOST")
+	}
+	if cfg.Profile == ProfileProd {
+		if len(cfg.JWT.Secret) < 32 {
+			problems = append(problems, "JWT_SECRET must be at least 32 characters in prod")
+		}
+		if cfg.Midtrans.Environment != MidtransProduction {
+			problems = append(problems, "MT_ENVIRONMENT must be production in prod")
+		}
+		if cfg.Chatbot.Provider == ChatbotFake {
+			problems = append(problems, "CHATBOT_PROVIDER cannot be fake in prod")
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("Invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// EnvironmentType maps the configured environment to the Midtrans client.
+func (c MidtransConfig) EnvironmentType() midtrans.EnvironmentType {
+	if c.Environment == MidtransProduction {
+		return midtrans.Production
+	}
+	return midtrans.Sandbox
+}
+
+// applyEnv overrides the fields tagged with env by the variables that are set.
+func applyEnv(value reflect.Value) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		structField := value.Type().Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := structField.Tag.Get("env")
+		raw, ok := os.LookupEnv(name)
+		if name == "" || !ok || raw == "" {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Int64:
+			duration, err := time.ParseDuration(raw)
+			if err != nil {
+				return fmt.Errorf("%s must be a duration like 15s, got %q", name, raw)
+			}
+			field.SetInt(int64(duration))
+		case reflect.String:
+			field.SetString(raw)
+		case reflect.Int:
+			number, err := strconv.Atoi(raw)
+			if err != nil {
+				return fmt.Errorf("%s must be a number, got %q", name, raw)
+			}
+			field.SetInt(int64(number))
+		case reflect.Float64:
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("%s must be a number, got %q", name, raw)
+			}
+			field.SetFloat(number)
+		case reflect.Bool:
+			flag, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("%s must be true or false, got %q", name, raw)
+			}
+			field.SetBool(flag)
+		}
+	}
+	return nil
+}
@@ -2,35 +2,37 @@ package config
 
 import (
 	"fmt"
-	"os"
 
-	"github.com/IrvanWijayaSardam/SelfBank/entity"
-
-	"github.com/joho/godotenv"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-)
-
-func SetupDatabaseConnection() *gorm.DB {
-	errEnv := godotenv.Load()
-	if errEnv != nil {
-		panic("Failed to load env file")
-	}
+	"gorm.io/plugin/opentelemetry/tracing"
 
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	dbHost := os.Getenv("DB_HOST")
-	dbName := os.Getenv("DB_NAME")
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+)
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:3306)/%s?charset=utf8&parseTime=True&loc=Local", dbUser, dbPass, dbHost, dbName)
+func SetupDatabaseConnection(cfg DatabaseConfig) *gorm.DB {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8&parseTime=True&loc=Local", cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
 
 	if err != nil {
 		panic("Failed to create connection to database")
 	}
 
-	db.AutoMigrate(&entity.User{}, &entity.Deposit{}, &entity.PaymentToken{},
-		&entity.Withdrawal{}, &entity.Transaction{})
+	// Queries run with WithContext become child spans of the request. The
+	// bound values are left out as they hold balances and personal data.
+	err = db.Use(tracing.NewPlugin(tracing.WithDBName(cfg.Name), tracing.WithoutQueryVariables(), tracing.WithoutMetrics()))
+	if err != nil {
+		panic("Failed to set up database tracing")
+	}
+	err = db.Use(queryTimeout{timeout: cfg.QueryTimeout})
+	if err != nil {
+		panic("Failed to set up the database query timeout")
+	}
+	err = db.Use(audit.NewPlugin())
+	if err != nil {
+		panic("Failed to set up the audit log")
+	}
+
 	return db
 }
 
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"github.com/IrvanWijayaSardam/SelfBank/fx"
+)
+
+// SetupRateProvider loads the exchange rates from the rates file, falling
+// back to the built in development rates when it is not set.
+func SetupRateProvider(cfg FXConfig) fx.RateProvider {
+	var provider fx.RateProvider
+	var err error
+
+	if cfg.RatesFile != "" {
+		provider, err = fx.NewFileProvider(cfg.RatesFile)
+	} else {
+		provider, err = fx.NewStaticProvider(fx.DefaultRates)
+	}
+	if err != nil {
+		panic("Failed to load exchange rates: " + err.Error())
+	}
+	return provider
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	queryContextKey = "selfbank:query_context"
+	queryCancelKey  = "selfbank:query_cancel"
+)
+
+type noQueryTimeoutKey struct{}
+
+// WithoutQueryTimeout marks ctx for statements that may legitimately run for
+// long, such as schema migrations.
+func WithoutQueryTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noQueryTimeoutKey{}, true)
+}
+
+// queryTimeout bounds every statement GORM runs. The context passed with
+// WithContext still applies, so a client that disconnects cancels its query
+// before the deadline does. Row and Rows are left alone as their result is
+// read after the callbacks have returned.
+type queryTimeout struct {
+	timeout time.Duration
+}
+
+func (p queryTimeout) Name() string {
+	return "selfbank:query_timeout"
+}
+
+func (p queryTimeout) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	registrations := []struct {
+		register func(name string, fn func(*gorm.DB)) error
+		name     string
+		fn       func(*gorm.DB)
+	}{
+		{callbacks.Create().Before("*").Register, "selfbank:start_deadline", p.start},
+		{callbacks.Create().After("*").Register, "selfbank:end_deadline", p.end},
+		{callbacks.Query().Before("*").Register, "selfbank:start_deadline", p.start},
+		{callbacks.Query().After("*").Register, "selfbank:end_deadline", p.end},
+		{callbacks.Update().Before("*").Register, "selfbank:start_deadline", p.start},
+		{callbacks.Update().After("*").Register, "selfbank:end_deadline", p.end},
+		{callbacks.Delete().Before("*").Register, "selfbank:start_deadline", p.start},
+		{callbacks.Delete().After("*").Register, "selfbank:end_deadline", p.end},
+		{callbacks.Raw().Before("*").Register, "selfbank:start_deadline", p.start},
+		{callbacks.Raw().After("*").Register, "selfbank:end_deadline", p.end},
+	}
+	for _, registration := range registrations {
+		if err := registration.register(registration.name, registration.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start swaps in a context with the deadline, unless the caller already set
+// an earlier one or opted out.
+func (p queryTimeout) start(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx.Value(noQueryTimeoutKey{}) != nil {
+		return
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= p.timeout {
+		return
+	}
+
+	timed, cancel := context.WithTimeout(ctx, p.timeout)
+	db.InstanceSet(queryContextKey, ctx)
+	db.InstanceSet(queryCancelKey, cancel)
+	db.Statement.Context = timed
+}
+
+// end releases the deadline and restores the caller's context, so a chain
+// that is reused for a second statement does not inherit a cancelled one.
+func (p queryTimeout) end(db *gorm.DB) {
+	cancel, ok := db.InstanceGet(queryCancelKey)
+	if !ok || cancel == nil {
+		return
+	}
+	cancel.(context.CancelFunc)()
+	if ctx, ok := db.InstanceGet(queryContextKey); ok {
+		db.Statement.Context = ctx.(context.Context)
+	}
+	db.InstanceSet(queryCancelKey, nil)
+}
@@ -2,22 +2,24 @@ package config
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/go-redis/redis"
 	"github.com/sirupsen/logrus"
 )
 
-func ConnectRedis() *redis.Client {
+func ConnectRedis(cfg RedisConfig) *redis.Client {
 	client := redis.NewClient(&redis.Options{
-		Addr: fmt.Sprintf("%v:%v", os.Getenv("REDIS_HOST"), os.Getenv("REDIS_PORT")),
-		DB:   1,
+		Addr:     fmt.Sprintf("%v:%v", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+		DB:       cfg.DB,
 	})
 
+	// The client reconnects on its own, so it is returned even when Redis is
+	// down. Features that need it answer 503 until it is back.
 	_, err := client.Ping().Result()
 	if err != nil {
-		logrus.Error(err.Error())
-		return nil
+		logrus.Warn("Redis is unavailable, running in degraded mode: ", err)
+		return client
 	}
 
 	logrus.Info("Connection established")
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
+)
+
+// SetupBlobStore builds the file store selected by the storage driver (local,
+// s3 or cloudinary) together with the signer used for links to private
+// files. Calls to the store are traced.
+func SetupBlobStore(cfg StorageConfig, baseURL string) (storage.BlobStore, *storage.URLSigner) {
+	signer := storage.NewURLSigner(cfg.SigningKey, baseURL)
+
+	switch cfg.Driver {
+	case "s3":
+		store, err := storage.NewS3Store(
+			cfg.S3.Endpoint,
+			cfg.S3.Region,
+			cfg.S3.AccessKey,
+			cfg.S3.SecretKey,
+			cfg.S3.Bucket,
+			cfg.S3.UseSSL,
+		)
+		if err != nil {
+			panic("Failed to create S3 storage: " + err.Error())
+		}
+		return storage.Traced(store, cfg.Driver), signer
+	case "cloudinary":
+		store, err := storage.NewCloudinaryStore(
+			cfg.Cloudinary.CloudName,
+			cfg.Cloudinary.APIKey,
+			cfg.Cloudinary.APISecret,
+			cfg.Cloudinary.Folder,
+			signer,
+		)
+		if err != nil {
+			panic("Failed to create Cloudinary storage: " + err.Error())
+		}
+		return storage.Traced(store, cfg.Driver), signer
+	default:
+		return storage.Traced(storage.NewLocalStore(cfg.LocalRoot, baseURL, signer), cfg.Driver), signer
+	}
+}
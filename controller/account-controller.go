@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+type AccountController interface {
+	Close(context echo.Context) error
+	Reactivate(context echo.Context) error
+}
+
+type accountController struct {
+	AccountService service.AccountService
+	jwtService     service.JWTService
+}
+
+func NewAccountController(accountService service.AccountService, jwtService service.JWTService) AccountController {
+	return &accountController{
+		AccountService: accountService,
+		jwtService:     jwtService,
+	}
+}
+
+func (c *accountController) Close(context echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	var closureDTO dto.AccountClosureDTO
+	if err := bind(context, &closureDTO); err != nil {
+		return err
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	payout, err := c.AccountService.Close(context.Request().Context(), idUser, closureDTO)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	closure := dto.AccountClosureResponse{}
+	if payout != nil {
+		closure.Payout = &dto.WithdrawalResponseDTO{
+			ID:     payout.ID,
+			IDUser: payout.ID_User,
+			Date:   helper.ConvertUnixtime(payout.Date).Format("2006-01-02 15:04:05"),
+			Amount: payout.Amount,
+			Fee:    payout.Fee,
+			Status: payout.Status,
+			To:     payout.To,
+		}
+	}
+
+	response := helper.BuildResponse(true, "Account closed", closure)
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *accountController) Reactivate(context echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	if err := c.AccountService.Reactivate(context.Request().Context(), idUser); err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildOkResponse(true, "Account reactivated")
+	return context.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	stdcontext "context"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+// adminRecentActivity is how many of each kind of movement the user overview
+// shows.
+const adminRecentActivity = 5
+
+type AdminController interface {
+	Users(context echo.Context) error
+	User(context echo.Context) error
+	Freeze(context echo.Context) error
+	Unfreeze(context echo.Context) error
+	ResetCredentials(context echo.Context) error
+	ChangeRole(context echo.Context) error
+	Close(context echo.Context) error
+	Reactivate(context echo.Context) error
+}
+
+type adminController struct {
+	AdminService       service.AdminService
+	UserService        service.UserService
+	KycService         service.KycService
+	WalletService      service.WalletService
+	TransactionService service.TransactionService
+	DepositService     service.DepositService
+	WithdrawalService  service.WithdrawalService
+	jwtService         service.JWTService
+}
+
+func NewAdminController(adminService service.AdminService, userService service.UserService, kycService service.KycService, walletService service.WalletService,
+	transactionService service.TransactionService, depositService service.DepositService, withdrawalService service.WithdrawalService, jwtService service.JWTService) AdminController {
+	return &adminController{
+		AdminService:       adminService,
+		UserService:        userService,
+		KycService:         kycService,
+		WalletService:      walletService,
+		TransactionService: transactionService,
+		DepositService:     depositService,
+		WithdrawalService:  withdrawalService,
+		jwtService:         jwtService,
+	}
+}
+
+// Users searches users by name, email, phone or account number with q, and
+// by status.
+func (c *adminController) Users(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	defaultPage := 1
+	defaultPageSize := 10
+
+	page, err := strconv.Atoi(context.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = defaultPage
+	}
+
+	pageSize, err := strconv.Atoi(context.QueryParam("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	var status uint64
+	if statusParam := context.QueryParam("status"); statusParam != "" {
+		status, err = strconv.ParseUint(statusParam, 10, 64)
+		if err != nil {
+			return apperror.ErrInvalidRequest.WithDetail("status must be a number")
+		}
+	}
+
+	query := context.QueryParam("q")
+	users, err := c.AdminService.SearchUsers(context.Request().Context(), query, status, page, pageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	total := c.AdminService.TotalSearchUsers(context.Request().Context(), query, status)
+
+	customResponse := struct {
+		Status  bool                      `json:"status"`
+		Message string                    `json:"message"`
+		Data    []entity.User             `json:"data"`
+		Paging  helper.PaginationResponse `json:"paging"`
+	}{
+		Status:  true,
+		Message: "OK!",
+		Data:    users,
+		Paging:  helper.BuildPaginationResponse(int(total), page, pageSize),
+	}
+
+	return context.JSON(http.StatusOK, customResponse)
+}
+
+// User shows everything support needs about a user at once: the profile and
+// balance, wallets, KYC and the latest movements.
+func (c *adminController) User(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	ctx := context.Request().Context()
+	user, err := c.AdminService.FindUser(ctx, id)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	balance, err := c.UserService.GetSaldo(ctx, user.ID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	user.Balance = &balance
+
+	wallets, err := c.WalletService.Wallets(ctx, user.ID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	transactions, err := c.TransactionService.RecentTransactions(ctx, user.ID, user.AccountNumber, adminRecentActivity)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	deposits, err := c.DepositService.RecentDeposits(ctx, user.ID, adminRecentActivity)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	withdrawals, err := c.WithdrawalService.FindWithdrawalByIDUser(ctx, user.ID, 1, adminRecentActivity)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	var kyc *dto.KycSubmissionResponse
+	if submission := c.KycService.MySubmission(ctx, user.ID); submission.ID != 0 {
+		response := buildKycResponse(submission)
+		kyc = &response
+	}
+
+	overview := struct {
+		User               entity.User                `json:"user"`
+		Wallets            []entity.Wallet            `json:"wallets"`
+		Kyc                *dto.KycSubmissionResponse `json:"kyc"`
+		RecentTransactions []entity.Transaction       `json:"recent_transactions"`
+		RecentDeposits     []entity.Deposit           `json:"recent_deposits"`
+		RecentWithdrawals  []entity.Withdrawal        `json:"recent_withdrawals"`
+	}{
+		User:               user,
+		Wallets:            wallets,
+		Kyc:                kyc,
+		RecentTransactions: transactions,
+		RecentDeposits:     deposits,
+		RecentWithdrawals:  withdrawals,
+	}
+
+	response := helper.BuildResponse(true, "OK!", overview)
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *adminController) Freeze(context echo.Context) error {
+	return c.userAction(context, c.AdminService.Freeze, "Account frozen")
+}
+
+func (c *adminController) Unfreeze(context echo.Context) error {
+	return c.userAction(context, c.AdminService.Unfreeze, "Account unfrozen")
+}
+
+func (c *adminController) Close(context echo.Context) error {
+	return c.userAction(context, c.AdminService.Close, "Account closed")
+}
+
+func (c *adminController) Reactivate(context echo.Context) error {
+	return c.userAction(context, c.AdminService.Reactivate, "Account reactivated")
+}
+
+func (c *adminController) ResetCredentials(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	var actionDTO dto.AdminActionDTO
+	if err := bind(context, &actionDTO); err != nil {
+		return err
+	}
+
+	password, err := c.AdminService.ResetCredentials(context.Request().Context(), id, actionDTO.Reason)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "Password reset, hand the temporary password to the user", dto.AdminCredentialsResponse{TemporaryPassword: password})
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *adminController) ChangeRole(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	var roleDTO dto.AdminRoleDTO
+	if err := bind(context, &roleDTO); err != nil {
+		return err
+	}
+
+	if err := c.AdminService.ChangeRole(context.Request().Context(), id, roleDTO.IdRole, roleDTO.Reason); err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildOkResponse(true, "Role changed")
+	return context.JSON(http.StatusOK, response)
+}
+
+// userAction runs one of the account changes that only take a reason.
+func (c *adminController) userAction(context echo.Context, action func(ctx stdcontext.Context, id uint64, reason string) error, message string) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	var actionDTO dto.AdminActionDTO
+	if err := bind(context, &actionDTO); err != nil {
+		return err
+	}
+
+	if err := action(context.Request().Context(), id, actionDTO.Reason); err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildOkResponse(true, message)
+	return context.JSON(http.StatusOK, response)
+}
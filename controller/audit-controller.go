@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+type AuditController interface {
+	Logs(context echo.Context) error
+	Verify(context echo.Context) error
+}
+
+type auditController struct {
+	AuditService service.AuditService
+	jwtService   service.JWTService
+}
+
+func NewAuditController(auditService service.AuditService, jwtService service.JWTService) AuditController {
+	return &auditController{
+		AuditService: auditService,
+		jwtService:   jwtService,
+	}
+}
+
+// Logs lists the audit logs, latest first, filtered by entity, entityId,
+// actorId, action and the startDate and endDate unix times.
+func (c *auditController) Logs(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	defaultPage := 1
+	defaultPageSize := 20
+
+	page, err := strconv.Atoi(context.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = defaultPage
+	}
+
+	pageSize, err := strconv.Atoi(context.QueryParam("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	filter := entity.AuditFilter{
+		Entity:   context.QueryParam("entity"),
+		EntityID: context.QueryParam("entityId"),
+		Action:   context.QueryParam("action"),
+	}
+	if actorID := context.QueryParam("actorId"); actorID != "" {
+		filter.ActorID, err = strconv.ParseUint(actorID, 10, 64)
+		if err != nil {
+			return apperror.ErrInvalidID
+		}
+	}
+	if startDate := context.QueryParam("startDate"); startDate != "" {
+		filter.StartDate, err = strconv.ParseInt(startDate, 10, 64)
+		if err != nil {
+			return apperror.ErrInvalidDate
+		}
+	}
+	if endDate := context.QueryParam("endDate"); endDate != "" {
+		filter.EndDate, err = strconv.ParseInt(endDate, 10, 64)
+		if err != nil {
+			return apperror.ErrInvalidDate
+		}
+	}
+
+	logs, err := c.AuditService.Logs(context.Request().Context(), filter, page, pageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	total := c.AuditService.TotalLogs(context.Request().Context(), filter)
+
+	customResponse := struct {
+		Status  bool                      `json:"status"`
+		Message string                    `json:"message"`
+		Data    []entity.AuditLog         `json:"data"`
+		Paging  helper.PaginationResponse `json:"paging"`
+	}{
+		Status:  true,
+		Message: "OK!",
+		Data:    logs,
+		Paging:  helper.BuildPaginationResponse(int(total), page, pageSize),
+	}
+
+	return context.JSON(http.StatusOK, customResponse)
+}
+
+// Verify checks that no audit log was altered or removed.
+func (c *auditController) Verify(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	verification, err := c.AuditService.Verify(context.Request().Context())
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "OK!", verification)
+	return context.JSON(http.StatusOK, response)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
@@ -31,13 +32,19 @@ func NewAuthController(authService service.AuthService, jwtService service.JWTSe
 
 func (c *authController) Login(ctx echo.Context) error {
 	var loginDTO dto.LoginDTO
-	if err := ctx.Bind(&loginDTO); err != nil {
-		response := helper.BuildErrorResponse("Failed to process request")
-		return ctx.JSON(http.StatusBadRequest, response)
+	if err := bind(ctx, &loginDTO); err != nil {
+		return err
 	}
 
-	authResult := c.authService.VerifyCredential(loginDTO.Email, loginDTO.Password)
+	authResult := c.authService.VerifyCredential(ctx.Request().Context(), loginDTO.Email, loginDTO.Password)
 	if v, ok := authResult.(entity.User); ok {
+		// Owners of a dormant account sign in to reactivate it.
+		if v.Status != entity.UserStatusDormant {
+			if err := service.StatusError(v.Status); err != nil {
+				return err
+			}
+		}
+
 		accountNumberStr := strconv.FormatUint(v.AccountNumber, 10)
 		generatedToken, _ := c.jwtService.GenerateToken(strconv.FormatUint(v.ID, 10), v.Namadepan, v.Email, v.Telephone, v.Jk, v.IdRole, accountNumberStr)
 		v.Token = generatedToken
@@ -45,23 +52,23 @@ func (c *authController) Login(ctx echo.Context) error {
 		return ctx.JSON(http.StatusOK, response)
 	}
 
-	response := helper.BuildErrorResponse("Please check your credentials , Invalid Credential")
-	return ctx.JSON(http.StatusUnauthorized, response)
+	return apperror.ErrInvalidCredentials
 }
 
 func (c *authController) Register(ctx echo.Context) error {
 	var registerDTO dto.RegisterDTO
-	if err := ctx.Bind(&registerDTO); err != nil {
-		response := helper.BuildErrorResponse("Failed to process request" + err.Error())
-		return ctx.JSON(http.StatusBadRequest, response)
+	if err := bind(ctx, &registerDTO); err != nil {
+		return err
 	}
 
-	if !c.authService.IsDuplicateEmail(registerDTO.Email) {
-		response := helper.BuildErrorResponse("Failed to process request Duplicate email")
-		return ctx.JSON(http.StatusConflict, response)
+	if !c.authService.IsDuplicateEmail(ctx.Request().Context(), registerDTO.Email) {
+		return apperror.ErrEmailTaken
 	}
 
-	createdUser := c.authService.CreateUser(registerDTO)
+	createdUser, err := c.authService.CreateUser(ctx.Request().Context(), registerDTO)
+	if err != nil {
+		return apperror.Internal(err)
+	}
 	token, _ := c.jwtService.GenerateToken(strconv.FormatUint(createdUser.ID, 10), createdUser.Namadepan, createdUser.Email, createdUser.Telephone, createdUser.Jk, createdUser.IdRole, strconv.FormatUint(createdUser.AccountNumber, 10))
 	createdUser.Token = token
 	response := helper.BuildResponse(true, "OK!", createdUser)
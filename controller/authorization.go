@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+// authorizeAdmin returns the token claims when the caller is an admin and
+// the error to answer with otherwise. The routes run behind AuthorizeJWT,
+// which refuses tokens whose role no longer matches the account.
+func authorizeAdmin(jwtService service.JWTService, context echo.Context) (jwt.MapClaims, error) {
+	claims, err := authorizeUser(jwtService, context)
+	if err != nil {
+		return nil, err
+	}
+
+	roleID, ok := claims["idrole"].(float64)
+	if !ok || uint64(roleID) != entity.RoleAdmin {
+		return nil, apperror.ErrForbidden
+	}
+	return claims, nil
+}
+
+// authorizeUser returns the token claims of any signed in user and the error
+// to answer with when the token is missing or invalid.
+func authorizeUser(jwtService service.JWTService, context echo.Context) (jwt.MapClaims, error) {
+	authHeader := context.Request().Header.Get("Authorization")
+	token, err := jwtService.ValidateToken(authHeader)
+	if err != nil {
+		return nil, apperror.ErrInvalidToken.Wrap(err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, apperror.ErrInvalidToken
+	}
+
+	if _, ok := claims["userid"].(string); !ok {
+		return nil, apperror.ErrInvalidToken.WithDetail("UserID not found in claims")
+	}
+
+	context.Set("user", claims)
+	logging.AddFields(context.Request().Context(), logrus.Fields{"user_id": claims["userid"]})
+	return claims, nil
+}
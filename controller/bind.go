@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+)
+
+// bind reads the request into i and checks its validate tags. A body that
+// cannot be read is an invalid request, fields breaking their rules are
+// reported one by one.
+func bind(context echo.Context, i interface{}) error {
+	if err := context.Bind(i); err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+	return context.Validate(i)
+}
@@ -1,9 +1,15 @@
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
 	"github.com/IrvanWijayaSardam/SelfBank/service"
 	"github.com/labstack/echo/v4"
@@ -11,6 +17,11 @@ import (
 
 type ChatbotController interface {
 	Request(ctx echo.Context) error
+	Stream(ctx echo.Context) error
+	Conversations(ctx echo.Context) error
+	Conversation(ctx echo.Context) error
+	DeleteConversation(ctx echo.Context) error
+	Usage(ctx echo.Context) error
 }
 
 type chatbotController struct {
@@ -26,15 +37,226 @@ func NewChatbotController(fundService service.ChatbotService, jwtService service
 }
 
 func (c *chatbotController) Request(ctx echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, ctx)
+	if err != nil {
+		return err
+	}
+
 	var request dto.ChatRequest
-	if err := ctx.Bind(&request); err != nil {
-		return ctx.JSON(http.StatusBadRequest, helper.BuildErrorResponse("error when parsing data"))
+	if err := bind(ctx, &request); err != nil {
+		return err
 	}
 
-	result, err := c.ChatbotService.Request(request)
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	result, err := c.ChatbotService.Request(ctx.Request().Context(), idUser, request)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, helper.BuildErrorResponse("error when parsing data"))
+		return chatbotError(err)
 	}
 
 	return ctx.JSON(http.StatusOK, helper.BuildResponse(true, "Chatbot Replied", result))
 }
+
+// Stream answers like Request over server-sent events. Each piece of the
+// reply is a "token" event, the last event is "done" with the whole reply or
+// "error" with the usual error body. Errors raised before the first token are
+// answered as plain JSON.
+func (c *chatbotController) Stream(ctx echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, ctx)
+	if err != nil {
+		return err
+	}
+
+	var request dto.ChatRequest
+	if err := bind(ctx, &request); err != nil {
+		return err
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	result, err := c.ChatbotService.Stream(ctx.Request().Context(), idUser, request, func(token string) error {
+		return writeEvent(ctx, "token", map[string]string{"token": token})
+	})
+	if err != nil {
+		err = chatbotError(err)
+		if ctx.Response().Committed {
+			writeEvent(ctx, "error", buildStreamError(ctx, err))
+		}
+		return err
+	}
+
+	return writeEvent(ctx, "done", result)
+}
+
+func (c *chatbotController) Conversations(ctx echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, ctx)
+	if err != nil {
+		return err
+	}
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(ctx.QueryParam("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	conversations, err := c.ChatbotService.Conversations(ctx.Request().Context(), idUser, page, pageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	conversationResponses := []dto.ChatConversationResponse{}
+	for _, conversation := range conversations {
+		conversationResponses = append(conversationResponses, buildChatConversationResponse(conversation, nil))
+	}
+
+	total := c.ChatbotService.TotalConversations(ctx.Request().Context(), idUser)
+	totalPages := (int(total) + pageSize - 1) / pageSize
+
+	customResponse := struct {
+		Status  bool                           `json:"status"`
+		Message string                         `json:"message"`
+		Errors  interface{}                    `json:"errors"`
+		Data    []dto.ChatConversationResponse `json:"data"`
+		Paging  helper.PaginationResponse      `json:"paging"`
+	}{
+		Status:  true,
+		Message: "OK!",
+		Errors:  nil,
+		Data:    conversationResponses,
+		Paging:  helper.PaginationResponse{TotalRecords: int(total), CurrentPage: page, TotalPages: totalPages},
+	}
+
+	return ctx.JSON(http.StatusOK, customResponse)
+}
+
+func (c *chatbotController) Conversation(ctx echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	conversation, messages, err := c.ChatbotService.Conversation(ctx.Request().Context(), idUser, id)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "OK!", buildChatConversationResponse(conversation, messages))
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (c *chatbotController) DeleteConversation(ctx echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	if err := c.ChatbotService.DeleteConversation(ctx.Request().Context(), idUser, id); err != nil {
+		return apperror.Internal(err)
+	}
+
+	return ctx.JSON(http.StatusOK, helper.BuildOkResponse(true, "Conversation Deleted"))
+}
+
+// Usage reports the tokens each user spent between the startDate and
+// endDate unix times, the current month by default. Admins only.
+func (c *chatbotController) Usage(ctx echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, ctx); err != nil {
+		return err
+	}
+
+	now := helper.ConvertUnixtime(time.Now().Unix())
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Unix()
+	endDate := now.Unix() + 1
+
+	var err error
+	if startDateStr := ctx.QueryParam("startDate"); startDateStr != "" {
+		startDate, err = strconv.ParseInt(startDateStr, 10, 64)
+		if err != nil {
+			return apperror.ErrInvalidDate
+		}
+	}
+	if endDateStr := ctx.QueryParam("endDate"); endDateStr != "" {
+		endDate, err = strconv.ParseInt(endDateStr, 10, 64)
+		if err != nil {
+			return apperror.ErrInvalidDate
+		}
+	}
+
+	usage, err := c.ChatbotService.Usage(ctx.Request().Context(), startDate, endDate)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	return ctx.JSON(http.StatusOK, helper.BuildResponse(true, "OK!", usage))
+}
+
+// chatbotError keeps the errors that carry a code, such as an unknown
+// conversation, and reports anything else as the chatbot being unavailable.
+func chatbotError(err error) error {
+	if _, ok := apperror.As(err); ok {
+		return err
+	}
+	return apperror.ErrChatbotUnavailable.Wrap(err)
+}
+
+// writeEvent sends a server-sent event, starting the stream on the first
+// one.
+func writeEvent(ctx echo.Context, event string, data interface{}) error {
+	response := ctx.Response()
+	if !response.Committed {
+		response.Header().Set(echo.HeaderContentType, "text/event-stream")
+		response.Header().Set(echo.HeaderCacheControl, "no-cache")
+		response.Header().Set(echo.HeaderConnection, "keep-alive")
+		response.Header().Set("X-Accel-Buffering", "no")
+		response.WriteHeader(http.StatusOK)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event, encoded); err != nil {
+		return err
+	}
+	response.Flush()
+	return nil
+}
+
+func buildStreamError(ctx echo.Context, err error) helper.ResponseError {
+	appErr, _ := apperror.As(err)
+	lang := apperror.Language(ctx.Request().Header.Get("Accept-Language"))
+	return helper.BuildErrorResponseWithCode(appErr.Code, appErr.Localize(lang))
+}
+
+func buildChatConversationResponse(conversation entity.ChatConversation, messages []entity.ChatMessage) dto.ChatConversationResponse {
+	response := dto.ChatConversationResponse{
+		ID:        conversation.ID,
+		Title:     conversation.Title,
+		CreatedAt: helper.ConvertUnixtime(conversation.CreatedAt).Format("2006-01-02 15:04:05"),
+		UpdatedAt: helper.ConvertUnixtime(conversation.UpdatedAt).Format("2006-01-02 15:04:05"),
+	}
+	for _, message := range messages {
+		response.Messages = append(response.Messages, dto.ChatMessageResponse{
+			ID:        message.ID,
+			Role:      message.Role,
+			Content:   message.Content,
+			CreatedAt: helper.ConvertUnixtime(message.CreatedAt).Format("2006-01-02 15:04:05"),
+		})
+	}
+	return response
+}
@@ -2,19 +2,20 @@ package controller
 
 import (
 	"encoding/json"
-	"fmt"
-	"log"
 	"net/http"
-	"os"
 	"strconv"
 
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/config"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
 	"github.com/IrvanWijayaSardam/SelfBank/service"
 
 	"github.com/midtrans/midtrans-go"
@@ -30,56 +31,60 @@ type DepositController interface {
 }
 
 type depositController struct {
-	DepositService service.DepositService
-	jwtService     service.JWTService
+	DepositService  service.DepositService
+	FeeLimitService service.FeeLimitService
+	jwtService      service.JWTService
+	midtrans        coreapi.Client
 }
 
-func NewDepositController(depositService service.DepositService, jwtService service.JWTService) DepositController {
+// NewDepositController builds the Midtrans client once from the config, so
+// charges, refunds and notifications all use the configured keys and
+// environment without touching the midtrans package globals.
+func NewDepositController(depositService service.DepositService, feeLimitService service.FeeLimitService, jwtService service.JWTService, midtransConfig config.MidtransConfig) DepositController {
+	var client coreapi.Client
+	client.New(midtransConfig.ServerKey, midtransConfig.EnvironmentType())
+	client.ClientKey = midtransConfig.ClientKey
+
 	return &depositController{
-		DepositService: depositService,
-		jwtService:     jwtService,
+		DepositService:  depositService,
+		FeeLimitService: feeLimitService,
+		jwtService:      jwtService,
+		midtrans:        client,
 	}
 }
 
 func (c *depositController) Insert(context echo.Context) error {
 	authHeader := context.Request().Header.Get("Authorization")
-	// errEnv := godotenv.Load()
-	// if errEnv != nil {
-	// 	panic("Failed to load env file")
-	// }
-
-	MT_SERVER_KEY := os.Getenv("MT_SERVER_KEY")
-	MT_CLIENT_KEY := os.Getenv("MT_CLIENT_KEY")
 
 	token, err := c.jwtService.ValidateToken(authHeader)
 	if err != nil {
-		log.Println(err)
-		response := helper.BuildErrorResponse("Token is not valid")
-		return context.JSON(http.StatusUnauthorized, response)
+		return apperror.ErrInvalidToken.Wrap(err)
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		context.Set("user", claims)
 
 		userID, ok := claims["userid"].(string)
 		if !ok {
-			response := helper.BuildErrorResponse("UserID not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 
 		var DepositDTO dto.DepositDTO
-		if err := context.Bind(&DepositDTO); err != nil {
-			response := helper.BuildErrorResponse("Failed to process request")
-			return context.JSON(http.StatusBadRequest, response)
+		if err := bind(context, &DepositDTO); err != nil {
+			return err
 		}
 
 		DepositDTO.ID_User, _ = strconv.ParseUint(userID, 10, 64)
+		DepositDTO.Fee, err = c.FeeLimitService.Evaluate(context.Request().Context(), DepositDTO.ID_User, entity.TrxTypeDeposit, DepositDTO.PaymentType, DepositDTO.Amount)
+		if err != nil {
+			return apperror.Internal(err)
+		}
 
-		Deposit := c.DepositService.InsertDeposit(DepositDTO)
+		grossAmount, err := DepositDTO.Amount.Add(DepositDTO.Fee)
+		if err != nil {
+			return apperror.Invalid(err)
+		}
 
-		// Initialize Midtrans client with your server key and environment
-		midtrans.ServerKey = MT_SERVER_KEY
-		midtrans.ClientKey = MT_CLIENT_KEY
-		midtrans.Environment = midtrans.Sandbox
+		Deposit := c.DepositService.InsertDeposit(context.Request().Context(), DepositDTO)
 
 		// Create a map that maps IdPayment to the corresponding bank name
 		bankMap := map[string]string{
@@ -108,16 +113,16 @@ func (c *depositController) Insert(context echo.Context) error {
 				BankTransfer: &coreapi.BankTransferDetails{Bank: midtransBank},
 				TransactionDetails: midtrans.TransactionDetails{
 					OrderID:  Deposit.ID,
-					GrossAmt: int64(Deposit.Amount),
+					GrossAmt: grossAmount.Amount,
 				},
 			}
 
-			chargeResp, err := coreapi.ChargeTransaction(chargeReq)
+			span := startMidtransSpan(context.Request().Context(), "ChargeTransaction")
+			chargeResp, err := c.midtrans.ChargeTransaction(chargeReq)
+			endMidtransSpan(span, err)
 			if err != nil {
-				c.DepositService.UpdateDepositStatus(Deposit.ID, 3)
-				res := helper.BuildErrorResponse("Failed to charge deposit")
-				context.JSON(http.StatusInternalServerError, res)
-				return err
+				c.DepositService.UpdateDepositStatus(context.Request().Context(), Deposit.ID, 3)
+				return apperror.ErrPaymentProvider.Wrap(err)
 			}
 
 			var vaAccount string
@@ -130,7 +135,8 @@ func (c *depositController) Insert(context echo.Context) error {
 
 			response := make(map[string]interface{})
 			response["va_account"] = vaAccount
-			c.DepositService.InsertPaymentToken(Deposit.ID, chargeResp.TransactionID, vaAccount, "-")
+			response["fee"] = Deposit.Fee
+			c.DepositService.InsertPaymentToken(context.Request().Context(), Deposit.ID, chargeResp.TransactionID, vaAccount, "-")
 
 			res := helper.BuildResponse(true, "Deposit inserted successfully!", response)
 			return context.JSON(http.StatusCreated, res)
@@ -142,23 +148,25 @@ func (c *depositController) Insert(context echo.Context) error {
 				PaymentType: "gopay",
 				TransactionDetails: midtrans.TransactionDetails{
 					OrderID:  Deposit.ID,
-					GrossAmt: int64(Deposit.Amount),
+					GrossAmt: grossAmount.Amount,
 				},
 			}
 
-			chargeResp, err := coreapi.ChargeTransaction(chargeReq)
+			span := startMidtransSpan(context.Request().Context(), "ChargeTransaction")
+			chargeResp, err := c.midtrans.ChargeTransaction(chargeReq)
+			endMidtransSpan(span, err)
 			if err != nil {
-				res := helper.BuildErrorResponse("Failed to charge transaction")
-				return context.JSON(http.StatusInternalServerError, res)
+				return apperror.ErrPaymentProvider.Wrap(err)
 			}
 			response := make(map[string]interface{})
+			response["fee"] = Deposit.Fee
 
 			if len(chargeResp.Actions) > 0 {
 				for _, action := range chargeResp.Actions {
 					if action.Name == "deeplink-redirect" {
 						deepLinkURL := action.URL
 						response["callback_url"] = deepLinkURL
-						c.DepositService.InsertPaymentToken(Deposit.ID, chargeResp.TransactionID, "-", deepLinkURL)
+						c.DepositService.InsertPaymentToken(context.Request().Context(), Deposit.ID, chargeResp.TransactionID, "-", deepLinkURL)
 						break
 					}
 				}
@@ -166,13 +174,11 @@ func (c *depositController) Insert(context echo.Context) error {
 			res := helper.BuildResponse(true, "Transaction inserted successfully!", response)
 			return context.JSON(http.StatusCreated, res)
 		} else {
-			res := helper.BuildErrorResponse("Unsupported payment type")
-			return context.JSON(http.StatusBadRequest, res)
+			return apperror.ErrUnsupportedPaymentType
 		}
 	}
 
-	response := helper.BuildErrorResponse("Invalid JWT token")
-	return context.JSON(http.StatusUnauthorized, response)
+	return apperror.ErrInvalidToken
 }
 
 func (c *depositController) All(context echo.Context) error {
@@ -192,16 +198,14 @@ func (c *depositController) All(context echo.Context) error {
 	if startDateStr != "" {
 		startDate, err = strconv.ParseInt(startDateStr, 10, 64)
 		if err != nil {
-			response := helper.BuildErrorResponse("Invalid startDate")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidDate
 		}
 	}
 
 	if endDateStr != "" {
 		endDate, err = strconv.ParseInt(endDateStr, 10, 64)
 		if err != nil {
-			response := helper.BuildErrorResponse("Invalid End Date")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidDate
 		}
 	}
 
@@ -220,32 +224,28 @@ func (c *depositController) All(context echo.Context) error {
 
 	token, err := c.jwtService.ValidateToken(authHeader)
 	if err != nil {
-		log.Println(err)
-		response := helper.BuildErrorResponse("Token is not valid")
-		return context.JSON(http.StatusUnauthorized, response)
+		return apperror.ErrInvalidToken.Wrap(err)
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		context.Set("user", claims)
 
 		userID, ok := claims["userid"].(string)
 		if !ok {
-			response := helper.BuildErrorResponse("UserID not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 
 		roleID, ok := claims["idrole"].(float64)
 		if !ok {
-			response := helper.BuildErrorResponse("IDRole not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 		switch roleID {
 		case 1:
 			if startDate != 0 && endDate != 0 {
-				Deposits, err = c.DepositService.SearchByDateAll(startDate, endDate)
-				total = c.DepositService.TotalDepositByDate(startDate, endDate)
+				Deposits, err = c.DepositService.SearchByDateAll(context.Request().Context(), startDate, endDate)
+				total = c.DepositService.TotalDepositByDate(context.Request().Context(), startDate, endDate)
 			} else {
-				Deposits, err = c.DepositService.All(page, pageSize)
-				total = c.DepositService.TotalDeposit()
+				Deposits, err = c.DepositService.All(context.Request().Context(), page, pageSize)
+				total = c.DepositService.TotalDeposit(context.Request().Context())
 			}
 
 			var depositResponses []dto.DepositResponse
@@ -267,7 +267,7 @@ func (c *depositController) All(context echo.Context) error {
 					status = "Created"
 				}
 
-				paymentInfo := c.DepositService.FindPaymentInfoById(deposit.ID)
+				paymentInfo := c.DepositService.FindPaymentInfoById(context.Request().Context(), deposit.ID)
 
 				depositResponse := dto.DepositResponse{
 					Id_deposit:      deposit.ID,
@@ -275,6 +275,7 @@ func (c *depositController) All(context echo.Context) error {
 					Virtual_account: paymentInfo.VirtualAcc,
 					Url_callback:    paymentInfo.CallbackUrl,
 					Amount:          deposit.Amount,
+					Fee:             deposit.Fee,
 					Status:          status,
 					Date:            helper.ConvertUnixtime(deposit.Date).Format("2006-01-02 15:04:05"),
 				}
@@ -283,8 +284,7 @@ func (c *depositController) All(context echo.Context) error {
 			if exportTo == "pdf" {
 				pdfBuffer, err := c.DepositService.GenerateDepositPDF(depositResponses)
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to generate PDF")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 
 				pdfFileName := "transactions.pdf"
@@ -296,8 +296,7 @@ func (c *depositController) All(context echo.Context) error {
 				// Write the PDF from the buffer to the response writer
 				_, err = pdfBuffer.WriteTo(context.Response())
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to write PDF to response")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 			}
 
@@ -319,19 +318,18 @@ func (c *depositController) All(context echo.Context) error {
 		case 2:
 			userIDCnv, err := strconv.ParseUint(userID, 10, 64)
 			if err != nil {
-				fmt.Println("Conversion error:", err)
+				logging.FromContext(context.Request().Context()).WithError(err).Warn("Conversion error")
 			}
 			if startDate != 0 && endDate != 0 {
-				Deposits, err = c.DepositService.SearchByDateIDUser(userIDCnv, startDate, endDate)
-				total = c.DepositService.TotalDepositByDateIdUser(userIDCnv, startDate, endDate)
+				Deposits, err = c.DepositService.SearchByDateIDUser(context.Request().Context(), userIDCnv, startDate, endDate)
+				total = c.DepositService.TotalDepositByDateIdUser(context.Request().Context(), userIDCnv, startDate, endDate)
 			} else {
-				Deposits, err = c.DepositService.FindDepositByIDUser(userIDCnv, page, pageSize)
-				total = c.DepositService.TotalDeposit()
+				Deposits, err = c.DepositService.FindDepositByIDUser(context.Request().Context(), userIDCnv, page, pageSize)
+				total = c.DepositService.TotalDeposit(context.Request().Context())
 			}
 
 			if err != nil {
-				response := helper.BuildErrorResponse("Failed to fetch data")
-				return context.JSON(http.StatusInternalServerError, response)
+				return apperror.Internal(err)
 			}
 			var depositResponses []dto.DepositResponse
 
@@ -352,7 +350,7 @@ func (c *depositController) All(context echo.Context) error {
 					status = "Created"
 				}
 
-				paymentInfo := c.DepositService.FindPaymentInfoById(deposit.ID)
+				paymentInfo := c.DepositService.FindPaymentInfoById(context.Request().Context(), deposit.ID)
 
 				depositResponse := dto.DepositResponse{
 					Id_deposit:      deposit.ID,
@@ -360,6 +358,7 @@ func (c *depositController) All(context echo.Context) error {
 					Virtual_account: paymentInfo.VirtualAcc,
 					Url_callback:    paymentInfo.CallbackUrl,
 					Amount:          deposit.Amount,
+					Fee:             deposit.Fee,
 					Status:          status,
 					Date:            helper.ConvertUnixtime(deposit.Date).Format("2006-01-02 15:04:05"),
 				}
@@ -369,8 +368,7 @@ func (c *depositController) All(context echo.Context) error {
 			if exportTo == "pdf" {
 				pdfBuffer, err := c.DepositService.GenerateDepositPDF(depositResponses)
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to generate PDF")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 
 				pdfFileName := "transactions.pdf"
@@ -382,11 +380,10 @@ func (c *depositController) All(context echo.Context) error {
 				// Write the PDF from the buffer to the response writer
 				_, err = pdfBuffer.WriteTo(context.Response())
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to write PDF to response")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 			}
-			total := c.DepositService.TotalDepositByUserID(userIDCnv)
+			total := c.DepositService.TotalDepositByUserID(context.Request().Context(), userIDCnv)
 
 			totalPages := (int(total) + pageSize - 1) / pageSize
 
@@ -405,55 +402,42 @@ func (c *depositController) All(context echo.Context) error {
 			return context.JSON(http.StatusOK, customResponse)
 		}
 	}
-	response := helper.BuildErrorResponse("Invalid token claims")
-	return context.JSON(http.StatusBadRequest, response)
+	return apperror.ErrInvalidToken
 
 }
 
 func (c *depositController) Refund(context echo.Context) error {
 	authHeader := context.Request().Header.Get("Authorization")
-	errEnv := godotenv.Load()
-	if errEnv != nil {
-		panic("Failed to load env file")
-	}
-
-	MT_SERVER_KEY := os.Getenv("MT_SERVER_KEY")
-	MT_CLIENT_KEY := os.Getenv("MT_CLIENT_KEY")
 
 	token, err := c.jwtService.ValidateToken(authHeader)
 	if err != nil {
-		log.Println(err)
-		response := helper.BuildErrorResponse("Token is not valid")
-		return context.JSON(http.StatusUnauthorized, response)
+		return apperror.ErrInvalidToken.Wrap(err)
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		context.Set("user", claims)
 
 		var RefundDTO dto.RefundDTO
-		if err := context.Bind(&RefundDTO); err != nil {
-			response := helper.BuildErrorResponse("Failed to process request")
-			return context.JSON(http.StatusBadRequest, response)
+		if err := bind(context, &RefundDTO); err != nil {
+			return err
 		}
 
-		// Initialize Midtrans client with your server key and environment
-		midtrans.ServerKey = MT_SERVER_KEY
-		midtrans.ClientKey = MT_CLIENT_KEY
-		midtrans.Environment = midtrans.Sandbox
+		if RefundDTO.Amount.Currency != money.IDR || !RefundDTO.Amount.IsPositive() {
+			return apperror.ErrInvalidAmount
+		}
 
 		refundReq := &coreapi.RefundReq{
 			RefundKey: "withdrawal22938928",
-			Amount:    int64(RefundDTO.Amount),
+			Amount:    RefundDTO.Amount.Amount,
 			Reason:    RefundDTO.Reason,
 		}
 
 		orderIDStr := strconv.FormatUint(RefundDTO.OrderID, 10) // Convert the uint64 to a string
 
-		refundResp, err := coreapi.DirectRefundTransaction(orderIDStr, refundReq)
+		span := startMidtransSpan(context.Request().Context(), "DirectRefundTransaction")
+		refundResp, err := c.midtrans.DirectRefundTransaction(orderIDStr, refundReq)
+		endMidtransSpan(span, err)
 		if err != nil {
-			// Handle the error when refunding the deposit
-			res := helper.BuildErrorResponse("Failed to refund deposit")
-			context.JSON(http.StatusInternalServerError, res)
-			return err
+			return apperror.ErrPaymentProvider.Wrap(err)
 		}
 
 		// Assuming you have a "response" map to store the response data
@@ -466,17 +450,15 @@ func (c *depositController) Refund(context echo.Context) error {
 		return context.JSON(http.StatusOK, res)
 	}
 
-	response := helper.BuildErrorResponse("Invalid JWT token")
-	return context.JSON(http.StatusUnauthorized, response)
+	return apperror.ErrInvalidToken
 }
 
 func (c *depositController) FindDepositByID(context echo.Context) error {
 	id := context.Param("id")
 
-	Deposit := c.DepositService.FindDepositByID(id)
+	Deposit := c.DepositService.FindDepositByID(context.Request().Context(), id)
 	if Deposit.ID == "" {
-		response := helper.BuildErrorResponse("Data Not Found !")
-		return context.JSON(http.StatusOK, response)
+		return apperror.ErrNotFound
 	} else {
 		status := ""
 		switch Deposit.Status {
@@ -494,7 +476,7 @@ func (c *depositController) FindDepositByID(context echo.Context) error {
 			status = "Created"
 		}
 
-		paymentInfo := c.DepositService.FindPaymentInfoById(Deposit.ID)
+		paymentInfo := c.DepositService.FindPaymentInfoById(context.Request().Context(), Deposit.ID)
 
 		depositResponse := dto.DepositResponse{
 			Id_deposit:      Deposit.ID,
@@ -502,6 +484,7 @@ func (c *depositController) FindDepositByID(context echo.Context) error {
 			Virtual_account: paymentInfo.VirtualAcc,
 			Url_callback:    paymentInfo.CallbackUrl,
 			Amount:          Deposit.Amount,
+			Fee:             Deposit.Fee,
 			Status:          status,
 			Date:            helper.ConvertUnixtime(Deposit.Date).Format("2006-01-02 15:04:05"),
 		}
@@ -517,27 +500,24 @@ func (c *depositController) HandleMidtransNotification(ctx echo.Context) error {
 
 	// 1. Parse JSON request body
 	if err := json.NewDecoder(ctx.Request().Body).Decode(&notificationPayload); err != nil {
-		// Handle the error when decoding the JSON payload
-		res := helper.BuildErrorResponse("Failed to parse notification")
-		return ctx.JSON(http.StatusBadRequest, res)
+		return apperror.ErrInvalidRequest.Wrap(err)
 	}
 
 	// 2. Get order ID from the payload
 	orderID, exists := notificationPayload["order_id"].(string)
 	if !exists {
-		// Handle the case when the key `order_id` is not found
-		res := helper.BuildErrorResponse("Order ID not found in notification")
-		return ctx.JSON(http.StatusBadRequest, res)
+		return apperror.ErrInvalidRequest.WithDetail("order_id not found in notification")
 	}
 
-	depositStatusResp, midErr := coreapi.CheckTransaction(orderID)
+	span := startMidtransSpan(ctx.Request().Context(), "CheckTransaction")
+	depositStatusResp, midErr := c.midtrans.CheckTransaction(orderID)
+	endMidtransSpan(span, midErr)
 	if midErr != nil {
-		// Handle the error when checking deposit status using Midtrans error type
-		res := helper.BuildErrorResponse("Failed to check deposit status" + midErr.Message)
-		return ctx.JSON(http.StatusInternalServerError, res)
+		return apperror.ErrPaymentProvider.Wrap(midErr)
 	}
 
 	if depositStatusResp != nil {
+		metrics.MidtransNotifications.WithLabelValues(depositStatusResp.TransactionStatus).Inc()
 		status := ""
 		switch depositStatusResp.TransactionStatus {
 		case "capture":
@@ -547,41 +527,31 @@ func (c *depositController) HandleMidtransNotification(ctx echo.Context) error {
 				status = "success"
 			}
 		case "settlement":
-			err := c.DepositService.UpdateDepositStatus(orderID, 5)
+			err := c.DepositService.UpdateDepositStatus(ctx.Request().Context(), orderID, 5)
 			if err != nil {
-				// Handle the error when updating the status
-				res := helper.BuildErrorResponse("Failed to update deposit status" + err.Error())
-				return ctx.JSON(http.StatusInternalServerError, res)
+				return apperror.Internal(err)
 			}
 		case "deny":
-			err := c.DepositService.UpdateDepositStatus(orderID, 4)
+			err := c.DepositService.UpdateDepositStatus(ctx.Request().Context(), orderID, 4)
 			if err != nil {
-				// Handle the error when updating the status
-				res := helper.BuildErrorResponse("Failed to update deposit status" + err.Error())
-				return ctx.JSON(http.StatusInternalServerError, res)
+				return apperror.Internal(err)
 			}
 		case "cancel", "expire":
-			err := c.DepositService.UpdateDepositStatus(orderID, 3)
+			err := c.DepositService.UpdateDepositStatus(ctx.Request().Context(), orderID, 3)
 			if err != nil {
-				// Handle the error when updating the status
-				res := helper.BuildErrorResponse("Failed to update deposit status" + err.Error())
-				return ctx.JSON(http.StatusInternalServerError, res)
+				return apperror.Internal(err)
 			}
 		case "pending":
-			err := c.DepositService.UpdateDepositStatus(orderID, 2)
+			err := c.DepositService.UpdateDepositStatus(ctx.Request().Context(), orderID, 2)
 			if err != nil {
-				// Handle the error when updating the status
-				res := helper.BuildErrorResponse("Failed to update deposit status" + err.Error())
-				return ctx.JSON(http.StatusInternalServerError, res)
+				return apperror.Internal(err)
 			}
 		}
 
 		if status == "success" {
-			err := c.DepositService.UpdateDepositStatus(orderID, 5)
+			err := c.DepositService.UpdateDepositStatus(ctx.Request().Context(), orderID, 5)
 			if err != nil {
-				// Handle the error when updating the status
-				res := helper.BuildErrorResponse("Failed to update deposit status" + err.Error())
-				return ctx.JSON(http.StatusInternalServerError, res)
+				return apperror.Internal(err)
 			}
 		}
 	}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+)
+
+// HTTPErrorHandler answers every error returned by a handler or middleware
+// with the same body: a stable code, a message in the language asked for by
+// Accept-Language and, for validation failures, a detail or the fields that
+// failed. Errors without a
+// code are reported as internal errors and their text is never shown.
+func HTTPErrorHandler(err error, context echo.Context) {
+	if context.Response().Committed {
+		return
+	}
+
+	appErr, ok := apperror.As(err)
+	if !ok {
+		if httpErr, isHTTPErr := err.(*echo.HTTPError); isHTTPErr {
+			appErr = apperror.FromStatus(httpErr.Code).Wrap(err)
+		} else {
+			appErr = apperror.ErrInternal.Wrap(err)
+		}
+	}
+	logging.AddFields(context.Request().Context(), logrus.Fields{"error_code": appErr.Code})
+
+	lang := apperror.Language(context.Request().Header.Get("Accept-Language"))
+	context.Response().Header().Set("Content-Language", lang)
+	context.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+
+	if context.Request().Method == http.MethodHead {
+		context.NoContent(appErr.Status)
+		return
+	}
+
+	response := helper.BuildErrorResponseWithCode(appErr.Code, appErr.Localize(lang))
+	response.Detail = appErr.Detail
+	for _, field := range appErr.Fields {
+		response.Fields = append(response.Fields, helper.FieldError{
+			Field:   field.Field,
+			Rule:    field.Rule,
+			Message: field.Localize(lang),
+		})
+	}
+	context.JSON(appErr.Status, response)
+}
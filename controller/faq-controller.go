@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+const faqSearchLimit = 5
+
+type FaqController interface {
+	All(context echo.Context) error
+	FindByID(context echo.Context) error
+	Insert(context echo.Context) error
+	Update(context echo.Context) error
+	Delete(context echo.Context) error
+	Search(context echo.Context) error
+}
+
+type faqController struct {
+	FaqService service.FaqService
+	jwtService service.JWTService
+}
+
+func NewFaqController(faqService service.FaqService, jwtService service.JWTService) FaqController {
+	return &faqController{
+		FaqService: faqService,
+		jwtService: jwtService,
+	}
+}
+
+func (c *faqController) All(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	defaultPage := 1
+	defaultPageSize := 10
+
+	page, err := strconv.Atoi(context.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = defaultPage
+	}
+
+	pageSize, err := strconv.Atoi(context.QueryParam("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	articles, err := c.FaqService.Articles(context.Request().Context(), page, pageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	var articleResponses []dto.FaqArticleResponse
+	for _, article := range articles {
+		articleResponses = append(articleResponses, buildFaqArticleResponse(article))
+	}
+
+	total := c.FaqService.TotalArticles(context.Request().Context())
+
+	customResponse := struct {
+		Status  bool                      `json:"status"`
+		Message string                    `json:"message"`
+		Data    []dto.FaqArticleResponse  `json:"data"`
+		Paging  helper.PaginationResponse `json:"paging"`
+	}{
+		Status:  true,
+		Message: "OK!",
+		Data:    articleResponses,
+		Paging:  helper.BuildPaginationResponse(int(total), page, pageSize),
+	}
+
+	return context.JSON(http.StatusOK, customResponse)
+}
+
+func (c *faqController) FindByID(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	article, err := c.FaqService.Article(context.Request().Context(), id)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "OK!", buildFaqArticleResponse(article))
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *faqController) Insert(context echo.Context) error {
+	claims, err := authorizeAdmin(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	var articleDTO dto.FaqArticleDTO
+	if err := bind(context, &articleDTO); err != nil {
+		return err
+	}
+
+	adminID, _ := claims["userid"].(string)
+	article, err := c.FaqService.InsertArticle(context.Request().Context(), helper.StringToUint64(adminID), articleDTO)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "Article created", buildFaqArticleResponse(article))
+	return context.JSON(http.StatusCreated, response)
+}
+
+func (c *faqController) Update(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	var articleDTO dto.FaqArticleDTO
+	if err := bind(context, &articleDTO); err != nil {
+		return err
+	}
+
+	article, err := c.FaqService.UpdateArticle(context.Request().Context(), id, articleDTO)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "Article updated", buildFaqArticleResponse(article))
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *faqController) Delete(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	if err := c.FaqService.DeleteArticle(context.Request().Context(), id); err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildOkResponse(true, "Article deleted")
+	return context.JSON(http.StatusOK, response)
+}
+
+// Search lets admins check which passages the chatbot would be given for a
+// question.
+func (c *faqController) Search(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	query := strings.TrimSpace(context.QueryParam("q"))
+	if query == "" {
+		return apperror.ErrInvalidRequest.WithDetail("q is required")
+	}
+
+	matches, err := c.FaqService.Search(context.Request().Context(), query, faqSearchLimit)
+	if err != nil {
+		return apperror.ErrFaqIndexFailed.Wrap(err)
+	}
+
+	response := helper.BuildResponse(true, "OK!", matches)
+	return context.JSON(http.StatusOK, response)
+}
+
+func buildFaqArticleResponse(article entity.FaqArticle) dto.FaqArticleResponse {
+	return dto.FaqArticleResponse{
+		ID:        article.ID,
+		Title:     article.Title,
+		Body:      article.Body,
+		Published: article.Published,
+		CreatedBy: article.CreatedBy,
+		CreatedAt: helper.ConvertUnixtime(article.CreatedAt).Format("2006-01-02 15:04:05"),
+		UpdatedAt: helper.ConvertUnixtime(article.UpdatedAt).Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+type FeeLimitController interface {
+	AllRules(context echo.Context) error
+	SaveLimitRule(context echo.Context) error
+	SaveFeeRule(context echo.Context) error
+}
+
+type feeLimitController struct {
+	FeeLimitService service.FeeLimitService
+	jwtService      service.JWTService
+}
+
+func NewFeeLimitController(feeLimitService service.FeeLimitService, jwtService service.JWTService) FeeLimitController {
+	return &feeLimitController{
+		FeeLimitService: feeLimitService,
+		jwtService:      jwtService,
+	}
+}
+
+func (c *feeLimitController) AllRules(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	limitRules, err := c.FeeLimitService.AllLimitRules(context.Request().Context())
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	feeRules, err := c.FeeLimitService.AllFeeRules(context.Request().Context())
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "OK!", map[string]interface{}{
+		"limits": limitRules,
+		"fees":   feeRules,
+	})
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *feeLimitController) SaveLimitRule(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	var rule entity.LimitRule
+	if err := bind(context, &rule); err != nil {
+		return err
+	}
+
+	saved, err := c.FeeLimitService.SaveLimitRule(context.Request().Context(), rule)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	response := helper.BuildResponse(true, "Limit rule saved", saved)
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *feeLimitController) SaveFeeRule(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	var rule entity.FeeRule
+	if err := bind(context, &rule); err != nil {
+		return err
+	}
+
+	saved, err := c.FeeLimitService.SaveFeeRule(context.Request().Context(), rule)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	response := helper.BuildResponse(true, "Fee rule saved", saved)
+	return context.JSON(http.StatusOK, response)
+}
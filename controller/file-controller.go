@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
+)
+
+type FileController interface {
+	ServePublic(context echo.Context) error
+	ServeSigned(context echo.Context) error
+}
+
+type fileController struct {
+	blobStore storage.BlobStore
+	signer    *storage.URLSigner
+}
+
+func NewFileController(blobStore storage.BlobStore, signer *storage.URLSigner) FileController {
+	return &fileController{
+		blobStore: blobStore,
+		signer:    signer,
+	}
+}
+
+func (c *fileController) ServePublic(context echo.Context) error {
+	return c.serve(context, storage.PublicPrefix+context.Param("*"), "public, max-age=86400")
+}
+
+func (c *fileController) ServeSigned(context echo.Context) error {
+	key := context.Param("*")
+
+	err := c.signer.Verify(key, context.QueryParam("expires"), context.QueryParam("signature"))
+	if err != nil {
+		return apperror.ErrForbidden.WithDetail(err.Error())
+	}
+
+	return c.serve(context, key, "private, no-store")
+}
+
+func (c *fileController) serve(context echo.Context, key string, cacheControl string) error {
+	file, err := c.blobStore.Get(context.Request().Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+
+	context.Response().Header().Set("Cache-Control", cacheControl)
+	context.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	context.Response().Header().Set(echo.HeaderContentType, contentType)
+	context.Response().WriteHeader(http.StatusOK)
+	_, err = io.Copy(context.Response(), file)
+	return err
+}
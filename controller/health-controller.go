@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/health"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+)
+
+type HealthController interface {
+	Live(context echo.Context) error
+	Ready(context echo.Context) error
+}
+
+type healthController struct {
+	checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) HealthController {
+	return &healthController{checker: checker}
+}
+
+// Live only tells that the process is serving requests, it never looks at
+// dependencies so a database outage does not get the API restarted.
+func (c *healthController) Live(context echo.Context) error {
+	response := helper.BuildResponse(true, "OK!", health.Report{Status: health.StatusOK})
+	return context.JSON(http.StatusOK, response)
+}
+
+// Ready answers 503 while a critical dependency is down or the API is
+// shutting down. A degraded report still counts as ready.
+func (c *healthController) Ready(context echo.Context) error {
+	report := c.checker.Run(context.Request().Context())
+	if !report.Ready() {
+		response := helper.BuildResponse(false, "Not ready", report)
+		return context.JSON(http.StatusServiceUnavailable, response)
+	}
+
+	response := helper.BuildResponse(true, "OK!", report)
+	return context.JSON(http.StatusOK, response)
+}
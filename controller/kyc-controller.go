@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+type KycController interface {
+	Submit(context echo.Context) error
+	MySubmission(context echo.Context) error
+	Queue(context echo.Context) error
+	Approve(context echo.Context) error
+	Reject(context echo.Context) error
+	Document(context echo.Context) error
+}
+
+type kycController struct {
+	KycService service.KycService
+	jwtService service.JWTService
+}
+
+func NewKycController(kycService service.KycService, jwtService service.JWTService) KycController {
+	return &kycController{
+		KycService: kycService,
+		jwtService: jwtService,
+	}
+}
+
+func (c *kycController) Submit(context echo.Context) error {
+	authHeader := context.Request().Header.Get("Authorization")
+	token, err := c.jwtService.ValidateToken(authHeader)
+	if err != nil {
+		return apperror.ErrInvalidToken.Wrap(err)
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		context.Set("user", claims)
+
+		userID, ok := claims["userid"].(string)
+		if !ok {
+			return apperror.ErrInvalidToken
+		}
+
+		var submissionDTO dto.KycSubmissionDTO
+		if err := bind(context, &submissionDTO); err != nil {
+			return err
+		}
+
+		ktp, err := context.FormFile("ktp")
+		if err != nil {
+			return apperror.ErrKycDocumentRequired.With(apperror.Params{"document": service.KycDocumentKtp})
+		}
+
+		selfie, err := context.FormFile("selfie")
+		if err != nil {
+			return apperror.ErrKycDocumentRequired.With(apperror.Params{"document": service.KycDocumentSelfie})
+		}
+
+		idUser, _ := strconv.ParseUint(userID, 10, 64)
+		submission, err := c.KycService.Submit(context.Request().Context(), idUser, submissionDTO, ktp, selfie)
+		if err != nil {
+			return apperror.Invalid(err)
+		}
+
+		response := helper.BuildResponse(true, "KYC submitted, please wait for our review", buildKycResponse(submission))
+		return context.JSON(http.StatusCreated, response)
+	}
+
+	return apperror.ErrInvalidToken
+}
+
+func (c *kycController) MySubmission(context echo.Context) error {
+	authHeader := context.Request().Header.Get("Authorization")
+	token, err := c.jwtService.ValidateToken(authHeader)
+	if err != nil {
+		return apperror.ErrInvalidToken.Wrap(err)
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		context.Set("user", claims)
+
+		userID, ok := claims["userid"].(string)
+		if !ok {
+			return apperror.ErrInvalidToken
+		}
+
+		idUser, _ := strconv.ParseUint(userID, 10, 64)
+		submission := c.KycService.MySubmission(context.Request().Context(), idUser)
+		if submission.ID == 0 {
+			return apperror.ErrKycNotSubmitted
+		}
+
+		response := helper.BuildResponse(true, "OK!", buildKycResponse(submission))
+		return context.JSON(http.StatusOK, response)
+	}
+
+	return apperror.ErrInvalidToken
+}
+
+func (c *kycController) Queue(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	defaultPage := 1
+	defaultPageSize := 10
+
+	page, err := strconv.Atoi(context.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = defaultPage
+	}
+
+	pageSize, err := strconv.Atoi(context.QueryParam("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	submissions, err := c.KycService.PendingSubmissions(context.Request().Context(), page, pageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	var kycResponses []dto.KycSubmissionResponse
+	for _, submission := range submissions {
+		kycResponses = append(kycResponses, buildKycResponse(submission))
+	}
+
+	total := c.KycService.TotalPendingSubmissions(context.Request().Context())
+
+	customResponse := struct {
+		Status  bool                        `json:"status"`
+		Message string                      `json:"message"`
+		Data    []dto.KycSubmissionResponse `json:"data"`
+		Paging  helper.PaginationResponse   `json:"paging"`
+	}{
+		Status:  true,
+		Message: "OK!",
+		Data:    kycResponses,
+		Paging:  helper.BuildPaginationResponse(int(total), page, pageSize),
+	}
+
+	return context.JSON(http.StatusOK, customResponse)
+}
+
+func (c *kycController) Approve(context echo.Context) error {
+	claims, err := authorizeAdmin(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	reviewerID, _ := claims["userid"].(string)
+	err = c.KycService.Approve(context.Request().Context(), id, helper.StringToUint64(reviewerID))
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	response := helper.BuildOkResponse(true, "KYC submission approved")
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *kycController) Reject(context echo.Context) error {
+	claims, err := authorizeAdmin(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	var rejectDTO dto.KycRejectDTO
+	if err := bind(context, &rejectDTO); err != nil {
+		return err
+	}
+
+	reviewerID, _ := claims["userid"].(string)
+	err = c.KycService.Reject(context.Request().Context(), id, helper.StringToUint64(reviewerID), rejectDTO.Reason)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	response := helper.BuildOkResponse(true, "KYC submission rejected")
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *kycController) Document(context echo.Context) error {
+	if _, err := authorizeAdmin(c.jwtService, context); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(context.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidID
+	}
+
+	documentURL, err := c.KycService.DocumentURL(context.Request().Context(), id, context.Param("document"))
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	return context.Redirect(http.StatusFound, documentURL)
+}
+
+func buildKycResponse(submission entity.KycSubmission) dto.KycSubmissionResponse {
+	status := ""
+	switch submission.Status {
+	case entity.KycStatusPending:
+		status = "Pending"
+	case entity.KycStatusApproved:
+		status = "Approved"
+	case entity.KycStatusRejected:
+		status = "Rejected"
+	}
+
+	response := dto.KycSubmissionResponse{
+		ID:           submission.ID,
+		IDUser:       submission.ID_User,
+		NIK:          submission.NIK,
+		DateOfBirth:  submission.DateOfBirth,
+		Address:      submission.Address,
+		Status:       status,
+		RejectReason: submission.RejectReason,
+		SubmittedAt:  helper.ConvertUnixtime(submission.SubmittedAt).Format("2006-01-02 15:04:05"),
+	}
+	if submission.ReviewedAt != 0 {
+		response.ReviewedAt = helper.ConvertUnixtime(submission.ReviewedAt).Format("2006-01-02 15:04:05")
+	}
+	return response
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/midtrans/midtrans-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/IrvanWijayaSardam/SelfBank/tracing"
+)
+
+// startMidtransSpan traces a call to the Midtrans API. The client takes no
+// context, so the span is opened around the call instead of by the
+// transport.
+func startMidtransSpan(ctx context.Context, operation string) trace.Span {
+	_, span := tracing.Start(ctx, "midtrans "+operation, semconv.PeerService("midtrans"))
+	return span
+}
+
+func endMidtransSpan(span trace.Span, err *midtrans.Error) {
+	if err != nil {
+		tracing.End(span, err)
+		return
+	}
+	tracing.End(span, nil)
+}
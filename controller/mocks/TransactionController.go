@@ -26,6 +26,20 @@ func (_m *TransactionController) All(context echo.Context) error {
 	return r0
 }
 
+// Confirm provides a mock function with given fields: context
+func (_m *TransactionController) Confirm(context echo.Context) error {
+	ret := _m.Called(context)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(context)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindTransactionByID provides a mock function with given fields: context
 func (_m *TransactionController) FindTransactionByID(context echo.Context) error {
 	ret := _m.Called(context)
@@ -40,6 +54,20 @@ func (_m *TransactionController) FindTransactionByID(context echo.Context) error
 	return r0
 }
 
+// Inquiry provides a mock function with given fields: context
+func (_m *TransactionController) Inquiry(context echo.Context) error {
+	ret := _m.Called(context)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(context)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Insert provides a mock function with given fields: context
 func (_m *TransactionController) Insert(context echo.Context) error {
 	ret := _m.Called(context)
@@ -12,6 +12,20 @@ type UserController struct {
 	mock.Mock
 }
 
+// All provides a mock function with given fields: ctx
+func (_m *UserController) All(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FileUpload provides a mock function with given fields: ctx
 func (_m *UserController) FileUpload(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -40,6 +54,20 @@ func (_m *UserController) MyProfile(ctx echo.Context) error {
 	return r0
 }
 
+// UpdateProfile provides a mock function with given fields: ctx
+func (_m *UserController) UpdateProfile(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserController creates a new instance of UserController. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserController(t interface {
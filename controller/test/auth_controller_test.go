@@ -9,15 +9,20 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/controller"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/service/mocks"
+	"github.com/IrvanWijayaSardam/SelfBank/validation"
 )
 
 func TestAuthController_Login(t *testing.T) {
 	e := echo.New()
+	e.HTTPErrorHandler = controller.HTTPErrorHandler
+	e.Validator = validation.New()
 	t.Run("Success Login Test", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(`{"email": "test@gmail.com", "password": "password123"}`))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -29,7 +34,7 @@ func TestAuthController_Login(t *testing.T) {
 
 		controller := controller.NewAuthController(authService, jwtService)
 
-		authService.On("VerifyCredential", "test@gmail.com", "password123").Return(
+		authService.On("VerifyCredential", mock.Anything, "test@gmail.com", "password123").Return(
 			entity.User{
 				ID:            1,
 				Email:         "aminivan@gmail.com",
@@ -69,11 +74,12 @@ func TestAuthController_Login(t *testing.T) {
 
 		controller := controller.NewAuthController(authService, jwtService)
 
-		authService.On("VerifyCredential", "test@gmail.com", "password123").Return(nil).Once()
+		authService.On("VerifyCredential", mock.Anything, "test@gmail.com", "password123").Return(nil).Once()
 
 		err := controller.Login(c)
+		assert.ErrorIs(t, err, apperror.ErrInvalidCredentials)
 
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(err, c)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
 
 		authService.AssertExpectations(t)
@@ -90,8 +96,9 @@ func TestAuthController_Login(t *testing.T) {
 		controller := controller.NewAuthController(authService, jwtService)
 
 		err := controller.Login(c)
+		assert.ErrorIs(t, err, apperror.ErrInvalidRequest)
 
-		assert.Nil(t, err)
+		e.HTTPErrorHandler(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 
 		authService.AssertExpectations(t)
@@ -109,7 +116,6 @@ func TestAuthController_Register(t *testing.T) {
 		Password:     "zeolga",
 		Telephone:    "6281340691423",
 		Jk:           "F",
-		Status:       1,
 	}
 
 	var dataUser = entity.User{
@@ -127,6 +133,8 @@ func TestAuthController_Register(t *testing.T) {
 	jwtService := mocks.NewMockJWTService(t)
 
 	e := echo.New()
+	e.HTTPErrorHandler = controller.HTTPErrorHandler
+	e.Validator = validation.New()
 	t.Run("Success Register", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(`{
 			"nama_depan": "Zelvia",
@@ -145,8 +153,8 @@ func TestAuthController_Register(t *testing.T) {
 
 		controller := controller.NewAuthController(authService, jwtService)
 
-		authService.On("IsDuplicateEmail", "zeolga@gmail.com").Return(true).Once()
-		authService.On("CreateUser", registerData).Return(dataUser).Once()
+		authService.On("IsDuplicateEmail", mock.Anything, "zeolga@gmail.com").Return(true).Once()
+		authService.On("CreateUser", mock.Anything, registerData).Return(dataUser, nil).Once()
 		jwtService.On(
 			"GenerateToken",
 			"1",
@@ -184,14 +192,42 @@ func TestAuthController_Register(t *testing.T) {
 		controller := controller.NewAuthController(authService, jwtService)
 
 		err := controller.Register(c)
+		assert.ErrorIs(t, err, apperror.ErrInvalidRequest)
 
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 
 		authService.AssertExpectations(t)
 		jwtService.AssertExpectations(t)
 	})
 
+	t.Run("Validation Error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(`{
+			"nama_depan": "Zelvia",
+			"nama_belakang": "Olga Maharani",
+			"email": "zeolga",
+			"username": "zeolga",
+			"password": "zeolga",
+			"telp": "12345",
+			"jk": "F",
+			"idrole": 2,
+			"status": 1
+		}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		controller := controller.NewAuthController(authService, jwtService)
+
+		err := controller.Register(c)
+		assert.ErrorIs(t, err, apperror.ErrValidation)
+
+		e.HTTPErrorHandler(err, c)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"email"`)
+		assert.Contains(t, rec.Body.String(), `"field":"telp"`)
+	})
+
 	t.Run("Duplicate Email", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(`{
 			"nama_depan": "Zelvia",
@@ -210,11 +246,12 @@ func TestAuthController_Register(t *testing.T) {
 
 		controller := controller.NewAuthController(authService, jwtService)
 
-		authService.On("IsDuplicateEmail", "zeolga@gmail.com").Return(false).Once()
+		authService.On("IsDuplicateEmail", mock.Anything, "zeolga@gmail.com").Return(false).Once()
 
 		err := controller.Register(c)
+		assert.ErrorIs(t, err, apperror.ErrEmailTaken)
 
-		assert.NoError(t, err)
+		e.HTTPErrorHandler(err, c)
 		assert.Equal(t, http.StatusConflict, rec.Code)
 
 		authService.AssertExpectations(t)
@@ -1,17 +1,19 @@
 package controller
 
 import (
-	"fmt"
-	"log"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
 	"github.com/IrvanWijayaSardam/SelfBank/service"
 )
 
@@ -19,18 +21,22 @@ type TransactionController interface {
 	Insert(context echo.Context) error
 	All(context echo.Context) error
 	FindTransactionByID(context echo.Context) error
+	Inquiry(context echo.Context) error
+	Confirm(context echo.Context) error
 }
 
 type transactionController struct {
 	TransactionService service.TransactionService
 	UserService        service.UserService
+	FeeLimitService    service.FeeLimitService
 	jwtService         service.JWTService
 }
 
-func NewTransactionController(transactionService service.TransactionService, userService service.UserService, jwtService service.JWTService) TransactionController {
+func NewTransactionController(transactionService service.TransactionService, userService service.UserService, feeLimitService service.FeeLimitService, jwtService service.JWTService) TransactionController {
 	return &transactionController{
 		TransactionService: transactionService,
 		UserService:        userService,
+		FeeLimitService:    feeLimitService,
 		jwtService:         jwtService,
 	}
 }
@@ -40,54 +46,152 @@ func (c *transactionController) Insert(context echo.Context) error {
 	token, err := c.jwtService.ValidateToken(authHeader)
 
 	if err != nil {
-		log.Println(err)
-		response := helper.BuildErrorResponse("Token is not valid")
-		return context.JSON(http.StatusUnauthorized, response)
+		return apperror.ErrInvalidToken.Wrap(err)
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		context.Set("user", claims)
 
 		userID, ok := claims["userid"].(string)
 		if !ok {
-			response := helper.BuildErrorResponse("UserID not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 
 		accountNumber, ok := claims["accountnumber"].(string)
 		if !ok {
-			response := helper.BuildErrorResponse("Account Number not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 		var TransactionDTO dto.TransactionDTO
-		if err := context.Bind(&TransactionDTO); err != nil {
-			response := helper.BuildErrorResponse("Failed to process request " + err.Error())
-			return context.JSON(http.StatusBadRequest, response)
+		if err := bind(context, &TransactionDTO); err != nil {
+			return err
 		}
 
-		validateTo := c.TransactionService.ValidateAccNumber(TransactionDTO.TransactionTo)
+		validateTo := c.TransactionService.ValidateAccNumber(context.Request().Context(), TransactionDTO.TransactionTo)
 		if validateTo == false {
-			response := helper.BuildErrorResponse("Nomor Rekening Tujuan Tidak Valid")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrAccountNotFound
 		}
 
 		TransactionDTO.ID_User, _ = strconv.ParseUint(userID, 10, 64)
 		TransactionDTO.TransactionFrom, _ = strconv.ParseUint(accountNumber, 10, 64)
 
-		TransactionDTO.ID_User, _ = strconv.ParseUint(userID, 10, 64)
-		currentSaldo := c.UserService.GetSaldo(TransactionDTO.ID_User)
+		TransactionDTO.Fee, err = c.FeeLimitService.Evaluate(context.Request().Context(), TransactionDTO.ID_User, entity.TrxTypeTransfer, "", TransactionDTO.Amount)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+
+		total, err := TransactionDTO.Amount.Add(TransactionDTO.Fee)
+		if err != nil {
+			return apperror.Invalid(err)
+		}
 
-		if currentSaldo >= int64(TransactionDTO.Amount) {
-			Transaction := c.TransactionService.InsertTransaction(TransactionDTO)
+		currentSaldo, err := c.UserService.GetSaldo(context.Request().Context(), TransactionDTO.ID_User)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+
+		if !currentSaldo.LessThan(total) {
+			Transaction := c.TransactionService.InsertTransaction(context.Request().Context(), TransactionDTO)
 			res := helper.BuildResponse(true, "Transaction Success", Transaction)
 			return context.JSON(http.StatusCreated, res)
 		} else {
-			res := helper.BuildErrorResponse("Cannot continue withdrawal because your balance is insufficient")
-			return context.JSON(http.StatusBadRequest, res)
+			return apperror.ErrInsufficientFunds
 		}
-	} else {
-		res := helper.BuildErrorResponse("There's Something Wrong")
-		return context.JSON(http.StatusBadRequest, res)
 	}
+
+	return apperror.ErrInvalidToken
+}
+
+func (c *transactionController) Inquiry(context echo.Context) error {
+	authHeader := context.Request().Header.Get("Authorization")
+	token, err := c.jwtService.ValidateToken(authHeader)
+
+	if err != nil {
+		return apperror.ErrInvalidToken.Wrap(err)
+	}
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		context.Set("user", claims)
+
+		userID, ok := claims["userid"].(string)
+		if !ok {
+			return apperror.ErrInvalidToken
+		}
+
+		accountNumber, ok := claims["accountnumber"].(string)
+		if !ok {
+			return apperror.ErrInvalidToken
+		}
+
+		var inquiryDTO dto.TransferInquiryDTO
+		if err := bind(context, &inquiryDTO); err != nil {
+			return err
+		}
+
+		idUser, _ := strconv.ParseUint(userID, 10, 64)
+		accNumberFrom, _ := strconv.ParseUint(accountNumber, 10, 64)
+
+		inquiry, err := c.TransactionService.Inquiry(context.Request().Context(), idUser, accNumberFrom, inquiryDTO)
+		if err != nil {
+			return apperror.Invalid(err)
+		}
+
+		res := helper.BuildResponse(true, "Please confirm your transfer", inquiry)
+		return context.JSON(http.StatusOK, res)
+	}
+
+	return apperror.ErrInvalidToken
+}
+
+func (c *transactionController) Confirm(context echo.Context) error {
+	authHeader := context.Request().Header.Get("Authorization")
+	token, err := c.jwtService.ValidateToken(authHeader)
+
+	if err != nil {
+		return apperror.ErrInvalidToken.Wrap(err)
+	}
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		context.Set("user", claims)
+
+		userID, ok := claims["userid"].(string)
+		if !ok {
+			return apperror.ErrInvalidToken
+		}
+
+		var confirmDTO dto.TransferConfirmDTO
+		if err := bind(context, &confirmDTO); err != nil {
+			return err
+		}
+
+		quote, err := c.TransactionService.FindQuote(context.Request().Context(), confirmDTO.QuoteID)
+		if errors.Is(err, repository.ErrCacheUnavailable) {
+			return err
+		}
+		idUser, _ := strconv.ParseUint(userID, 10, 64)
+		if err != nil || quote.ID_User != idUser {
+			return apperror.ErrQuoteExpired
+		}
+
+		total, err := quote.Amount.Add(quote.Fee)
+		if err != nil {
+			return apperror.Invalid(err)
+		}
+
+		currentSaldo, err := c.UserService.GetSaldo(context.Request().Context(), idUser)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		if currentSaldo.LessThan(total) {
+			return apperror.ErrInsufficientFunds
+		}
+
+		Transaction, err := c.TransactionService.ConfirmTransfer(context.Request().Context(), quote)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+
+		res := helper.BuildResponse(true, "Transaction Success", Transaction)
+		return context.JSON(http.StatusCreated, res)
+	}
+
+	return apperror.ErrInvalidToken
 }
 
 func (c *transactionController) All(context echo.Context) error {
@@ -112,30 +216,25 @@ func (c *transactionController) All(context echo.Context) error {
 
 	token, err := c.jwtService.ValidateToken(authHeader)
 	if err != nil {
-		log.Println(err)
-		response := helper.BuildErrorResponse("Token is not valid")
-		return context.JSON(http.StatusUnauthorized, response)
+		return apperror.ErrInvalidToken.Wrap(err)
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		context.Set("user", claims)
 
 		userID, ok := claims["userid"].(string)
 		if !ok {
-			response := helper.BuildErrorResponse("UserID not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 
 		roleID, ok := claims["idrole"].(float64)
 		if !ok {
-			response := helper.BuildErrorResponse("IDRole not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 		switch roleID {
 		case 1:
-			Transactions, err := c.TransactionService.All(page, pageSize)
+			Transactions, err := c.TransactionService.All(context.Request().Context(), page, pageSize)
 			if err != nil {
-				response := helper.BuildErrorResponse("Failed to fetch data")
-				return context.JSON(http.StatusInternalServerError, response)
+				return apperror.Internal(err)
 			}
 
 			for _, transaction := range Transactions {
@@ -146,6 +245,9 @@ func (c *transactionController) All(context echo.Context) error {
 					AccountNumberTo:   transaction.TransactionTo,
 					Date:              helper.ConvertUnixtime(transaction.Date).Format("2006-01-02 15:04:05"),
 					Amount:            transaction.Amount,
+					Fee:               transaction.Fee,
+					Note:              transaction.Note,
+					Category:          transaction.Category,
 					Status:            transaction.Status,
 				}
 				transactionResponses = append(transactionResponses, response)
@@ -154,8 +256,7 @@ func (c *transactionController) All(context echo.Context) error {
 			if exportTo == "pdf" {
 				pdfBuffer, err := c.TransactionService.GenerateTransactionPDF(Transactions)
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to generate PDF")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 
 				pdfFileName := "transactions.pdf"
@@ -167,11 +268,10 @@ func (c *transactionController) All(context echo.Context) error {
 				// Write the PDF from the buffer to the response writer
 				_, err = pdfBuffer.WriteTo(context.Response())
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to write PDF to response")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 			}
-			total := c.TransactionService.TotalTransaction()
+			total := c.TransactionService.TotalTransaction(context.Request().Context())
 
 			totalPages := (int(total) + pageSize - 1) / pageSize
 
@@ -193,12 +293,11 @@ func (c *transactionController) All(context echo.Context) error {
 		case 2:
 			userIDCnv, err := strconv.ParseUint(userID, 10, 64)
 			if err != nil {
-				fmt.Println("Conversion error:", err)
+				logging.FromContext(context.Request().Context()).WithError(err).Warn("Conversion error")
 			}
-			Transactions, err := c.TransactionService.FindTransactionByIDUser(userIDCnv, page, pageSize)
+			Transactions, err := c.TransactionService.FindTransactionByIDUser(context.Request().Context(), userIDCnv, page, pageSize)
 			if err != nil {
-				response := helper.BuildErrorResponse("Failed to fetch data")
-				return context.JSON(http.StatusInternalServerError, response)
+				return apperror.Internal(err)
 			}
 
 			for _, transaction := range Transactions {
@@ -209,6 +308,9 @@ func (c *transactionController) All(context echo.Context) error {
 					AccountNumberTo:   transaction.TransactionTo,
 					Date:              helper.ConvertUnixtime(transaction.Date).Format("2006-01-02 15:04:05"),
 					Amount:            transaction.Amount,
+					Fee:               transaction.Fee,
+					Note:              transaction.Note,
+					Category:          transaction.Category,
 					Status:            transaction.Status,
 				}
 				transactionResponses = append(transactionResponses, response)
@@ -217,8 +319,7 @@ func (c *transactionController) All(context echo.Context) error {
 			if exportTo == "pdf" {
 				pdfBuffer, err := c.TransactionService.GenerateTransactionPDF(Transactions)
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to generate PDF")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 
 				pdfFileName := "exported/transactions.pdf"
@@ -230,12 +331,11 @@ func (c *transactionController) All(context echo.Context) error {
 				// Write the PDF from the buffer to the response writer
 				_, err = pdfBuffer.WriteTo(context.Response())
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to write PDF to response")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 			}
 
-			total := c.TransactionService.TotalTransactionByUserID(userIDCnv)
+			total := c.TransactionService.TotalTransactionByUserID(context.Request().Context(), userIDCnv)
 
 			totalPages := (int(total) + pageSize - 1) / pageSize
 
@@ -256,8 +356,7 @@ func (c *transactionController) All(context echo.Context) error {
 			return context.JSON(http.StatusOK, customResponse)
 		}
 	}
-	response := helper.BuildErrorResponse("Invalid token claims")
-	return context.JSON(http.StatusBadRequest, response)
+	return apperror.ErrInvalidToken
 
 }
 
@@ -267,14 +366,12 @@ func (c *transactionController) FindTransactionByID(context echo.Context) error
 	orderIDUint, err := strconv.ParseUint(id, 10, 64)
 	if err != nil {
 		// Handle the error when parsing orderID
-		res := helper.BuildErrorResponse("Failed to parse order ID")
-		return context.JSON(http.StatusBadRequest, res)
+		return apperror.ErrInvalidID
 	}
-	Transaction := c.TransactionService.FindTransactionByID(orderIDUint)
+	Transaction := c.TransactionService.FindTransactionByID(context.Request().Context(), orderIDUint)
 
 	if Transaction.ID == 0 {
-		response := helper.BuildErrorResponse("Data Not Found !")
-		return context.JSON(http.StatusOK, response)
+		return apperror.ErrNotFound
 	} else {
 		var transactionResponses = dto.TransactionResponse{
 			ID:                Transaction.ID,
@@ -283,6 +380,9 @@ func (c *transactionController) FindTransactionByID(context echo.Context) error
 			AccountNumberTo:   Transaction.TransactionTo,
 			Date:              helper.ConvertUnixtime(Transaction.Date).Format("2006-01-02 15:04:05"),
 			Amount:            Transaction.Amount,
+			Fee:               Transaction.Fee,
+			Note:              Transaction.Note,
+			Category:          Transaction.Category,
 			Status:            Transaction.Status,
 		}
 
@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
 	"github.com/IrvanWijayaSardam/SelfBank/service"
 	"github.com/labstack/echo/v4"
 )
@@ -28,15 +31,16 @@ func NewVerificationController(verifService service.VerificationService, jwtServ
 
 func (c *verificationController) SendVerificationEmail(ctx echo.Context) error {
 	var verificationDTO dto.VerificationDTO
-	if err := ctx.Bind(&verificationDTO); err != nil {
-		response := helper.BuildErrorResponse("Failed to process request")
-		return ctx.JSON(http.StatusBadRequest, response)
+	if err := bind(ctx, &verificationDTO); err != nil {
+		return err
 	}
 
-	sendEmail := c.verificationService.SendVerificationEmail(verificationDTO.Email)
+	sendEmail := c.verificationService.SendVerificationEmail(ctx.Request().Context(), verificationDTO.Email)
+	if errors.Is(sendEmail, repository.ErrCacheUnavailable) {
+		return sendEmail
+	}
 	if sendEmail != nil {
-		response := helper.BuildErrorResponse("Failed Sending Email Verification" + sendEmail.Error())
-		return ctx.JSON(http.StatusBadGateway, response)
+		return apperror.ErrEmailDeliveryFailed.Wrap(sendEmail)
 	}
 
 	response := helper.BuildOkResponse(true, "Please Check Your Email To Do A Verification")
@@ -46,10 +50,9 @@ func (c *verificationController) SendVerificationEmail(ctx echo.Context) error {
 func (c *verificationController) ValidateVerification(ctx echo.Context) error {
 	otp := ctx.QueryParam("otp")
 
-	verifyOTP := c.verificationService.VerifyOtp(otp)
+	verifyOTP := c.verificationService.VerifyOtp(ctx.Request().Context(), otp)
 	if verifyOTP != true {
-		response := helper.BuildErrorResponse("Incorrect / Expired OTP")
-		return ctx.JSON(http.StatusBadGateway, response)
+		return apperror.ErrOTPExpired
 	}
 
 	response := helper.BuildOkResponse(true, "Account verified")
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+type WalletController interface {
+	Wallets(context echo.Context) error
+	Open(context echo.Context) error
+	Transfer(context echo.Context) error
+	Transfers(context echo.Context) error
+	Rate(context echo.Context) error
+}
+
+type walletController struct {
+	WalletService service.WalletService
+	jwtService    service.JWTService
+}
+
+func NewWalletController(walletService service.WalletService, jwtService service.JWTService) WalletController {
+	return &walletController{
+		WalletService: walletService,
+		jwtService:    jwtService,
+	}
+}
+
+func (c *walletController) Wallets(context echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	wallets, err := c.WalletService.Wallets(context.Request().Context(), idUser)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	response := helper.BuildResponse(true, "OK!", wallets)
+	return context.JSON(http.StatusOK, response)
+}
+
+func (c *walletController) Open(context echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	var openDTO dto.OpenWalletDTO
+	if err := bind(context, &openDTO); err != nil {
+		return err
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	wallet, err := c.WalletService.OpenWallet(context.Request().Context(), idUser, openDTO.Currency)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	response := helper.BuildResponse(true, "Wallet opened", wallet)
+	return context.JSON(http.StatusCreated, response)
+}
+
+func (c *walletController) Transfer(context echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	accountNumber, ok := claims["accountnumber"].(string)
+	if !ok {
+		return apperror.ErrInvalidToken
+	}
+
+	var transferDTO dto.WalletTransferDTO
+	if err := bind(context, &transferDTO); err != nil {
+		return err
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	accNumberFrom, _ := strconv.ParseUint(accountNumber, 10, 64)
+
+	transfer, err := c.WalletService.Transfer(context.Request().Context(), idUser, accNumberFrom, transferDTO)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	response := helper.BuildResponse(true, "Transaction Success", buildWalletTransferResponse(transfer))
+	return context.JSON(http.StatusCreated, response)
+}
+
+func (c *walletController) Transfers(context echo.Context) error {
+	claims, err := authorizeUser(c.jwtService, context)
+	if err != nil {
+		return err
+	}
+
+	page, err := strconv.Atoi(context.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(context.QueryParam("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	idUser, _ := strconv.ParseUint(claims["userid"].(string), 10, 64)
+	transfers, err := c.WalletService.Transfers(context.Request().Context(), idUser, page, pageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	transferResponses := []dto.WalletTransferResponse{}
+	for _, transfer := range transfers {
+		transferResponses = append(transferResponses, buildWalletTransferResponse(transfer))
+	}
+
+	total := c.WalletService.TotalTransfers(context.Request().Context(), idUser)
+	totalPages := (int(total) + pageSize - 1) / pageSize
+
+	customResponse := struct {
+		Status  bool                         `json:"status"`
+		Message string                       `json:"message"`
+		Errors  interface{}                  `json:"errors"`
+		Data    []dto.WalletTransferResponse `json:"data"`
+		Paging  helper.PaginationResponse    `json:"paging"`
+	}{
+		Status:  true,
+		Message: "OK!",
+		Errors:  nil,
+		Data:    transferResponses,
+		Paging:  helper.PaginationResponse{TotalRecords: int(total), CurrentPage: page, TotalPages: totalPages},
+	}
+
+	return context.JSON(http.StatusOK, customResponse)
+}
+
+func (c *walletController) Rate(context echo.Context) error {
+	if _, err := authorizeUser(c.jwtService, context); err != nil {
+		return err
+	}
+
+	amount, err := strconv.ParseInt(context.QueryParam("amount"), 10, 64)
+	if err != nil || amount < 0 {
+		amount = 0
+	}
+
+	conversion, err := c.WalletService.Quote(context.QueryParam("from"), context.QueryParam("to"), amount)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	response := helper.BuildResponse(true, "OK!", dto.ExchangeRateResponse{
+		From:   conversion.Amount.Currency,
+		To:     conversion.Result.Currency,
+		Rate:   conversion.RateString(),
+		Amount: conversion.Amount,
+		Result: conversion.Result,
+	})
+	return context.JSON(http.StatusOK, response)
+}
+
+func buildWalletTransferResponse(transfer entity.WalletTransfer) dto.WalletTransferResponse {
+	return dto.WalletTransferResponse{
+		ID:                transfer.ID,
+		AccountNumberFrom: transfer.AccountFrom,
+		AccountNumberTo:   transfer.AccountTo,
+		FromCurrency:      transfer.FromCurrency,
+		ToCurrency:        transfer.ToCurrency,
+		DebitAmount:       transfer.DebitAmount,
+		CreditAmount:      transfer.CreditAmount,
+		Rate:              transfer.Rate,
+		Note:              transfer.Note,
+		Date:              helper.ConvertUnixtime(transfer.Date).Format("2006-01-02 15:04:05"),
+		Status:            transfer.Status,
+	}
+}
@@ -1,16 +1,17 @@
 package controller
 
 import (
-	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
 	"github.com/IrvanWijayaSardam/SelfBank/service"
 )
 
@@ -23,13 +24,15 @@ type WithdrawalController interface {
 type withdrawalController struct {
 	WithdrawalService service.WithdrawalService
 	UserService       service.UserService
+	FeeLimitService   service.FeeLimitService
 	jwtService        service.JWTService
 }
 
-func NewWithdrawalController(withdrawalService service.WithdrawalService, userService service.UserService, jwtService service.JWTService) WithdrawalController {
+func NewWithdrawalController(withdrawalService service.WithdrawalService, userService service.UserService, feeLimitService service.FeeLimitService, jwtService service.JWTService) WithdrawalController {
 	return &withdrawalController{
 		WithdrawalService: withdrawalService,
 		UserService:       userService,
+		FeeLimitService:   feeLimitService,
 		jwtService:        jwtService,
 	}
 }
@@ -39,41 +42,48 @@ func (c *withdrawalController) Insert(context echo.Context) error {
 	token, err := c.jwtService.ValidateToken(authHeader)
 
 	if err != nil {
-		log.Println(err)
-		response := helper.BuildErrorResponse("Token is not valid")
-		return context.JSON(http.StatusUnauthorized, response)
+		return apperror.ErrInvalidToken.Wrap(err)
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		context.Set("user", claims)
 
 		userID, ok := claims["userid"].(string)
 		if !ok {
-			response := helper.BuildErrorResponse("UserID not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 
 		var WithdrawalDTO dto.WithdrawalDTO
-		if err := context.Bind(&WithdrawalDTO); err != nil {
-			response := helper.BuildErrorResponse("Failed to process request")
-			return context.JSON(http.StatusBadRequest, response)
+		if err := bind(context, &WithdrawalDTO); err != nil {
+			return err
 		}
 
 		WithdrawalDTO.ID_User, _ = strconv.ParseUint(userID, 10, 64)
-		currentSaldo := c.UserService.GetSaldo(WithdrawalDTO.ID_User)
+		WithdrawalDTO.Fee, err = c.FeeLimitService.Evaluate(context.Request().Context(), WithdrawalDTO.ID_User, entity.TrxTypeWithdrawal, "", WithdrawalDTO.Amount)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+
+		total, err := WithdrawalDTO.Amount.Add(WithdrawalDTO.Fee)
+		if err != nil {
+			return apperror.Invalid(err)
+		}
 
-		if currentSaldo >= int64(WithdrawalDTO.Amount) {
-			Withdrawal := c.WithdrawalService.InsertWithdrawal(WithdrawalDTO)
+		currentSaldo, err := c.UserService.GetSaldo(context.Request().Context(), WithdrawalDTO.ID_User)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+
+		if !currentSaldo.LessThan(total) {
+			Withdrawal := c.WithdrawalService.InsertWithdrawal(context.Request().Context(), WithdrawalDTO)
 			res := helper.BuildResponse(true, "Withdrawal Success", Withdrawal)
 			return context.JSON(http.StatusCreated, res)
 		} else {
-			res := helper.BuildErrorResponse("Cannot continue withdrawal because your balance is insufficient")
-			return context.JSON(http.StatusBadRequest, res)
+			return apperror.ErrInsufficientFunds
 		}
 
-	} else {
-		res := helper.BuildErrorResponse("There's Something Wrong Contact Developer")
-		return context.JSON(http.StatusBadRequest, res)
 	}
+
+	return apperror.ErrInvalidToken
 }
 
 func (c *withdrawalController) All(context echo.Context) error {
@@ -99,30 +109,25 @@ func (c *withdrawalController) All(context echo.Context) error {
 
 	token, err := c.jwtService.ValidateToken(authHeader)
 	if err != nil {
-		log.Println(err)
-		response := helper.BuildErrorResponse("Token is not valid")
-		return context.JSON(http.StatusUnauthorized, response)
+		return apperror.ErrInvalidToken.Wrap(err)
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		context.Set("user", claims)
 
 		userID, ok := claims["userid"].(string)
 		if !ok {
-			response := helper.BuildErrorResponse("UserID not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 
 		roleID, ok := claims["idrole"].(float64)
 		if !ok {
-			response := helper.BuildErrorResponse("IDRole not found in claims")
-			return context.JSON(http.StatusBadRequest, response)
+			return apperror.ErrInvalidToken
 		}
 		switch roleID {
 		case 1:
-			Withdrawals, err := c.WithdrawalService.All(page, pageSize)
+			Withdrawals, err := c.WithdrawalService.All(context.Request().Context(), page, pageSize)
 			if err != nil {
-				response := helper.BuildErrorResponse("Failed to fetch data")
-				return context.JSON(http.StatusInternalServerError, response)
+				return apperror.Internal(err)
 			}
 
 			for _, transaction := range Withdrawals {
@@ -131,6 +136,7 @@ func (c *withdrawalController) All(context echo.Context) error {
 					IDUser: transaction.ID_User,
 					Date:   helper.ConvertUnixtime(transaction.Date).Format("2006-01-02 15:04:05"),
 					Amount: transaction.Amount,
+					Fee:    transaction.Fee,
 					Status: transaction.Status,
 					To:     transaction.To,
 				}
@@ -140,8 +146,7 @@ func (c *withdrawalController) All(context echo.Context) error {
 			if exportTo == "pdf" {
 				pdfBuffer, err := c.WithdrawalService.GenerateWithdrawalPDF(Withdrawals)
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to generate PDF")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 
 				pdfFileName := "transactions.pdf"
@@ -153,12 +158,11 @@ func (c *withdrawalController) All(context echo.Context) error {
 				// Write the PDF from the buffer to the response writer
 				_, err = pdfBuffer.WriteTo(context.Response())
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to write PDF to response")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 			}
 
-			total := c.WithdrawalService.TotalWithdrawal()
+			total := c.WithdrawalService.TotalWithdrawal(context.Request().Context())
 
 			totalPages := (int(total) + pageSize - 1) / pageSize
 
@@ -178,12 +182,11 @@ func (c *withdrawalController) All(context echo.Context) error {
 		case 2:
 			userIDCnv, err := strconv.ParseUint(userID, 10, 64)
 			if err != nil {
-				fmt.Println("Conversion error:", err)
+				logging.FromContext(context.Request().Context()).WithError(err).Warn("Conversion error")
 			}
-			Withdrawals, err := c.WithdrawalService.FindWithdrawalByIDUser(userIDCnv, page, pageSize)
+			Withdrawals, err := c.WithdrawalService.FindWithdrawalByIDUser(context.Request().Context(), userIDCnv, page, pageSize)
 			if err != nil {
-				response := helper.BuildErrorResponse("Failed to fetch data")
-				return context.JSON(http.StatusInternalServerError, response)
+				return apperror.Internal(err)
 			}
 			for _, transaction := range Withdrawals {
 				response := dto.WithdrawalResponseDTO{
@@ -191,6 +194,7 @@ func (c *withdrawalController) All(context echo.Context) error {
 					IDUser: transaction.ID_User,
 					Date:   helper.ConvertUnixtime(transaction.Date).Format("2006-01-02 15:04:05"),
 					Amount: transaction.Amount,
+					Fee:    transaction.Fee,
 					To:     transaction.To,
 					Status: transaction.Status,
 				}
@@ -200,8 +204,7 @@ func (c *withdrawalController) All(context echo.Context) error {
 			if exportTo == "pdf" {
 				pdfBuffer, err := c.WithdrawalService.GenerateWithdrawalPDF(Withdrawals)
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to generate PDF")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 
 				pdfFileName := "transactions.pdf"
@@ -213,12 +216,11 @@ func (c *withdrawalController) All(context echo.Context) error {
 				// Write the PDF from the buffer to the response writer
 				_, err = pdfBuffer.WriteTo(context.Response())
 				if err != nil {
-					response := helper.BuildErrorResponse("Failed to write PDF to response")
-					return context.JSON(http.StatusInternalServerError, response)
+					return apperror.Internal(err)
 				}
 			}
 
-			total := c.WithdrawalService.TotalWithdrawalByUserID(userIDCnv)
+			total := c.WithdrawalService.TotalWithdrawalByUserID(context.Request().Context(), userIDCnv)
 
 			totalPages := (int(total) + pageSize - 1) / pageSize
 
@@ -237,8 +239,7 @@ func (c *withdrawalController) All(context echo.Context) error {
 			return context.JSON(http.StatusOK, customResponse)
 		}
 	}
-	response := helper.BuildErrorResponse("Invalid token claims")
-	return context.JSON(http.StatusBadRequest, response)
+	return apperror.ErrInvalidToken
 
 }
 
@@ -248,20 +249,19 @@ func (c *withdrawalController) FindWithdrawalByID(context echo.Context) error {
 	orderIDUint, err := strconv.ParseUint(id, 10, 64)
 	if err != nil {
 		// Handle the error when parsing orderID
-		res := helper.BuildErrorResponse("Failed to parse order ID")
-		return context.JSON(http.StatusBadRequest, res)
+		return apperror.ErrInvalidID
 	}
 
-	Withdrawal := c.WithdrawalService.FindWithdrawalByID(orderIDUint)
+	Withdrawal := c.WithdrawalService.FindWithdrawalByID(context.Request().Context(), orderIDUint)
 	if Withdrawal.ID == 0 {
-		res := helper.BuildErrorResponse("Withdrawal not found")
-		return context.JSON(http.StatusNotFound, res)
+		return apperror.ErrNotFound
 	} else {
 		var data = dto.WithdrawalResponseDTO{
 			ID:     Withdrawal.ID,
 			IDUser: Withdrawal.ID_User,
 			Date:   helper.ConvertUnixtime(Withdrawal.Date).Format("2006-01-02 15:04:05"),
 			Amount: Withdrawal.Amount,
+			Fee:    Withdrawal.Fee,
 			To:     Withdrawal.To,
 			Status: Withdrawal.Status,
 		}
@@ -0,0 +1,15 @@
+package dto
+
+// AccountClosureDTO closes the caller's account. A remaining balance is paid
+// out to PayoutTo, like a withdrawal.
+type AccountClosureDTO struct {
+	Password string `json:"password" form:"password" validate:"required"`
+	PayoutTo string `json:"payout_to" form:"payout_to" validate:"max=255"`
+	Reason   string `json:"reason" form:"reason" validate:"max=255"`
+}
+
+// AccountClosureResponse holds the payout of the remaining balance, null
+// when there was none.
+type AccountClosureResponse struct {
+	Payout *WithdrawalResponseDTO `json:"payout"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// AdminActionDTO carries the reason an admin gives for changing an account,
+// it is kept in the audit log.
+type AdminActionDTO struct {
+	Reason string `json:"reason" form:"reason" validate:"required,max=255"`
+}
+
+type AdminRoleDTO struct {
+	IdRole uint64 `json:"idrole" form:"idrole" validate:"required"`
+	Reason string `json:"reason" form:"reason" validate:"required,max=255"`
+}
+
+type AdminCredentialsResponse struct {
+	TemporaryPassword string `json:"temporary_password"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// AuditVerification is the result of checking the audit log chain. BrokenAt
+// is the first log that was altered or follows a removed one.
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Checked  int    `json:"checked"`
+	BrokenAt uint64 `json:"broken_at,omitempty"`
+	Problem  string `json:"problem,omitempty"`
+}
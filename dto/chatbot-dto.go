@@ -1,6 +1,36 @@
 package dto
 
+// ChatRequest sends Message to the chatbot. Leaving ConversationID empty
+// starts a new conversation.
 type ChatRequest struct {
-	Message  string `json:"message" form:"message" validate:"required"`
-	Response string
+	Message        string `json:"message" form:"message" validate:"required,max=1000"`
+	ConversationID uint64 `json:"conversation_id" form:"conversation_id"`
+	Response       string
+}
+
+type ChatReply struct {
+	ConversationID uint64       `json:"conversation_id"`
+	Reply          string       `json:"reply"`
+	Sources        []ChatSource `json:"sources,omitempty"`
+}
+
+// ChatSource is a knowledge base article the reply cited.
+type ChatSource struct {
+	ArticleID uint64 `json:"article_id"`
+	Title     string `json:"title"`
+}
+
+type ChatConversationResponse struct {
+	ID        uint64                `json:"id"`
+	Title     string                `json:"title"`
+	CreatedAt string                `json:"created_at"`
+	UpdatedAt string                `json:"updated_at"`
+	Messages  []ChatMessageResponse `json:"messages,omitempty"`
+}
+
+type ChatMessageResponse struct {
+	ID        uint64 `json:"id"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
 }
@@ -0,0 +1,28 @@
+package dto
+
+// FaqArticleDTO creates or updates a knowledge base article. Articles are
+// published unless Published is false.
+type FaqArticleDTO struct {
+	Title     string `json:"title" form:"title" validate:"required,max=200"`
+	Body      string `json:"body" form:"body" validate:"required,max=20000"`
+	Published *bool  `json:"published" form:"published"`
+}
+
+// FaqMatch is a passage of an article found for a question, Score being the
+// cosine similarity of their embeddings.
+type FaqMatch struct {
+	ArticleID uint64  `json:"article_id"`
+	Title     string  `json:"title"`
+	Content   string  `json:"content"`
+	Score     float64 `json:"score"`
+}
+
+type FaqArticleResponse struct {
+	ID        uint64 `json:"id"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	Published bool   `json:"published"`
+	CreatedBy uint64 `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
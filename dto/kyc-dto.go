@@ -0,0 +1,23 @@
+package dto
+
+type KycSubmissionDTO struct {
+	NIK         string `json:"nik" form:"nik" validate:"required,numeric,len=16"`
+	DateOfBirth string `json:"date_of_birth" form:"date_of_birth" validate:"required"`
+	Address     string `json:"address" form:"address" validate:"required"`
+}
+
+type KycRejectDTO struct {
+	Reason string `json:"reason" form:"reason" validate:"required"`
+}
+
+type KycSubmissionResponse struct {
+	ID           uint64 `json:"id"`
+	IDUser       uint64 `json:"id_user"`
+	NIK          string `json:"nik"`
+	DateOfBirth  string `json:"date_of_birth"`
+	Address      string `json:"address"`
+	Status       string `json:"status"`
+	RejectReason string `json:"reject_reason,omitempty"`
+	SubmittedAt  string `json:"submitted_at"`
+	ReviewedAt   string `json:"reviewed_at,omitempty"`
+}
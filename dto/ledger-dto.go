@@ -0,0 +1,8 @@
+package dto
+
+// LedgerIssue is a discrepancy found while reconciling the ledger.
+type LedgerIssue struct {
+	IDUser  uint64 `json:"id_user"`
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
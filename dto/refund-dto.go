@@ -1,8 +1,10 @@
 package dto
 
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
 type RefundDTO struct {
-	OrderID uint64  `json:"idorder" form:"idorder"`
-	Amount  float64 `json:"amount" validate:"required"`
-	TrxType uint64  `json:"type" form:"type" binding:"required"`
-	Reason  string  `json:"reason" validate:"required"`
+	OrderID uint64      `json:"idorder" form:"idorder" validate:"required"`
+	Amount  money.Money `json:"amount" validate:"required,positive_amount"`
+	TrxType uint64      `json:"type" form:"type"`
+	Reason  string      `json:"reason" validate:"required"`
 }
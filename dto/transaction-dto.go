@@ -1,18 +1,49 @@
 package dto
 
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
 type TransactionDTO struct {
-	ID_User         uint64 `json:"id_user" form:"id_user"`
-	TransactionFrom uint64 `json:"acc_number_from" form:"acc_number_from"`
-	TransactionTo   uint64 `json:"acc_number_to" form:"acc_number_to" validate:"required"`
-	Amount          uint64 `json:"amount" validate:"required"`
+	ID_User         uint64      `json:"id_user" form:"id_user"`
+	TransactionFrom uint64      `json:"acc_number_from" form:"acc_number_from"`
+	TransactionTo   uint64      `json:"acc_number_to" form:"acc_number_to" validate:"required,account_number"`
+	Amount          money.Money `json:"amount" validate:"required,positive_amount"`
+	Fee             money.Money `json:"-"`
+	Note            string      `json:"note" form:"note"`
+	Category        string      `json:"category" form:"category"`
 }
 
 type TransactionResponse struct {
-	ID                uint64 `json:"id"`
-	IDUser            uint64 `json:"id_user"`
-	AccountNumberFrom uint64 `json:"acc_number_from"`
-	AccountNumberTo   uint64 `json:"acc_number_to"`
-	Date              string `json:"date"`
-	Amount            uint64 `json:"amount"`
-	Status            uint64 `json:"status"`
+	ID                uint64      `json:"id"`
+	IDUser            uint64      `json:"id_user"`
+	AccountNumberFrom uint64      `json:"acc_number_from"`
+	AccountNumberTo   uint64      `json:"acc_number_to"`
+	Date              string      `json:"date"`
+	Amount            money.Money `json:"amount"`
+	Fee               money.Money `json:"fee"`
+	Note              string      `json:"note"`
+	Category          string      `json:"category"`
+	Status            uint64      `json:"status"`
+}
+
+type TransferInquiryDTO struct {
+	TransactionTo uint64      `json:"acc_number_to" form:"acc_number_to" validate:"required,account_number"`
+	Amount        money.Money `json:"amount" form:"amount" validate:"required,positive_amount"`
+	Note          string      `json:"note" form:"note"`
+	Category      string      `json:"category" form:"category"`
+}
+
+type TransferConfirmDTO struct {
+	QuoteID string `json:"quote_id" form:"quote_id" validate:"required"`
+}
+
+type TransferInquiryResponse struct {
+	QuoteID         string      `json:"quote_id"`
+	AccountNumberTo uint64      `json:"acc_number_to"`
+	RecipientName   string      `json:"recipient_name"`
+	Amount          money.Money `json:"amount"`
+	Fee             money.Money `json:"fee"`
+	Total           money.Money `json:"total"`
+	Note            string      `json:"note"`
+	Category        string      `json:"category"`
+	ExpiresAt       string      `json:"expires_at"`
 }
@@ -6,8 +6,9 @@ type File struct {
 	File multipart.File `json:"file,omitempty" validate:"required"`
 }
 
-type Url struct {
-	Url string `json:"url,omitempty" validate:"required"`
+type UploadedImage struct {
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnail_url"`
 }
 
 type MediaDto struct {
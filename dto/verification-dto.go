@@ -1,5 +1,5 @@
 package dto
 
 type VerificationDTO struct {
-	Email string `json:"email" form:"email" binding:"required,email"`
+	Email string `json:"email" form:"email" validate:"required,email"`
 }
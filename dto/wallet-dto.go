@@ -0,0 +1,41 @@
+package dto
+
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
+type OpenWalletDTO struct {
+	Currency string `json:"currency" form:"currency" validate:"required,len=3"`
+}
+
+// WalletTransferDTO moves Amount out of the FromCurrency wallet, given in
+// minor units of that currency. Leaving TransactionTo empty converts between
+// the caller's own wallets.
+type WalletTransferDTO struct {
+	FromCurrency  string `json:"from_currency" form:"from_currency" validate:"required"`
+	ToCurrency    string `json:"to_currency" form:"to_currency"`
+	TransactionTo uint64 `json:"acc_number_to" form:"acc_number_to" validate:"omitempty,account_number"`
+	Amount        int64  `json:"amount" form:"amount" validate:"required,positive_amount"`
+	Convert       bool   `json:"convert" form:"convert"`
+	Note          string `json:"note" form:"note"`
+}
+
+type WalletTransferResponse struct {
+	ID                uint64      `json:"id"`
+	AccountNumberFrom uint64      `json:"acc_number_from"`
+	AccountNumberTo   uint64      `json:"acc_number_to"`
+	FromCurrency      string      `json:"from_currency"`
+	ToCurrency        string      `json:"to_currency"`
+	DebitAmount       money.Money `json:"debit_amount"`
+	CreditAmount      money.Money `json:"credit_amount"`
+	Rate              string      `json:"rate"`
+	Note              string      `json:"note"`
+	Date              string      `json:"date"`
+	Status            uint64      `json:"status"`
+}
+
+type ExchangeRateResponse struct {
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Rate   string      `json:"rate"`
+	Amount money.Money `json:"amount"`
+	Result money.Money `json:"result"`
+}
@@ -1,16 +1,20 @@
 package dto
 
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
 type WithdrawalDTO struct {
-	ID_User uint64 `json:"iduser" form:"iduser"`
-	Amount  uint64 `json:"amount" validate:"required"`
-	To      string `json:"to" form:"to" binding:"required"`
+	ID_User uint64      `json:"iduser" form:"iduser"`
+	Amount  money.Money `json:"amount" validate:"required,positive_amount"`
+	Fee     money.Money `json:"-"`
+	To      string      `json:"to" form:"to" validate:"required"`
 }
 
 type WithdrawalResponseDTO struct {
-	ID     uint64 `json:"id"`
-	IDUser uint64 `json:"id_user"`
-	Date   string `json:"date"`
-	Amount uint64 `json:"amount"`
-	To     string `json:"to"`
-	Status uint64 `json:"status"`
+	ID     uint64      `json:"id"`
+	IDUser uint64      `json:"id_user"`
+	Date   string      `json:"date"`
+	Amount money.Money `json:"amount"`
+	Fee    money.Money `json:"fee"`
+	To     string      `json:"to"`
+	Status uint64      `json:"status"`
 }
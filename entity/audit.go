@@ -0,0 +1,39 @@
+package entity
+
+// AuditLog records a change made to a row and who made it. Before and After
+// hold the changed columns as JSON. Hash covers the entry and the Hash of the
+// one before it, so editing or removing an entry breaks the chain.
+type AuditLog struct {
+	ID        uint64 `gorm:"primary_key:auto_increment" json:"id"`
+	ActorID   uint64 `gorm:"type:int(100)" json:"actor_id"`
+	Action    string `gorm:"type:varchar(50)" json:"action"`
+	Entity    string `gorm:"type:varchar(50)" json:"entity"`
+	EntityID  string `gorm:"type:varchar(64)" json:"entity_id"`
+	Before    string `gorm:"type:text" json:"before,omitempty"`
+	After     string `gorm:"type:text" json:"after,omitempty"`
+	Reason    string `gorm:"type:varchar(255)" json:"reason,omitempty"`
+	IP        string `gorm:"type:varchar(45)" json:"ip"`
+	RequestID string `gorm:"type:varchar(64)" json:"request_id"`
+	CreatedAt int64  `gorm:"type:bigint" json:"created_at"`
+	PrevHash  string `gorm:"type:char(64)" json:"prev_hash"`
+	Hash      string `gorm:"type:char(64)" json:"hash"`
+}
+
+// AuditHead is the single row holding the Hash of the latest audit log. It is
+// locked while a log is appended so the chain never forks, and tells when the
+// latest logs were removed.
+type AuditHead struct {
+	ID   uint64 `gorm:"primary_key" json:"id"`
+	Hash string `gorm:"type:char(64)" json:"hash"`
+}
+
+// AuditFilter narrows the audit logs down, empty fields match everything.
+// StartDate and EndDate are unix times.
+type AuditFilter struct {
+	Entity    string
+	EntityID  string
+	ActorID   uint64
+	Action    string
+	StartDate int64
+	EndDate   int64
+}
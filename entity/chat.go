@@ -0,0 +1,44 @@
+package entity
+
+// ChatConversation is a thread of messages between a user and the chatbot.
+type ChatConversation struct {
+	ID        uint64 `gorm:"primary_key:auto_increment" json:"id"`
+	ID_User   uint64 `gorm:"type:int(100);index" json:"id_user"`
+	User      User   `gorm:"foreignKey:ID_User" json:"-"`
+	Title     string `gorm:"type:varchar(100)" json:"title"`
+	CreatedAt int64  `gorm:"type:bigint" json:"created_at"`
+	UpdatedAt int64  `gorm:"type:bigint" json:"updated_at"`
+}
+
+// ChatMessage is one message of a conversation. Only what the user wrote and
+// the chatbot's replies are kept, tool calls are not. Tokens is an estimate
+// of the message's size, used to fit the history into the model's context.
+type ChatMessage struct {
+	ID             uint64 `gorm:"primary_key:auto_increment" json:"id"`
+	ConversationID uint64 `gorm:"index" json:"conversation_id"`
+	Role           string `gorm:"type:varchar(20)" json:"role"`
+	Content        string `gorm:"type:text" json:"content"`
+	Tokens         int    `gorm:"type:int(10)" json:"-"`
+	CreatedAt      int64  `gorm:"type:bigint" json:"created_at"`
+}
+
+// ChatUsage records the tokens one chatbot reply took, for the daily quota
+// and cost reports. Estimated is set when the provider did not report them.
+type ChatUsage struct {
+	ID               uint64 `gorm:"primary_key:auto_increment" json:"id"`
+	ID_User          uint64 `gorm:"type:int(100);index:idx_chat_usages_user_date" json:"id_user"`
+	ConversationID   uint64 `json:"conversation_id"`
+	Model            string `gorm:"type:varchar(100)" json:"model"`
+	PromptTokens     int    `gorm:"type:int(10)" json:"prompt_tokens"`
+	CompletionTokens int    `gorm:"type:int(10)" json:"completion_tokens"`
+	Estimated        bool   `json:"estimated"`
+	CreatedAt        int64  `gorm:"type:bigint;index:idx_chat_usages_user_date" json:"created_at"`
+}
+
+// ChatUsageTotal sums the chatbot usage of a user over a period.
+type ChatUsageTotal struct {
+	ID_User          uint64 `json:"id_user"`
+	Replies          int64  `json:"replies"`
+	PromptTokens     int64  `json:"prompt_tokens"`
+	CompletionTokens int64  `json:"completion_tokens"`
+}
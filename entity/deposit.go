@@ -1,10 +1,13 @@
 package entity
 
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
 type Deposit struct {
-	ID      string `gorm:"primary_key" json:"id"`
-	ID_User uint64 `gorm:"type:int(100);index" json:"id_user"`
-	User    User   `gorm:"foreignKey:ID_User" json:"-"`
-	Date    int64  `gorm:"type:bigint" json:"date"`
-	Amount  uint64 `gorm:"type:int(100)" json:"amount"`
-	Status  uint64 `gorm:"type:int(100);default:1" json:"status"`
+	ID      string      `gorm:"primary_key" json:"id"`
+	ID_User uint64      `gorm:"type:int(100);index" json:"id_user"`
+	User    User        `gorm:"foreignKey:ID_User" json:"-"`
+	Date    int64       `gorm:"type:bigint" json:"date"`
+	Amount  money.Money `gorm:"type:bigint" json:"amount"`
+	Fee     money.Money `gorm:"type:bigint;default:0" json:"fee"`
+	Status  uint64      `gorm:"type:int(100);default:1" json:"status"`
 }
@@ -0,0 +1,26 @@
+package entity
+
+import "github.com/IrvanWijayaSardam/SelfBank/llm"
+
+// FaqArticle is a knowledge base article the chatbot answers from. Only
+// published articles are searched.
+type FaqArticle struct {
+	ID        uint64 `gorm:"primary_key:auto_increment" json:"id"`
+	Title     string `gorm:"type:varchar(200)" json:"title"`
+	Body      string `gorm:"type:text" json:"body"`
+	Published bool   `json:"published"`
+	CreatedBy uint64 `gorm:"type:int(100)" json:"created_by"`
+	CreatedAt int64  `gorm:"type:bigint" json:"created_at"`
+	UpdatedAt int64  `gorm:"type:bigint" json:"updated_at"`
+}
+
+// FaqChunk is a passage of an article with its embedding. Model is the
+// embedding model, vectors of different models cannot be compared.
+type FaqChunk struct {
+	ID        uint64     `gorm:"primary_key:auto_increment" json:"id"`
+	ArticleID uint64     `gorm:"index" json:"article_id"`
+	Position  int        `gorm:"type:int(10)" json:"position"`
+	Content   string     `gorm:"type:text" json:"content"`
+	Embedding llm.Vector `gorm:"type:mediumblob" json:"-"`
+	Model     string     `gorm:"type:varchar(100)" json:"-"`
+}
@@ -0,0 +1,47 @@
+package entity
+
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
+const (
+	TrxTypeTransfer   = "transfer"
+	TrxTypeWithdrawal = "withdrawal"
+	TrxTypeDeposit    = "deposit"
+)
+
+// LimitRule bounds how much a user may move. IdRole and KycTier set to 0 match
+// every role or tier, and a limit set to 0 means unlimited.
+type LimitRule struct {
+	ID              uint64      `gorm:"primary_key:auto_increment" json:"id"`
+	TransactionType string      `gorm:"type:varchar(20);index" json:"transaction_type"`
+	IdRole          uint64      `gorm:"type:bigint;default:0" json:"idrole"`
+	KycTier         uint64      `gorm:"type:int(10);default:0" json:"kyc_tier"`
+	MinAmount       money.Money `gorm:"type:bigint;default:0" json:"min_amount"`
+	MaxAmount       money.Money `gorm:"type:bigint;default:0" json:"max_amount"`
+	DailyLimit      money.Money `gorm:"type:bigint;default:0" json:"daily_limit"`
+	MonthlyLimit    money.Money `gorm:"type:bigint;default:0" json:"monthly_limit"`
+}
+
+// FeeRule describes the fee charged on top of a transaction. An empty
+// PaymentMethod matches every method, PercentageBps is in basis points
+// (100 = 1%) and a MaxFee of 0 means uncapped.
+type FeeRule struct {
+	ID              uint64      `gorm:"primary_key:auto_increment" json:"id"`
+	TransactionType string      `gorm:"type:varchar(20);index" json:"transaction_type"`
+	PaymentMethod   string      `gorm:"type:varchar(20)" json:"payment_method"`
+	FlatFee         money.Money `gorm:"type:bigint;default:0" json:"flat_fee"`
+	PercentageBps   uint64      `gorm:"type:int(10);default:0" json:"percentage_bps"`
+	MinFee          money.Money `gorm:"type:bigint;default:0" json:"min_fee"`
+	MaxFee          money.Money `gorm:"type:bigint;default:0" json:"max_fee"`
+}
+
+// FeePosting records a fee collected by SelfBank, kept apart from the
+// transaction it was charged on.
+type FeePosting struct {
+	ID              uint64      `gorm:"primary_key:auto_increment" json:"id"`
+	ID_User         uint64      `gorm:"type:int(100);index" json:"id_user"`
+	User            User        `gorm:"foreignKey:ID_User" json:"-"`
+	TransactionType string      `gorm:"type:varchar(20)" json:"transaction_type"`
+	ReferenceID     string      `gorm:"type:varchar(255);index" json:"reference_id"`
+	Amount          money.Money `gorm:"type:bigint" json:"amount"`
+	Date            int64       `gorm:"type:bigint" json:"date"`
+}
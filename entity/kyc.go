@@ -0,0 +1,28 @@
+package entity
+
+const (
+	KycTierUnverified uint64 = 1
+	KycTierVerified   uint64 = 2
+)
+
+const (
+	KycStatusPending  uint64 = 1
+	KycStatusApproved uint64 = 2
+	KycStatusRejected uint64 = 3
+)
+
+type KycSubmission struct {
+	ID           uint64 `gorm:"primary_key:auto_increment" json:"id"`
+	ID_User      uint64 `gorm:"type:int(100);index" json:"id_user"`
+	User         User   `gorm:"foreignKey:ID_User" json:"-"`
+	NIK          string `gorm:"type:varchar(16);index" json:"nik"`
+	DateOfBirth  string `gorm:"type:varchar(10)" json:"date_of_birth"`
+	Address      string `gorm:"type:varchar(255)" json:"address"`
+	KtpPath      string `gorm:"type:varchar(255)" json:"-"`
+	SelfiePath   string `gorm:"type:varchar(255)" json:"-"`
+	Status       uint64 `gorm:"type:int(10);default:1" json:"status"`
+	RejectReason string `gorm:"type:varchar(255)" json:"reject_reason,omitempty"`
+	ReviewedBy   uint64 `gorm:"type:int(100)" json:"reviewed_by,omitempty"`
+	ReviewedAt   int64  `gorm:"type:bigint" json:"reviewed_at,omitempty"`
+	SubmittedAt  int64  `gorm:"type:bigint" json:"submitted_at"`
+}
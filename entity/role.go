@@ -0,0 +1,11 @@
+package entity
+
+const (
+	RoleAdmin uint64 = 1
+	RoleUser  uint64 = 2
+)
+
+type Role struct {
+	ID   uint64 `gorm:"primary_key:auto_increment" json:"id"`
+	Name string `gorm:"type:varchar(50);uniqueIndex" json:"name"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
+// TransferQuote is the result of a transfer inquiry. It lives in Redis until
+// the user confirms it or it expires, so it has no table of its own.
+type TransferQuote struct {
+	ID              string      `json:"id"`
+	ID_User         uint64      `json:"id_user"`
+	TransactionFrom uint64      `json:"acc_number_from"`
+	TransactionTo   uint64      `json:"acc_number_to"`
+	RecipientName   string      `json:"recipient_name"`
+	Amount          money.Money `json:"amount"`
+	Fee             money.Money `json:"fee"`
+	Note            string      `json:"note"`
+	Category        string      `json:"category"`
+	ExpiresAt       int64       `json:"expires_at"`
+}
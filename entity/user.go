@@ -1,19 +1,35 @@
 package entity
 
+import "github.com/IrvanWijayaSardam/SelfBank/money"
+
+// Only active accounts move money. Frozen ones are blocked by an admin,
+// dormant ones went idle and are reactivated by their owner, closed ones are
+// gone for good unless an admin reopens them.
+const (
+	UserStatusActive  uint64 = 1
+	UserStatusClosed  uint64 = 2
+	UserStatusFrozen  uint64 = 3
+	UserStatusDormant uint64 = 4
+)
+
 type User struct {
-	ID            uint64 `gorm:"primary_key:auto_increment" json:"id"`
-	Namadepan     string `gorm:"type:varchar(255)" json:"nama_depan"`
-	Namabelakang  string `gorm:"type:varchar(255)" json:"nama_belakang"`
-	Email         string `gorm:"type:varchar(255)" json:"email"`
-	Username      string `gorm:"type:varchar(255)" json:"username"`
-	Password      string `gorm:"->;<-;not null" json:"-"`
-	Telephone     string `gorm:"type:varchar(255)" json:"telp"`
-	Jk            string `gorm:"type:varchar(255)" json:"jk"`
-	Profile       string `gorm:"type:varchar(255)" json:"profile"`
-	Token         string `gorm:"-" json:"token,omitempty"`
-	Balance       string `gorm:"-" json:"balance,omitempty"`
-	AccountNumber uint64 `gorm:"type:varchar(255)" json:"acc_number"`
-	IdRole        uint64 `gorm:"type:bigint" json:"idrole"`
-	Status        uint64 `gorm:"type:int(100);default:1" json:"status"`
-	IsVerified    bool   `gorm:"type:boolean" json:"is_verified`
+	ID            uint64       `gorm:"primary_key:auto_increment" json:"id"`
+	Namadepan     string       `gorm:"type:varchar(255)" json:"nama_depan"`
+	Namabelakang  string       `gorm:"type:varchar(255)" json:"nama_belakang"`
+	Email         string       `gorm:"type:varchar(255);uniqueIndex" json:"email"`
+	Username      string       `gorm:"type:varchar(255)" json:"username"`
+	Password      string       `gorm:"->;<-;not null" json:"-"`
+	Telephone     string       `gorm:"type:varchar(255)" json:"telp"`
+	Jk            string       `gorm:"type:varchar(255)" json:"jk"`
+	Profile       string       `gorm:"type:varchar(255)" json:"profile"`
+	ProfileThumb  string       `gorm:"type:varchar(255)" json:"profile_thumbnail"`
+	Token         string       `gorm:"-" json:"token,omitempty"`
+	Balance       *money.Money `gorm:"-" json:"balance,omitempty"`
+	AccountNumber uint64       `gorm:"type:varchar(20);uniqueIndex" json:"acc_number"`
+	IdRole        uint64       `gorm:"type:bigint" json:"idrole"`
+	Status        uint64       `gorm:"type:int(100);default:1" json:"status"`
+	IsVerified    bool         `gorm:"type:boolean" json:"is_verified"`
+	KycTier       uint64       `gorm:"type:int(10);default:1" json:"kyc_tier"`
+	ActiveSince   int64        `gorm:"type:bigint" json:"-"`
+	ClosedAt      int64        `gorm:"type:bigint" json:"closed_at,omitempty"`
 }
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+
+	"gorm.io/gorm"
+)
+
+// Wallet holds the funds of a user in a single currency. Like the IDR account
+// balance, the balance is not stored but derived from the postings.
+type Wallet struct {
+	ID        uint64      `gorm:"primary_key:auto_increment" json:"id"`
+	ID_User   uint64      `gorm:"type:int(100);uniqueIndex:idx_wallet_user_currency" json:"id_user"`
+	User      User        `gorm:"foreignKey:ID_User" json:"-"`
+	Currency  string      `gorm:"type:varchar(3);uniqueIndex:idx_wallet_user_currency" json:"currency"`
+	Balance   money.Money `gorm:"-" json:"balance"`
+	CreatedAt int64       `gorm:"type:bigint" json:"created_at"`
+}
+
+// WalletTransfer moves funds between two wallets. CreditAmount only differs
+// from DebitAmount when the transfer was converted at Rate. AmountIDR is the
+// IDR value used for the transfer limits.
+type WalletTransfer struct {
+	ID           uint64      `gorm:"primary_key:auto_increment" json:"id"`
+	ID_User      uint64      `gorm:"type:int(100);index" json:"id_user"`
+	ToUser       uint64      `gorm:"type:int(100);index" json:"to_user"`
+	AccountFrom  uint64      `gorm:"type:varchar(255)" json:"acc_number_from"`
+	AccountTo    uint64      `gorm:"type:varchar(255)" json:"acc_number_to"`
+	FromCurrency string      `gorm:"type:varchar(3)" json:"from_currency"`
+	ToCurrency   string      `gorm:"type:varchar(3)" json:"to_currency"`
+	DebitAmount  money.Money `gorm:"type:bigint" json:"debit_amount"`
+	CreditAmount money.Money `gorm:"type:bigint" json:"credit_amount"`
+	Rate         string      `gorm:"type:varchar(32)" json:"rate"`
+	AmountIDR    money.Money `gorm:"column:amount_idr;type:bigint" json:"-"`
+	Note         string      `gorm:"type:varchar(255)" json:"note"`
+	Date         int64       `gorm:"type:bigint" json:"date"`
+	Status       uint64      `gorm:"type:int(100);default:1" json:"status"`
+}
+
+// AfterFind restores the currencies of the amounts, the table only stores
+// their minor units.
+func (t *WalletTransfer) AfterFind(tx *gorm.DB) error {
+	t.DebitAmount.Currency = t.FromCurrency
+	t.CreditAmount.Currency = t.ToCurrency
+	return nil
+}
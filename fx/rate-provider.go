@@ -0,0 +1,73 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+)
+
+// RateDecimals is the precision rates are shown and stored with.
+const RateDecimals = 8
+
+var ErrRateUnavailable = errors.New("Exchange rate is not available")
+
+// RateProvider returns how many units of to one unit of from buys.
+type RateProvider interface {
+	Rate(from string, to string) (*big.Rat, error)
+}
+
+// Conversion is the result of Convert.
+type Conversion struct {
+	Amount money.Money
+	Result money.Money
+	Rate   *big.Rat
+}
+
+// RateString formats the rate the way it is stored on transfers.
+func (c Conversion) RateString() string {
+	return c.Rate.FloatString(RateDecimals)
+}
+
+// Convert turns amount into the to currency, rounding down so the bank never
+// pays out more than it received.
+func Convert(provider RateProvider, amount money.Money, to string) (Conversion, error) {
+	fromExponent, ok := money.Exponent(amount.Currency)
+	if !ok {
+		return Conversion{}, fmt.Errorf("Currency %s is not supported", amount.Currency)
+	}
+	toExponent, ok := money.Exponent(to)
+	if !ok {
+		return Conversion{}, fmt.Errorf("Currency %s is not supported", to)
+	}
+
+	rate := big.NewRat(1, 1)
+	if amount.Currency != to {
+		var err error
+		rate, err = provider.Rate(amount.Currency, to)
+		if err != nil {
+			return Conversion{}, err
+		}
+	}
+
+	result := new(big.Rat).SetInt64(amount.Amount)
+	result.Mul(result, rate)
+	result.Mul(result, pow10(toExponent))
+	result.Quo(result, pow10(fromExponent))
+
+	converted := new(big.Int).Quo(result.Num(), result.Denom())
+	if !converted.IsInt64() {
+		return Conversion{}, money.ErrOverflow
+	}
+
+	return Conversion{
+		Amount: amount,
+		Result: money.New(converted.Int64(), to),
+		Rate:   rate,
+	}, nil
+}
+
+func pow10(exponent int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+}
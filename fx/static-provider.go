@@ -0,0 +1,77 @@
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// DefaultRates are used when no rates file is configured. They are only
+// meant for development and tests.
+var DefaultRates = map[string]string{
+	"IDR": "1",
+	"USD": "15500",
+	"SGD": "11400",
+}
+
+type staticProvider struct {
+	// values of one unit of each currency in the base currency
+	values map[string]*big.Rat
+}
+
+// NewStaticProvider builds a provider from the value of one unit of each
+// currency expressed in a common base currency, such as {"IDR": "1",
+// "USD": "15500"}. Values are decimal strings to keep them exact.
+func NewStaticProvider(values map[string]string) (RateProvider, error) {
+	provider := &staticProvider{values: make(map[string]*big.Rat, len(values))}
+	for currency, value := range values {
+		rat, ok := new(big.Rat).SetString(value)
+		if !ok || rat.Sign() <= 0 {
+			return nil, fmt.Errorf("Invalid rate %q for %s", value, currency)
+		}
+		provider.values[currency] = rat
+	}
+	return provider, nil
+}
+
+type rateFile struct {
+	Base  string            `json:"base"`
+	Rates map[string]string `json:"rates"`
+}
+
+// NewFileProvider reads a JSON file shaped like
+// {"base": "IDR", "rates": {"USD": "15500", "SGD": "11400"}}, where every rate
+// is the value of one unit of that currency in base.
+func NewFileProvider(path string) (RateProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("Invalid rates file %s: %w", path, err)
+	}
+	if file.Base == "" {
+		return nil, fmt.Errorf("Rates file %s has no base currency", path)
+	}
+
+	values := map[string]string{file.Base: "1"}
+	for currency, value := range file.Rates {
+		values[currency] = value
+	}
+	return NewStaticProvider(values)
+}
+
+func (p *staticProvider) Rate(from string, to string) (*big.Rat, error) {
+	fromValue, ok := p.values[from]
+	if !ok {
+		return nil, ErrRateUnavailable
+	}
+	toValue, ok := p.values[to]
+	if !ok {
+		return nil, ErrRateUnavailable
+	}
+	return new(big.Rat).Quo(fromValue, toValue), nil
+}
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+)
+
+// New account numbers are AccountNumberLength digits long: the branch
+// prefix, random digits and a Luhn check digit over the rest. The 7-digit
+// numbers given out before have no check digit and stay valid.
+const (
+	AccountNumberLength        = 10
+	DefaultAccountNumberPrefix = "100"
+	legacyAccountNumberLength  = 7
+)
+
+// A prefix leaves at least five random digits and cannot start with 0, the
+// numbers are stored as integers.
+var accountNumberPrefixPattern = regexp.MustCompile(`^[1-9][0-9]{0,3}$`)
+
+func ValidAccountNumberPrefix(prefix string) bool {
+	return accountNumberPrefixPattern.MatchString(prefix)
+}
+
+// GenerateAccountNumber returns a random account number starting with
+// prefix. It is not checked against the taken ones, the unique index on
+// users.account_number is.
+func GenerateAccountNumber(prefix string) (uint64, error) {
+	if !ValidAccountNumberPrefix(prefix) {
+		return 0, fmt.Errorf("Invalid account number prefix %q", prefix)
+	}
+
+	digits := []byte(prefix)
+	for len(digits) < AccountNumberLength-1 {
+		n, err := crand.Int(crand.Reader, big.NewInt(10))
+		if err != nil {
+			return 0, err
+		}
+		digits = append(digits, byte('0'+n.Int64()))
+	}
+	digits = append(digits, luhnCheckDigit(digits))
+	return strconv.ParseUint(string(digits), 10, 64)
+}
+
+// ValidAccountNumber reports whether number is a legacy account number or a
+// new one with the right check digit. The check catches any single mistyped
+// digit and most swapped neighbours.
+func ValidAccountNumber(number uint64) bool {
+	digits := strconv.FormatUint(number, 10)
+	switch len(digits) {
+	case legacyAccountNumberLength:
+		return true
+	case AccountNumberLength:
+		last := len(digits) - 1
+		return luhnCheckDigit([]byte(digits[:last])) == digits[last]
+	}
+	return false
+}
+
+// luhnCheckDigit returns the digit that makes digits pass the Luhn check
+// once appended.
+func luhnCheckDigit(digits []byte) byte {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
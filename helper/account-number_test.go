@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidAccountNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		number uint64
+		valid  bool
+	}{
+		{"Luhn Valid", 1000000016, true},
+		{"Luhn Valid Other Prefix", 1001234564, true},
+		{"Luhn Valid Short Prefix", 4512345671, true},
+		{"All Nines", 9999999999, true},
+		{"Legacy", 1234567, true},
+		{"Wrong Check Digit", 1000000018, false},
+		{"Mistyped Digit", 1000000116, false},
+		{"Swapped Neighbours", 1002134564, false},
+		{"Too Short", 100000001, false},
+		{"Too Long", 10000000166, false},
+		{"Luhn Valid But 11 Digits", 79927398713, false},
+		{"Between Lengths", 12345678, false},
+		{"Zero", 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.valid, ValidAccountNumber(test.number))
+		})
+	}
+}
+
+func TestLuhnCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+	}{
+		{"7992739871", '3'},
+		{"100000001", '6'},
+		{"100123456", '4'},
+		{"000000000", '0'},
+	}
+
+	for _, test := range tests {
+		t.Run(test.digits, func(t *testing.T) {
+			assert.Equal(t, test.want, luhnCheckDigit([]byte(test.digits)))
+		})
+	}
+}
+
+func TestGenerateAccountNumber(t *testing.T) {
+	tests := []struct {
+		prefix string
+		valid  bool
+	}{
+		{DefaultAccountNumberPrefix, true},
+		{"1", true},
+		{"45", true},
+		{"9999", true},
+		{"", false},
+		{"0", false},
+		{"012", false},
+		{"12345", false},
+		{"1a", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.prefix, func(t *testing.T) {
+			assert.Equal(t, test.valid, ValidAccountNumberPrefix(test.prefix))
+
+			number, err := GenerateAccountNumber(test.prefix)
+			if !test.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			digits := strconv.FormatUint(number, 10)
+			assert.Len(t, digits, AccountNumberLength)
+			assert.True(t, strings.HasPrefix(digits, test.prefix), "%s starts with %s", digits, test.prefix)
+			assert.True(t, ValidAccountNumber(number), "%s passes the Luhn check", digits)
+		})
+	}
+}
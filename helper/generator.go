@@ -1,17 +1,14 @@
 package helper
 
 import (
+	crand "crypto/rand"
+	"encoding/base64"
+	"math/big"
 	"math/rand"
+	"strconv"
 	"time"
 )
 
-func GenerateRandomAccountNumber() uint64 {
-	rand.Seed(time.Now().UnixNano())
-	min := 1000000 // 7-digit minimum number
-	max := 9999999 // 7-digit maximum number
-	return uint64(min + rand.Intn(max-min+1))
-}
-
 func GenerateTrxId() uint64 {
 	rand.Seed(time.Now().UnixNano())
 	min := 1000000000 // 7-digit minimum number
@@ -31,3 +28,22 @@ func ConvertUnixtime(epoch int64) time.Time {
 
 	return date
 }
+
+// GenerateOTP returns a random 7-digit one-time password.
+func GenerateOTP() (string, error) {
+	n, err := crand.Int(crand.Reader, big.NewInt(9000000))
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(1000000+n.Int64(), 10), nil
+}
+
+// GenerateRandomPassword returns a random password for a user to sign in
+// with and then change.
+func GenerateRandomPassword() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := crand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
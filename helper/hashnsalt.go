@@ -1,15 +1,14 @@
 package helper
 
 import (
-	"log"
-
+	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 )
 
 func HashAndSalt(pwd []byte) string {
 	hash, err := bcrypt.GenerateFromPassword(pwd, bcrypt.MinCost)
 	if err != nil {
-		log.Println(err)
+		logrus.Error(err)
 		panic("Failed to hash a password")
 	}
 	return string(hash)
@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MaskName keeps the first two letters of every word and hides the rest,
+// e.g. "Irvan Wijaya" becomes "Ir*** Wi****".
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(word)
+		if len(runes) <= 2 {
+			words[i] = word
+			continue
+		}
+		words[i] = string(runes[:2]) + strings.Repeat("*", len(runes)-2)
+	}
+	return strings.Join(words, " ")
+}
+
+// MaskAccountNumber keeps the last four digits of an account number and
+// hides the rest, e.g. 1000000018 becomes "******0018".
+func MaskAccountNumber(number uint64) string {
+	digits := strconv.FormatUint(number, 10)
+	if len(digits) <= 4 {
+		return strings.Repeat("*", len(digits))
+	}
+	return strings.Repeat("*", len(digits)-4) + digits[len(digits)-4:]
+}
@@ -12,7 +12,16 @@ type ResponseOK struct {
 }
 
 type ResponseError struct {
-	Status  bool   `json:"status"`
+	Status  bool         `json:"status"`
+	Code    string       `json:"code,omitempty"`
+	Message string       `json:"message"`
+	Detail  string       `json:"detail,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
 	Message string `json:"message"`
 }
 
@@ -52,3 +61,12 @@ func BuildErrorResponse(message string) ResponseError {
 	}
 	return res
 }
+
+func BuildErrorResponseWithCode(code string, message string) ResponseError {
+	res := ResponseError{
+		Status:  false,
+		Code:    code,
+		Message: message,
+	}
+	return res
+}
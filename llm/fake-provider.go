@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// FakeRule scripts the fake provider's answer to user messages containing
+// Match, ignoring case. An empty Match answers anything. With Function set
+// the fake first calls that function with Arguments, then replies with
+// Reply where {result} is the function's result.
+type FakeRule struct {
+	Match     string          `json:"match"`
+	Function  string          `json:"function"`
+	Arguments json.RawMessage `json:"arguments"`
+	Reply     string          `json:"reply"`
+}
+
+// DefaultFakeRules echo the user's message back, {message} being replaced by
+// it.
+var DefaultFakeRules = []FakeRule{
+	{Reply: "You said: {message}"},
+}
+
+const fakeEmbeddingSize = 256
+
+type fakeProvider struct {
+	rules []FakeRule
+}
+
+// NewFakeProvider answers from rules, the first matching one wins, without
+// calling any model. It gives the same answer to the same conversation every
+// time, for working offline and for tests.
+func NewFakeProvider(rules []FakeRule) Provider {
+	return &fakeProvider{rules: append(append([]FakeRule(nil), rules...), DefaultFakeRules...)}
+}
+
+// LoadFakeRules reads the rules of a fake provider from a JSON file holding
+// an array of rules.
+func LoadFakeRules(path string) ([]FakeRule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []FakeRule
+	if err := json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("Failed to parse fake chatbot script: %w", err)
+	}
+	return rules, nil
+}
+
+func (provider *fakeProvider) Complete(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition) (Completion, error) {
+	if err := ctx.Err(); err != nil {
+		return Completion{}, err
+	}
+	reply := provider.reply(messages)
+	return Completion{Message: reply, Usage: estimateUsage(messages, functions, reply)}, nil
+}
+
+func (provider *fakeProvider) reply(messages []openai.ChatCompletionMessage) openai.ChatCompletionMessage {
+	message, result, called := lastTurn(messages)
+	rule := provider.match(message)
+	if rule.Function != "" && !called {
+		arguments := string(rule.Arguments)
+		if arguments == "" {
+			arguments = "{}"
+		}
+		return openai.ChatCompletionMessage{
+			Role:         openai.ChatMessageRoleAssistant,
+			FunctionCall: &openai.FunctionCall{Name: rule.Function, Arguments: arguments},
+		}
+	}
+
+	reply := strings.NewReplacer("{message}", message, "{result}", result).Replace(rule.Reply)
+	return openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: reply}
+}
+
+// Stream sends the reply word by word.
+func (provider *fakeProvider) Stream(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition, onContent func(content string) error) (Completion, error) {
+	completion, err := provider.Complete(ctx, messages, functions)
+	if err != nil || completion.Message.FunctionCall != nil {
+		return completion, err
+	}
+
+	for _, word := range strings.SplitAfter(completion.Message.Content, " ") {
+		if word == "" {
+			continue
+		}
+		if err := onContent(word); err != nil {
+			return Completion{}, err
+		}
+	}
+	return completion, nil
+}
+
+// Embed hashes the words of each text into a fixed number of dimensions, so
+// texts sharing words end up close. It is no match for a real model but
+// enough to find FAQ articles offline.
+func (provider *fakeProvider) Embed(ctx context.Context, texts []string) ([]Vector, error) {
+	vectors := make([]Vector, len(texts))
+	for i, text := range texts {
+		vector := make(Vector, fakeEmbeddingSize)
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), isWordSeparator) {
+			hash := fnv.New32a()
+			hash.Write([]byte(word))
+			vector[hash.Sum32()%fakeEmbeddingSize]++
+		}
+		vectors[i] = vector
+	}
+	return vectors, nil
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func (provider *fakeProvider) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (provider *fakeProvider) match(message string) FakeRule {
+	lowered := strings.ToLower(message)
+	for _, rule := range provider.rules {
+		if strings.Contains(lowered, strings.ToLower(rule.Match)) {
+			return rule
+		}
+	}
+	return FakeRule{}
+}
+
+// lastTurn returns the latest user message and, when a function was called
+// since, the result of the last call.
+func lastTurn(messages []openai.ChatCompletionMessage) (message string, result string, called bool) {
+	for i := len(messages) - 1; i >= 0; i-- {
+		switch messages[i].Role {
+		case openai.ChatMessageRoleUser:
+			return messages[i].Content, result, called
+		case openai.ChatMessageRoleFunction:
+			if !called {
+				result, called = messages[i].Content, true
+			}
+		}
+	}
+	return "", result, called
+}
@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+)
+
+// FilteredText replaces the text that looked like instructions to the model.
+const FilteredText = "[filtered]"
+
+// injectionPatterns match text trying to pass for instructions. Tool results
+// carry text other people wrote, like the note of an incoming transfer, and
+// the model must not take orders from it.
+var injectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\b.{0,30}\b(instructions?|prompts?|rules|guidelines)\b`),
+	regexp.MustCompile(`(?i)\b(abaikan|lupakan)\b.{0,30}\b(instruksi|perintah|aturan)\b`),
+	regexp.MustCompile(`(?i)\b(you are now|act as|pretend to be|new instructions|system prompt)\b`),
+	regexp.MustCompile(`(?i)</?\s*(system|assistant|user|tool|function)\s*>`),
+	regexp.MustCompile(`(?im)^\s*(system|assistant|developer)\s*:`),
+	regexp.MustCompile(`(?i)\b(call|use|run)\b.{0,20}\b(function|tool)\b`),
+}
+
+// FilterInjection replaces text that looks like a prompt injection with
+// FilteredText and tells whether it did.
+func FilterInjection(text string) (string, bool) {
+	for _, pattern := range injectionPatterns {
+		if pattern.MatchString(text) {
+			return FilteredText, true
+		}
+	}
+	return text, false
+}
+
+// FilterInjectionJSON runs FilterInjection over every string of a JSON
+// document, keys excepted, and returns the document with how many strings
+// were filtered.
+func FilterInjectionJSON(document []byte) ([]byte, int, error) {
+	// Numbers are kept as they were written, float64 would round large ones.
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, 0, err
+	}
+
+	filtered := 0
+	value = filterValue(value, &filtered)
+	if filtered == 0 {
+		return document, 0, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	return encoded, filtered, err
+}
+
+func filterValue(value interface{}, filtered *int) interface{} {
+	switch typed := value.(type) {
+	case string:
+		text, ok := FilterInjection(typed)
+		if ok {
+			*filtered++
+		}
+		return text
+	case []interface{}:
+		for i := range typed {
+			typed[i] = filterValue(typed[i], filtered)
+		}
+	case map[string]interface{}:
+		for key := range typed {
+			typed[key] = filterValue(typed[key], filtered)
+		}
+	}
+	return value
+}
@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+type openAIProvider struct {
+	client  *openai.Client
+	options Options
+}
+
+// NewOpenAIProvider talks to OpenAI, or to any server with an OpenAI
+// compatible API such as llama.cpp or Ollama when the client is configured
+// with its base URL.
+func NewOpenAIProvider(client *openai.Client, options Options) Provider {
+	return &openAIProvider{client: client, options: options}
+}
+
+func (provider *openAIProvider) request(messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition) openai.ChatCompletionRequest {
+	return openai.ChatCompletionRequest{
+		Model:       provider.options.Model,
+		Messages:    messages,
+		Functions:   functions,
+		Temperature: provider.options.Temperature,
+	}
+}
+
+func (provider *openAIProvider) Complete(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition) (Completion, error) {
+	ctx, cancel := context.WithTimeout(ctx, provider.options.Timeout)
+	defer cancel()
+
+	resp, err := provider.client.CreateChatCompletion(ctx, provider.request(messages, functions))
+	if err != nil {
+		return Completion{}, err
+	}
+	if len(resp.Choices) == 0 {
+		return Completion{}, ErrNoChoices
+	}
+
+	return Completion{
+		Message: resp.Choices[0].Message,
+		Usage:   Usage{PromptTokens: resp.Usage.PromptTokens, CompletionTokens: resp.Usage.CompletionTokens},
+	}, nil
+}
+
+func (provider *openAIProvider) Stream(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition, onContent func(content string) error) (Completion, error) {
+	ctx, cancel := context.WithTimeout(ctx, provider.options.Timeout)
+	defer cancel()
+
+	stream, err := provider.client.CreateChatCompletionStream(ctx, provider.request(messages, functions))
+	if err != nil {
+		return Completion{}, err
+	}
+	defer stream.Close()
+
+	var content, name, arguments strings.Builder
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Completion{}, err
+		}
+		if len(resp.Choices) == 0 {
+			continue
+		}
+
+		delta := resp.Choices[0].Delta
+		if delta.FunctionCall != nil {
+			name.WriteString(delta.FunctionCall.Name)
+			arguments.WriteString(delta.FunctionCall.Arguments)
+		}
+		if delta.Content != "" {
+			content.WriteString(delta.Content)
+			if err := onContent(delta.Content); err != nil {
+				return Completion{}, err
+			}
+		}
+	}
+
+	message := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content.String()}
+	if name.Len() > 0 {
+		message.FunctionCall = &openai.FunctionCall{Name: name.String(), Arguments: arguments.String()}
+	}
+	// Streams do not report their usage.
+	return Completion{Message: message, Usage: estimateUsage(messages, functions, message)}, nil
+}
+
+func (provider *openAIProvider) Embed(ctx context.Context, texts []string) ([]Vector, error) {
+	var model openai.EmbeddingModel
+	model.UnmarshalText([]byte(provider.options.EmbeddingModel))
+	if model == openai.Unknown {
+		return nil, fmt.Errorf("Embedding model %q is not supported", provider.options.EmbeddingModel)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, provider.options.Timeout)
+	defer cancel()
+
+	resp, err := provider.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{Input: texts, Model: model})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("Expected %d embeddings, got %d", len(texts), len(resp.Data))
+	}
+
+	vectors := make([]Vector, len(texts))
+	for _, embedding := range resp.Data {
+		if embedding.Index < 0 || embedding.Index >= len(vectors) {
+			return nil, fmt.Errorf("Embedding index %d is out of range", embedding.Index)
+		}
+		vectors[embedding.Index] = embedding.Embedding
+	}
+	return vectors, nil
+}
+
+func (provider *openAIProvider) Ping(ctx context.Context) error {
+	_, err := provider.client.ListModels(ctx)
+	return err
+}
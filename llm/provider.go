@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// ErrNoChoices is returned when the model answered without any message.
+var ErrNoChoices = errors.New("The model returned no choices")
+
+// Provider is a chat model the chatbot talks to. Messages and functions use
+// the OpenAI chat format, which self-hosted servers speak as well.
+type Provider interface {
+	// Complete returns the assistant's next message, which is either a reply
+	// or a call to one of functions.
+	Complete(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition) (Completion, error)
+	// Stream works like Complete but passes each piece of the reply to
+	// onContent as it arrives. A function call is only returned once
+	// complete.
+	Stream(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition, onContent func(content string) error) (Completion, error)
+	// Embed returns the embedding of each text, in order.
+	Embed(ctx context.Context, texts []string) ([]Vector, error)
+	// Ping checks that the provider can be reached.
+	Ping(ctx context.Context) error
+}
+
+// Options tune the requests sent to a provider. Timeout bounds a single
+// request, a streamed one included.
+type Options struct {
+	Model          string
+	EmbeddingModel string
+	Temperature    float32
+	Timeout        time.Duration
+}
+
+// Completion is the answer of a provider with the tokens it took.
+type Completion struct {
+	Message openai.ChatCompletionMessage
+	Usage   Usage
+}
+
+// Usage counts the tokens of a request. Estimated is set when the provider
+// did not report them, as for streams, and they were counted with
+// EstimateTokens instead.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	Estimated        bool
+}
+
+// Add sums two usages.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		Estimated:        u.Estimated || other.Estimated,
+	}
+}
+
+// EstimateTokens approximates the tokens of a text without a tokenizer,
+// about four characters per token plus the overhead of a message.
+func EstimateTokens(text string) int {
+	return utf8.RuneCountInString(text)/4 + 4
+}
+
+// estimateUsage counts the tokens of a request the provider did not report.
+func estimateUsage(messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition, reply openai.ChatCompletionMessage) Usage {
+	usage := Usage{Estimated: true}
+	for _, message := range messages {
+		usage.PromptTokens += EstimateTokens(message.Content)
+		if message.FunctionCall != nil {
+			usage.PromptTokens += EstimateTokens(message.FunctionCall.Arguments)
+		}
+	}
+	for _, function := range functions {
+		encoded, _ := json.Marshal(function)
+		usage.PromptTokens += EstimateTokens(string(encoded))
+	}
+
+	usage.CompletionTokens = EstimateTokens(reply.Content)
+	if reply.FunctionCall != nil {
+		usage.CompletionTokens += EstimateTokens(reply.FunctionCall.Name + reply.FunctionCall.Arguments)
+	}
+	return usage
+}
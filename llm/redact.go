@@ -0,0 +1,38 @@
+package llm
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`(?:\+62|\b62|\b0)[ -]?8[1-9](?:[ -]?[0-9]){6,10}\b`)
+	// OTPs, PINs and account numbers look like any other number, they are
+	// recognized by the word in front of them.
+	codePattern    = regexp.MustCompile(`(?i)\b(otp|pin|kode|code|password|sandi|token)\b([^\d\n]{0,30})\d{4,8}\b`)
+	accountPattern = regexp.MustCompile(`(?i)\b(rekening|rek|norek|account|acc)\b([^\d\n]{0,30})\d{7,16}\b`)
+	// Longer runs of digits are NIKs, card or account numbers whatever
+	// surrounds them. Amounts are usually written with separators.
+	longNumberPattern = regexp.MustCompile(`\b\d{10,}\b`)
+	// A message holding nothing but a short number is most likely a code
+	// pasted on its own.
+	bareCodePattern = regexp.MustCompile(`^\s*\d{4,8}\s*$`)
+)
+
+// Redact replaces the personal data in a message with placeholders before
+// it is sent to a provider: email addresses, phone numbers, OTPs and PINs,
+// and account and other identity numbers. The placeholders keep the message
+// understandable for the model.
+func Redact(message string) string {
+	if bareCodePattern.MatchString(message) {
+		return "[CODE]"
+	}
+
+	message = emailPattern.ReplaceAllString(message, "[EMAIL]")
+	message = phonePattern.ReplaceAllString(message, "[PHONE]")
+	message = codePattern.ReplaceAllString(message, "$1$2[CODE]")
+	message = accountPattern.ReplaceAllString(message, "$1$2[ACCOUNT_NUMBER]")
+	message = longNumberPattern.ReplaceAllString(message, "[NUMBER]")
+	return strings.TrimSpace(message)
+}
@@ -0,0 +1,36 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"Email", "mail me at irvan.w@example.co.id please", "mail me at [EMAIL] please"},
+		{"International Phone", "call +62 812-3456-7890 now", "call [PHONE] now"},
+		{"Local Phone", "my number is 081234567890", "my number is [PHONE]"},
+		{"OTP", "my otp is 123456", "my otp is [CODE]"},
+		{"PIN", "PIN: 1234 right?", "PIN: [CODE] right?"},
+		{"Indonesian Code", "kode verifikasi 7654321", "kode verifikasi [CODE]"},
+		{"Account Number", "send it to rekening 1234567", "send it to rekening [ACCOUNT_NUMBER]"},
+		{"English Account Number", "my account no. 1000000018", "my account no. [ACCOUNT_NUMBER]"},
+		{"Long Number", "NIK 3174012345678901", "NIK [NUMBER]"},
+		{"Bare Code", "  482913 ", "[CODE]"},
+		{"Amount With Separators", "how do I top up Rp 50.000 with gopay?", "how do I top up Rp 50.000 with gopay?"},
+		{"Short Number", "I sent 250000 yesterday", "I sent 250000 yesterday"},
+		{"Plain Question", "Why was my deposit cancelled?", "Why was my deposit cancelled?"},
+		{"Trims Spaces", "  hello  ", "hello"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Redact(test.message))
+		})
+	}
+}
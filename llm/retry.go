@@ -0,0 +1,105 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sirupsen/logrus"
+
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+)
+
+type retryProvider struct {
+	Provider
+	retries int
+	backoff time.Duration
+}
+
+// WithRetry retries the failed requests of provider that may succeed on a
+// second try: rate limits, server errors, network errors and timeouts. The
+// wait starts at backoff and doubles with every retry. A stream is only
+// retried before it produced any content, the client already has the rest.
+func WithRetry(provider Provider, retries int, backoff time.Duration) Provider {
+	if retries <= 0 {
+		return provider
+	}
+	return &retryProvider{Provider: provider, retries: retries, backoff: backoff}
+}
+
+func (provider *retryProvider) Complete(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition) (Completion, error) {
+	var completion Completion
+	err := provider.retry(ctx, func() (bool, error) {
+		var err error
+		completion, err = provider.Provider.Complete(ctx, messages, functions)
+		return true, err
+	})
+	return completion, err
+}
+
+func (provider *retryProvider) Stream(ctx context.Context, messages []openai.ChatCompletionMessage, functions []openai.FunctionDefinition, onContent func(content string) error) (Completion, error) {
+	var completion Completion
+	err := provider.retry(ctx, func() (bool, error) {
+		streamed := false
+		var err error
+		completion, err = provider.Provider.Stream(ctx, messages, functions, func(content string) error {
+			streamed = true
+			return onContent(content)
+		})
+		return !streamed, err
+	})
+	return completion, err
+}
+
+func (provider *retryProvider) Embed(ctx context.Context, texts []string) ([]Vector, error) {
+	var vectors []Vector
+	err := provider.retry(ctx, func() (bool, error) {
+		var err error
+		vectors, err = provider.Provider.Embed(ctx, texts)
+		return true, err
+	})
+	return vectors, err
+}
+
+// retry calls attempt until it succeeds, fails for good or says it cannot
+// be repeated.
+func (provider *retryProvider) retry(ctx context.Context, attempt func() (repeatable bool, err error)) error {
+	wait := provider.backoff
+	for retry := 0; ; retry++ {
+		repeatable, err := attempt()
+		if err == nil || !repeatable || retry == provider.retries || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{"retry": retry + 1, "wait": wait}).Warn("Chat model request failed, retrying")
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+func retryable(err error) bool {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.HTTPStatusCode)
+	}
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		return retryableStatus(requestErr.HTTPStatusCode)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
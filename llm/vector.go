@@ -0,0 +1,55 @@
+package llm
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Vector is an embedding. It is stored as little endian float32s.
+type Vector []float32
+
+func (v Vector) Value() (driver.Value, error) {
+	encoded := make([]byte, 4*len(v))
+	for i, value := range v {
+		binary.LittleEndian.PutUint32(encoded[4*i:], math.Float32bits(value))
+	}
+	return encoded, nil
+}
+
+func (v *Vector) Scan(src interface{}) error {
+	encoded, ok := src.([]byte)
+	if !ok && src != nil {
+		return fmt.Errorf("llm: cannot scan %T into a vector", src)
+	}
+	if len(encoded)%4 != 0 {
+		return fmt.Errorf("llm: vector of %d bytes is not a list of float32", len(encoded))
+	}
+
+	decoded := make(Vector, len(encoded)/4)
+	for i := range decoded {
+		decoded[i] = math.Float32frombits(binary.LittleEndian.Uint32(encoded[4*i:]))
+	}
+	*v = decoded
+	return nil
+}
+
+// Cosine returns the cosine similarity of two vectors, 0 when their lengths
+// differ, as they do for embeddings of different models.
+func Cosine(a Vector, b Vector) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Setup configures the standard logrus logger: JSON lines outside of dev,
+// redaction of secrets everywhere, and the standard library log package
+// routed through it so stray log.Println calls are structured as well.
+func Setup(profile string) {
+	logger := logrus.StandardLogger()
+	logger.SetOutput(os.Stdout)
+	logger.AddHook(redactHook{})
+
+	if profile == "dev" {
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+		logger.SetLevel(logrus.DebugLevel)
+	} else {
+		logger.SetFormatter(&logrus.JSONFormatter{
+			FieldMap: logrus.FieldMap{
+				logrus.FieldKeyTime: "time",
+				logrus.FieldKeyMsg:  "message",
+			},
+		})
+		logger.SetLevel(logrus.InfoLevel)
+	}
+
+	log.SetFlags(0)
+	log.SetOutput(writerFunc(func(line []byte) {
+		logger.Info(string(trimNewline(line)))
+	}))
+}
+
+type ctxKey struct{}
+
+// requestLog carries the fields of one request. It is stored as a pointer so
+// fields learned later, like the user ID once the token is checked, reach
+// every log line written with the same context.
+type requestLog struct {
+	mu     sync.RWMutex
+	fields logrus.Fields
+}
+
+// NewContext returns a copy of ctx that logs with fields.
+func NewContext(ctx context.Context, fields logrus.Fields) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &requestLog{fields: fields})
+}
+
+// AddFields attaches fields to the log lines of the request behind ctx. It
+// does nothing when ctx does not come from NewContext.
+func AddFields(ctx context.Context, fields logrus.Fields) {
+	requestLog, ok := ctx.Value(ctxKey{}).(*requestLog)
+	if !ok {
+		return
+	}
+
+	requestLog.mu.Lock()
+	defer requestLog.mu.Unlock()
+	for key, value := range fields {
+		requestLog.fields[key] = value
+	}
+}
+
+// FromContext returns a logger carrying the request ID and the other fields
+// of the request behind ctx.
+func FromContext(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(logrus.StandardLogger())
+	if ctx == nil {
+		return entry
+	}
+
+	requestLog, ok := ctx.Value(ctxKey{}).(*requestLog)
+	if !ok {
+		return entry
+	}
+
+	requestLog.mu.RLock()
+	defer requestLog.mu.RUnlock()
+	return entry.WithFields(requestLog.fields)
+}
+
+type writerFunc func(line []byte)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	f(p)
+	return len(p), nil
+}
+
+func trimNewline(line []byte) []byte {
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		return line[:n-1]
+	}
+	return line
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are field names whose values never reach the logs. Keys are
+// compared lower case with dashes and underscores removed.
+var sensitiveKeys = map[string]bool{
+	"password":        true,
+	"pass":            true,
+	"otp":             true,
+	"token":           true,
+	"authorization":   true,
+	"secret":          true,
+	"signature":       true,
+	"accountnumber":   true,
+	"accnumber":       true,
+	"accountfrom":     true,
+	"accountto":       true,
+	"verificationkey": true,
+	"nik":             true,
+}
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+[\w\-.~+/]+=*`)
+	jwtPattern    = regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]+`)
+	// Account numbers, OTPs and NIKs are all long runs of digits. Amounts are
+	// formatted with separators so they are left alone.
+	digitsPattern = regexp.MustCompile(`\b\d{6,}\b`)
+)
+
+// RedactString masks tokens and long digit runs in free text.
+func RedactString(value string) string {
+	value = bearerPattern.ReplaceAllString(value, "Bearer "+redacted)
+	value = jwtPattern.ReplaceAllString(value, redacted)
+	return digitsPattern.ReplaceAllStringFunc(value, func(digits string) string {
+		return strings.Repeat("*", len(digits)-2) + digits[len(digits)-2:]
+	})
+}
+
+// IsSensitive reports whether a field with this name must be redacted.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	key = strings.NewReplacer("_", "", "-", "").Replace(key)
+	return sensitiveKeys[key]
+}
+
+type redactHook struct{}
+
+func (redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (redactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = RedactString(entry.Message)
+
+	// Data may be shared with the entry the caller still holds, so the
+	// redacted values go into a copy.
+	data := make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		switch {
+		case IsSensitive(key):
+			data[key] = redacted
+		case key == logrus.ErrorKey:
+			data[key] = RedactString(fmt.Sprint(value))
+		default:
+			if text, ok := value.(string); ok {
+				value = RedactString(text)
+			}
+			data[key] = value
+		}
+	}
+	entry.Data = data
+	return nil
+}
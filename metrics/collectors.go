@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/go-redis/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// RegisterDBStats exposes the connection pool of the MySQL database.
+func RegisterDBStats(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "mysql"))
+}
+
+// RegisterRedisStats exposes the connection pool of the Redis client.
+func RegisterRedisStats(client *redis.Client) {
+	prometheus.MustRegister(&redisCollector{client: client})
+}
+
+var (
+	redisHits = prometheus.NewDesc(namespace+"_redis_pool_hits_total",
+		"Times a free connection was found in the pool.", nil, nil)
+	redisMisses = prometheus.NewDesc(namespace+"_redis_pool_misses_total",
+		"Times a free connection was not found in the pool.", nil, nil)
+	redisTimeouts = prometheus.NewDesc(namespace+"_redis_pool_timeouts_total",
+		"Times a wait for a connection timed out.", nil, nil)
+	redisTotalConns = prometheus.NewDesc(namespace+"_redis_pool_connections",
+		"Connections in the pool.", nil, nil)
+	redisIdleConns = prometheus.NewDesc(namespace+"_redis_pool_idle_connections",
+		"Idle connections in the pool.", nil, nil)
+)
+
+type redisCollector struct {
+	client *redis.Client
+}
+
+func (c *redisCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- redisHits
+	ch <- redisMisses
+	ch <- redisTimeouts
+	ch <- redisTotalConns
+	ch <- redisIdleConns
+}
+
+func (c *redisCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(redisHits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(redisMisses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(redisTimeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(redisTotalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(redisIdleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "selfbank"
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	DepositsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deposits_created_total",
+		Help:      "Deposits created by payment method.",
+	}, []string{"payment_method"})
+
+	DepositStatusTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deposit_status_transitions_total",
+		Help:      "Deposit status changes reported by Midtrans.",
+	}, []string{"from", "to"})
+
+	MidtransNotifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "midtrans_notifications_total",
+		Help:      "Midtrans notifications by transaction status.",
+	}, []string{"transaction_status"})
+
+	TransfersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Money movements out of an account by kind (transfer, withdrawal, wallet).",
+	}, []string{"kind"})
+
+	TransferVolume = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_volume_idr_total",
+		Help:      "Rupiah moved out of accounts by kind (transfer, withdrawal, wallet).",
+	}, []string{"kind"})
+
+	OTPEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "otp_events_total",
+		Help:      "OTP sends and verifications by outcome.",
+	}, []string{"action", "outcome"})
+
+	ChatbotRequestDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "chatbot_request_duration_seconds",
+		Help:      "Latency of chatbot completions.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32},
+	})
+
+	ChatbotErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chatbot_errors_total",
+		Help:      "Chatbot requests that failed.",
+	})
+
+	ChatbotToolCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chatbot_tool_calls_total",
+		Help:      "Tools called by the chatbot by name.",
+	}, []string{"tool"})
+
+	ChatbotTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chatbot_tokens_total",
+		Help:      "Tokens used by the chatbot, by prompt or completion.",
+	}, []string{"type"})
+
+	ChatbotRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chatbot_rejections_total",
+		Help:      "Chatbot requests refused by the per-user quotas, by reason.",
+	}, []string{"reason"})
+
+	ChatbotFiltered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chatbot_filtered_total",
+		Help:      "Personal data redacted from messages and injections filtered from tool results.",
+	}, []string{"kind"})
+)
+
+const (
+	KindTransfer   = "transfer"
+	KindWithdrawal = "withdrawal"
+	KindWallet     = "wallet"
+
+	OutcomeSuccess     = "success"
+	OutcomeFailure     = "failure"
+	OutcomeUnavailable = "unavailable"
+)
+
+// DepositStatusName labels a deposit status in metrics.
+func DepositStatusName(status uint64) string {
+	switch status {
+	case 1:
+		return "created"
+	case 2:
+		return "pending"
+	case 3:
+		return "cancelled"
+	case 4:
+		return "denied"
+	case 5:
+		return "paid"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+)
+
+// ActiveAccount lets only active accounts through. It guards the routes that
+// move money and runs after AuthorizeJWT.
+func ActiveAccount(accountService service.AccountService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := c.Get("user").(jwt.MapClaims)
+			if !ok {
+				return apperror.ErrInvalidToken
+			}
+			userID, _ := claims["userid"].(string)
+			idUser, err := strconv.ParseUint(userID, 10, 64)
+			if err != nil {
+				return apperror.ErrInvalidToken
+			}
+
+			if err := accountService.EnsureActive(c.Request().Context(), idUser); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
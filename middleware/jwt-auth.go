@@ -1,40 +1,69 @@
 package middleware
 
 import (
-	"log"
-	"net/http"
+	"errors"
+	"strconv"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/service"
 
-	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
-func AuthorizeJWT(jwtService service.JWTService) echo.MiddlewareFunc {
+// AuthorizeJWT accepts a token only while it matches the account: frozen and
+// closed users are turned away and a role change makes the user sign in
+// again, so admin rights and freezes apply from the next request.
+func AuthorizeJWT(jwtService service.JWTService, userService service.UserService) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
-				response := helper.BuildErrorResponse("No Token Found !")
-				return c.JSON(http.StatusBadRequest, response)
+				return apperror.ErrInvalidToken.WithDetail("No token found")
 			}
 
 			tokenString := authHeader
 			token, err := jwtService.ValidateToken(tokenString)
 			if err != nil {
-				log.Println(err)
-				response := helper.BuildErrorResponse("Token is not valid -" + err.Error())
-				return c.JSON(http.StatusUnauthorized, response)
+				return apperror.ErrInvalidToken.Wrap(err)
 			}
 			if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+				userID, _ := claims["userid"].(string)
+				idUser, err := strconv.ParseUint(userID, 10, 64)
+				if err != nil {
+					return apperror.ErrInvalidToken
+				}
+				user, err := userService.FindUser(c.Request().Context(), idUser)
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return apperror.ErrInvalidToken
+				}
+				if err != nil {
+					return apperror.Internal(err)
+				}
+				if user.Status == entity.UserStatusFrozen || user.Status == entity.UserStatusClosed {
+					return service.StatusError(user.Status)
+				}
+				if roleID, ok := claims["idrole"].(float64); !ok || uint64(roleID) != user.IdRole {
+					return apperror.ErrInvalidToken.WithDetail("The role changed, sign in again")
+				}
+
 				c.Set("user", claims)
+				logging.AddFields(c.Request().Context(), logrus.Fields{"user_id": claims["userid"]})
+
+				// The changes made by the request are audited as the user's.
+				actor := audit.ActorFromContext(c.Request().Context())
+				actor.ID = idUser
+				c.SetRequest(c.Request().WithContext(audit.NewContext(c.Request().Context(), actor)))
 				return next(c)
 			}
 
-			response := helper.BuildErrorResponse("Invalid Token Claims")
-			return c.JSON(http.StatusUnauthorized, response)
+			return apperror.ErrInvalidToken
 		}
 	}
 }
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
+)
+
+// Metrics records the latency of every request by route pattern, so IDs in
+// the path do not explode the number of series.
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			started := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if httpErr, ok := err.(*echo.HTTPError); ok {
+				status = httpErr.Code
+			} else if appErr, ok := apperror.As(err); ok {
+				status = appErr.Status
+			}
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			metrics.HTTPRequestDuration.
+				WithLabelValues(c.Request().Method, route, strconv.Itoa(status)).
+				Observe(time.Since(started).Seconds())
+			return err
+		}
+	}
+}
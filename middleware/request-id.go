@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+)
+
+// validRequestID keeps IDs sent by proxies only when they cannot be used to
+// inject anything into the logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9\-_.]{1,64}$`)
+
+// RequestID reuses the X-Request-ID of the caller or assigns a new one, echoes
+// it in the response and attaches it to the request context so every log
+// line and audit log of the request carries it.
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+			if !validRequestID.MatchString(requestID) {
+				requestID = newRequestID()
+			}
+
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+			fields := logrus.Fields{
+				"request_id": requestID,
+				"route":      c.Path(),
+			}
+			// Lets the logs of a request be found from its trace and back.
+			if spanContext := trace.SpanContextFromContext(c.Request().Context()); spanContext.IsValid() {
+				fields["trace_id"] = spanContext.TraceID().String()
+			}
+			ctx := logging.NewContext(c.Request().Context(), fields)
+			ctx = audit.NewContext(ctx, audit.Actor{IP: c.RealIP(), RequestID: requestID})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
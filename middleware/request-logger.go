@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+)
+
+// RequestLogger writes one structured line per request. It must run after
+// RequestID so the line carries the request ID.
+func RequestLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			started := time.Now()
+			err := next(c)
+			if err != nil {
+				// Let the error handler write the response first so the
+				// logged status is the one the client got.
+				c.Error(err)
+			}
+
+			fields := logrus.Fields{
+				"method":     c.Request().Method,
+				"status":     c.Response().Status,
+				"latency_ms": time.Since(started).Milliseconds(),
+				"bytes_out":  c.Response().Size,
+				"remote_ip":  c.RealIP(),
+			}
+			if claims, ok := c.Get("user").(jwt.MapClaims); ok {
+				fields["user_id"] = claims["userid"]
+			}
+
+			entry := logging.FromContext(c.Request().Context()).WithFields(fields)
+			switch {
+			case err != nil && c.Response().Status < 500:
+				entry.WithError(err).Warn("Request failed")
+			case err != nil:
+				entry.WithError(err).Error("Request failed")
+			case c.Response().Status >= 500:
+				entry.Error("Request completed")
+			default:
+				entry.Info("Request completed")
+			}
+			return nil
+		}
+	}
+}
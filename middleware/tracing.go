@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+// untracedPaths are polled by probes and scrapers, tracing them would only
+// bury the real requests.
+var untracedPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Tracing starts a span for every request, continuing the trace of the
+// caller when it sends a traceparent header. Handlers pass the request
+// context down so services and repositories add their spans to it.
+func Tracing(serviceName string) echo.MiddlewareFunc {
+	return otelecho.Middleware(serviceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		return untracedPaths[c.Path()]
+	}))
+}
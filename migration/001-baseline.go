@@ -0,0 +1,100 @@
+package migration
+
+import "gorm.io/gorm"
+
+// baseline creates the schema exactly as AutoMigrate built it on every boot
+// before migrations existed, for the five tables of that time. The tables
+// are created only when missing, so a database set up by AutoMigrate adopts
+// this version unchanged and gets everything added since from the
+// migrations that follow.
+var baseline = Migration{
+	Version: 1,
+	Name:    "baseline",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, baselineTables)
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP TABLE IF EXISTS `transactions`",
+			"DROP TABLE IF EXISTS `withdrawals`",
+			"DROP TABLE IF EXISTS `payment_tokens`",
+			"DROP TABLE IF EXISTS `deposits`",
+			"DROP TABLE IF EXISTS `users`",
+		})
+	},
+}
+
+// baselineTables is the DDL AutoMigrate ran. The id_user columns took the
+// type of users.id from their foreign keys.
+var baselineTables = []string{
+	"CREATE TABLE IF NOT EXISTS `users` (" +
+		"`id` bigint unsigned AUTO_INCREMENT," +
+		"`namadepan` varchar(255)," +
+		"`namabelakang` varchar(255)," +
+		"`email` varchar(255)," +
+		"`username` varchar(255)," +
+		"`password` longtext NOT NULL," +
+		"`telephone` varchar(255)," +
+		"`jk` varchar(255)," +
+		"`profile` varchar(255)," +
+		"`account_number` varchar(255)," +
+		"`id_role` bigint," +
+		"`status` int(100) DEFAULT 1," +
+		"`is_verified` boolean," +
+		"PRIMARY KEY (`id`))",
+
+	"CREATE TABLE IF NOT EXISTS `deposits` (" +
+		"`id` varchar(191)," +
+		"`id_user` bigint unsigned," +
+		"`date` bigint," +
+		"`amount` int(100)," +
+		"`status` int(100) DEFAULT 1," +
+		"PRIMARY KEY (`id`)," +
+		"INDEX `idx_deposits_id_user` (`id_user`)," +
+		"CONSTRAINT `fk_deposits_user` FOREIGN KEY (`id_user`) REFERENCES `users`(`id`))",
+
+	"CREATE TABLE IF NOT EXISTS `payment_tokens` (" +
+		"`id` bigint unsigned AUTO_INCREMENT," +
+		"`created_at` datetime(3) NULL," +
+		"`updated_at` datetime(3) NULL," +
+		"`deleted_at` datetime(3) NULL," +
+		"`deposit_id` varchar(191) NOT NULL," +
+		"`payment_token` varchar(255) NOT NULL," +
+		"`virtual_acc` varchar(255) NOT NULL," +
+		"`callback_url` varchar(255) NOT NULL," +
+		"PRIMARY KEY (`id`)," +
+		"INDEX `idx_payment_tokens_deleted_at` (`deleted_at`)," +
+		"INDEX `idx_payment_tokens_deposit_id` (`deposit_id`))",
+
+	"CREATE TABLE IF NOT EXISTS `withdrawals` (" +
+		"`id` bigint unsigned AUTO_INCREMENT," +
+		"`id_user` bigint unsigned," +
+		"`date` bigint," +
+		"`amount` int(100)," +
+		"`to` varchar(255) NOT NULL," +
+		"`status` int(100) DEFAULT 1," +
+		"PRIMARY KEY (`id`)," +
+		"INDEX `idx_withdrawals_id_user` (`id_user`)," +
+		"CONSTRAINT `fk_withdrawals_user` FOREIGN KEY (`id_user`) REFERENCES `users`(`id`))",
+
+	"CREATE TABLE IF NOT EXISTS `transactions` (" +
+		"`id` bigint unsigned AUTO_INCREMENT," +
+		"`id_user` bigint unsigned," +
+		"`transaction_from` varchar(255)," +
+		"`transaction_to` varchar(255)," +
+		"`date` bigint," +
+		"`amount` int(100)," +
+		"`status` int(100) DEFAULT 1," +
+		"PRIMARY KEY (`id`)," +
+		"INDEX `idx_transactions_id_user` (`id_user`)," +
+		"CONSTRAINT `fk_transactions_user` FOREIGN KEY (`id_user`) REFERENCES `users`(`id`))",
+}
+
+func execAll(tx *gorm.DB, statements []string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package migration
+
+import "gorm.io/gorm"
+
+// addTransferDetails adds the fee, note and category a transfer is confirmed
+// with.
+var addTransferDetails = Migration{
+	Version: 2,
+	Name:    "add_transfer_details",
+	Up: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE `transactions` " +
+			"ADD COLUMN `fee` int(100) DEFAULT 0, " +
+			"ADD COLUMN `note` varchar(255), " +
+			"ADD COLUMN `category` varchar(50)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE `transactions` DROP COLUMN `fee`, DROP COLUMN `note`, DROP COLUMN `category`").Error
+	},
+}
@@ -0,0 +1,59 @@
+package migration
+
+import "gorm.io/gorm"
+
+// createFeeLimitRules stores the fee and limit rules admins configure and the
+// fees collected, and the fee charged on deposits and withdrawals.
+var createFeeLimitRules = Migration{
+	Version: 3,
+	Name:    "create_fee_limit_rules",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"ALTER TABLE `deposits` ADD COLUMN `fee` int(100) DEFAULT 0",
+			"ALTER TABLE `withdrawals` ADD COLUMN `fee` int(100) DEFAULT 0",
+
+			"CREATE TABLE IF NOT EXISTS `limit_rules` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`transaction_type` varchar(20)," +
+				"`id_role` bigint DEFAULT 0," +
+				"`kyc_tier` int(10) DEFAULT 0," +
+				"`min_amount` bigint DEFAULT 0," +
+				"`max_amount` bigint DEFAULT 0," +
+				"`daily_limit` bigint DEFAULT 0," +
+				"`monthly_limit` bigint DEFAULT 0," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_limit_rules_transaction_type` (`transaction_type`))",
+
+			"CREATE TABLE IF NOT EXISTS `fee_rules` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`transaction_type` varchar(20)," +
+				"`payment_method` varchar(20)," +
+				"`flat_fee` bigint DEFAULT 0," +
+				"`percentage_bps` int(10) DEFAULT 0," +
+				"`min_fee` bigint DEFAULT 0," +
+				"`max_fee` bigint DEFAULT 0," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_fee_rules_transaction_type` (`transaction_type`))",
+
+			"CREATE TABLE IF NOT EXISTS `fee_postings` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`id_user` int(100)," +
+				"`transaction_type` varchar(20)," +
+				"`reference_id` varchar(255)," +
+				"`amount` bigint," +
+				"`date` bigint," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_fee_postings_id_user` (`id_user`)," +
+				"INDEX `idx_fee_postings_reference_id` (`reference_id`))",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP TABLE IF EXISTS `fee_postings`",
+			"DROP TABLE IF EXISTS `fee_rules`",
+			"DROP TABLE IF EXISTS `limit_rules`",
+			"ALTER TABLE `withdrawals` DROP COLUMN `fee`",
+			"ALTER TABLE `deposits` DROP COLUMN `fee`",
+		})
+	},
+}
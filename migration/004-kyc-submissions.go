@@ -0,0 +1,38 @@
+package migration
+
+import "gorm.io/gorm"
+
+// createKycSubmissions stores the identity documents users submit and the
+// KYC tier their review gives them.
+var createKycSubmissions = Migration{
+	Version: 4,
+	Name:    "create_kyc_submissions",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"ALTER TABLE `users` ADD COLUMN `kyc_tier` int(10) DEFAULT 1",
+
+			"CREATE TABLE IF NOT EXISTS `kyc_submissions` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`id_user` int(100)," +
+				"`nik` varchar(16)," +
+				"`date_of_birth` varchar(10)," +
+				"`address` varchar(255)," +
+				"`ktp_path` varchar(255)," +
+				"`selfie_path` varchar(255)," +
+				"`status` int(10) DEFAULT 1," +
+				"`reject_reason` varchar(255)," +
+				"`reviewed_by` int(100)," +
+				"`reviewed_at` bigint," +
+				"`submitted_at` bigint," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_kyc_submissions_id_user` (`id_user`)," +
+				"INDEX `idx_kyc_submissions_nik` (`nik`))",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP TABLE IF EXISTS `kyc_submissions`",
+			"ALTER TABLE `users` DROP COLUMN `kyc_tier`",
+		})
+	},
+}
@@ -0,0 +1,16 @@
+package migration
+
+import "gorm.io/gorm"
+
+// addProfileThumbnails keeps the thumbnail stored next to every profile
+// picture.
+var addProfileThumbnails = Migration{
+	Version: 5,
+	Name:    "add_profile_thumbnails",
+	Up: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE `users` ADD COLUMN `profile_thumb` varchar(255)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE `users` DROP COLUMN `profile_thumb`").Error
+	},
+}
@@ -0,0 +1,46 @@
+package migration
+
+import "gorm.io/gorm"
+
+// createWallets stores the foreign currency wallets of users and the
+// transfers between wallets.
+var createWallets = Migration{
+	Version: 6,
+	Name:    "create_wallets",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"CREATE TABLE IF NOT EXISTS `wallets` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`id_user` int(100)," +
+				"`currency` varchar(3)," +
+				"`created_at` bigint," +
+				"PRIMARY KEY (`id`)," +
+				"UNIQUE INDEX `idx_wallet_user_currency` (`id_user`, `currency`))",
+
+			"CREATE TABLE IF NOT EXISTS `wallet_transfers` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`id_user` int(100)," +
+				"`to_user` int(100)," +
+				"`account_from` varchar(255)," +
+				"`account_to` varchar(255)," +
+				"`from_currency` varchar(3)," +
+				"`to_currency` varchar(3)," +
+				"`debit_amount` bigint," +
+				"`credit_amount` bigint," +
+				"`rate` varchar(32)," +
+				"`amount_idr` bigint," +
+				"`note` varchar(255)," +
+				"`date` bigint," +
+				"`status` int(100) DEFAULT 1," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_wallet_transfers_id_user` (`id_user`)," +
+				"INDEX `idx_wallet_transfers_to_user` (`to_user`))",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP TABLE IF EXISTS `wallet_transfers`",
+			"DROP TABLE IF EXISTS `wallets`",
+		})
+	},
+}
@@ -0,0 +1,25 @@
+package migration
+
+import "gorm.io/gorm"
+
+// widenAmountColumns moves the amounts and fees stored as int(100) to bigint,
+// which Money needs for large minor unit amounts. Tables added after the
+// baseline other than these three use bigint from the start.
+var widenAmountColumns = Migration{
+	Version: 7,
+	Name:    "widen_amount_columns",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"ALTER TABLE `deposits` MODIFY `amount` bigint, MODIFY `fee` bigint DEFAULT 0",
+			"ALTER TABLE `withdrawals` MODIFY `amount` bigint, MODIFY `fee` bigint DEFAULT 0",
+			"ALTER TABLE `transactions` MODIFY `amount` bigint, MODIFY `fee` bigint DEFAULT 0",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"ALTER TABLE `deposits` MODIFY `amount` int(100), MODIFY `fee` int(100) DEFAULT 0",
+			"ALTER TABLE `withdrawals` MODIFY `amount` int(100), MODIFY `fee` int(100) DEFAULT 0",
+			"ALTER TABLE `transactions` MODIFY `amount` int(100), MODIFY `fee` int(100) DEFAULT 0",
+		})
+	},
+}
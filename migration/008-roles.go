@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+
+	"gorm.io/gorm"
+)
+
+// createRoles stores the roles that were only known as the numbers 1 and 2.
+var createRoles = Migration{
+	Version: 8,
+	Name:    "create_roles",
+	Up: func(tx *gorm.DB) error {
+		err := tx.Exec("CREATE TABLE IF NOT EXISTS `roles` (" +
+			"`id` bigint unsigned AUTO_INCREMENT," +
+			"`name` varchar(50)," +
+			"PRIMARY KEY (`id`)," +
+			"UNIQUE INDEX `idx_roles_name` (`name`))").Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec("INSERT IGNORE INTO `roles` (`id`, `name`) VALUES (?, ?), (?, ?)",
+			entity.RoleAdmin, "admin", entity.RoleUser, "user").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP TABLE IF EXISTS `roles`").Error
+	},
+}
@@ -0,0 +1,38 @@
+package migration
+
+import "gorm.io/gorm"
+
+// createChatConversations stores the chatbot conversations, which used to be
+// forgotten after every reply.
+var createChatConversations = Migration{
+	Version: 9,
+	Name:    "create_chat_conversations",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"CREATE TABLE IF NOT EXISTS `chat_conversations` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`id_user` int(100)," +
+				"`title` varchar(100)," +
+				"`created_at` bigint," +
+				"`updated_at` bigint," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_chat_conversations_id_user` (`id_user`))",
+
+			"CREATE TABLE IF NOT EXISTS `chat_messages` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`conversation_id` bigint unsigned," +
+				"`role` varchar(20)," +
+				"`content` text," +
+				"`tokens` int(10)," +
+				"`created_at` bigint," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_chat_messages_conversation_id` (`conversation_id`))",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP TABLE IF EXISTS `chat_messages`",
+			"DROP TABLE IF EXISTS `chat_conversations`",
+		})
+	},
+}
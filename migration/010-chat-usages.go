@@ -0,0 +1,25 @@
+package migration
+
+import "gorm.io/gorm"
+
+// createChatUsages records the tokens used by the chatbot per user.
+var createChatUsages = Migration{
+	Version: 10,
+	Name:    "create_chat_usages",
+	Up: func(tx *gorm.DB) error {
+		return tx.Exec("CREATE TABLE IF NOT EXISTS `chat_usages` (" +
+			"`id` bigint unsigned AUTO_INCREMENT," +
+			"`id_user` int(100)," +
+			"`conversation_id` bigint unsigned," +
+			"`model` varchar(100)," +
+			"`prompt_tokens` int(10)," +
+			"`completion_tokens` int(10)," +
+			"`estimated` boolean," +
+			"`created_at` bigint," +
+			"PRIMARY KEY (`id`)," +
+			"INDEX `idx_chat_usages_user_date` (`id_user`, `created_at`))").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP TABLE IF EXISTS `chat_usages`").Error
+	},
+}
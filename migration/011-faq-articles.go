@@ -0,0 +1,39 @@
+package migration
+
+import "gorm.io/gorm"
+
+// createFaqArticles stores the chatbot's knowledge base and the embeddings
+// of its passages.
+var createFaqArticles = Migration{
+	Version: 11,
+	Name:    "create_faq_articles",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"CREATE TABLE IF NOT EXISTS `faq_articles` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`title` varchar(200)," +
+				"`body` text," +
+				"`published` boolean DEFAULT true," +
+				"`created_by` int(100)," +
+				"`created_at` bigint," +
+				"`updated_at` bigint," +
+				"PRIMARY KEY (`id`))",
+
+			"CREATE TABLE IF NOT EXISTS `faq_chunks` (" +
+				"`id` bigint unsigned AUTO_INCREMENT," +
+				"`article_id` bigint unsigned," +
+				"`position` int(10)," +
+				"`content` text," +
+				"`embedding` mediumblob," +
+				"`model` varchar(100)," +
+				"PRIMARY KEY (`id`)," +
+				"INDEX `idx_faq_chunks_article_id` (`article_id`))",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP TABLE IF EXISTS `faq_chunks`",
+			"DROP TABLE IF EXISTS `faq_articles`",
+		})
+	},
+}
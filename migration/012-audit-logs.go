@@ -0,0 +1,29 @@
+package migration
+
+import "gorm.io/gorm"
+
+// createAuditLogs records the changes admins make to users.
+var createAuditLogs = Migration{
+	Version: 12,
+	Name:    "create_audit_logs",
+	Up: func(tx *gorm.DB) error {
+		return tx.Exec("CREATE TABLE IF NOT EXISTS `audit_logs` (" +
+			"`id` bigint unsigned AUTO_INCREMENT," +
+			"`actor_id` int(100)," +
+			"`action` varchar(50)," +
+			"`entity` varchar(50)," +
+			"`entity_id` varchar(64)," +
+			"`before` text," +
+			"`after` text," +
+			"`reason` varchar(255)," +
+			"`ip` varchar(45)," +
+			"`request_id` varchar(64)," +
+			"`created_at` bigint," +
+			"PRIMARY KEY (`id`)," +
+			"INDEX `idx_audit_logs_entity` (`entity`, `entity_id`)," +
+			"INDEX `idx_audit_logs_actor` (`actor_id`))").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP TABLE IF EXISTS `audit_logs`").Error
+	},
+}
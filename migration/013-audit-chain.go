@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+
+	"gorm.io/gorm"
+)
+
+// chainAuditLogs hash-chains the audit logs, the ones already written
+// included, and adds the head row appends lock.
+var chainAuditLogs = Migration{
+	Version: 13,
+	Name:    "chain_audit_logs",
+	Up: func(tx *gorm.DB) error {
+		err := execAll(tx, []string{
+			"ALTER TABLE `audit_logs` ADD COLUMN `prev_hash` char(64), ADD COLUMN `hash` char(64)",
+			"CREATE TABLE IF NOT EXISTS `audit_heads` (" +
+				"`id` bigint unsigned," +
+				"`hash` char(64)," +
+				"PRIMARY KEY (`id`))",
+		})
+		if err != nil {
+			return err
+		}
+
+		var logs []entity.AuditLog
+		if err := tx.Order("id asc").Find(&logs).Error; err != nil {
+			return err
+		}
+		head := ""
+		for _, log := range logs {
+			log.PrevHash = head
+			log.Hash = audit.Hash(log)
+			err := tx.Model(&entity.AuditLog{}).Where("id = ?", log.ID).Updates(map[string]interface{}{"prev_hash": log.PrevHash, "hash": log.Hash}).Error
+			if err != nil {
+				return err
+			}
+			head = log.Hash
+		}
+
+		return tx.Exec("INSERT INTO `audit_heads` (`id`, `hash`) VALUES (?, ?)", audit.HeadID, head).Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP TABLE IF EXISTS `audit_heads`",
+			"ALTER TABLE `audit_logs` DROP COLUMN `prev_hash`, DROP COLUMN `hash`",
+		})
+	},
+}
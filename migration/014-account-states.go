@@ -0,0 +1,24 @@
+package migration
+
+import "gorm.io/gorm"
+
+// addAccountStates records when an account was last opened or reactivated,
+// which the dormancy job counts idle time from, and when it was closed.
+// Existing accounts start their idle time at the migration.
+var addAccountStates = Migration{
+	Version: 14,
+	Name:    "add_account_states",
+	Up: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"ALTER TABLE `users` ADD COLUMN `active_since` bigint, ADD COLUMN `closed_at` bigint",
+			"UPDATE `users` SET `active_since` = UNIX_TIMESTAMP()",
+			"CREATE INDEX `idx_users_status_active_since` ON `users` (`status`, `active_since`)",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP INDEX `idx_users_status_active_since` ON `users`",
+			"ALTER TABLE `users` DROP COLUMN `active_since`, DROP COLUMN `closed_at`",
+		})
+	},
+}
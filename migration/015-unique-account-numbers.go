@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// uniqueAccountNumbers makes account numbers unique. Accounts sharing a
+// number keep it for the oldest user, the others get a new number with the
+// default prefix. Transfers already sent to a shared number stay with the
+// oldest user, so the balances of the renumbered users need a review.
+var uniqueAccountNumbers = Migration{
+	Version: 15,
+	Name:    "unique_account_numbers",
+	Up: func(tx *gorm.DB) error {
+		var shared []string
+		err := tx.Model(&entity.User{}).Select("account_number").Where("account_number IS NOT NULL").
+			Group("account_number").Having("COUNT(*) > 1").Pluck("account_number", &shared).Error
+		if err != nil {
+			return err
+		}
+
+		for _, accountNumber := range shared {
+			var users []entity.User
+			if err := tx.Where("account_number = ?", accountNumber).Order("id asc").Find(&users).Error; err != nil {
+				return err
+			}
+			for _, user := range users[1:] {
+				renumbered, err := unusedAccountNumber(tx)
+				if err != nil {
+					return err
+				}
+				err = tx.Model(&entity.User{}).Where("id = ?", user.ID).Update("account_number", renumbered).Error
+				if err != nil {
+					return err
+				}
+				logrus.Warnf("User %d shared an account number with user %d and got a new one", user.ID, users[0].ID)
+			}
+		}
+
+		return execAll(tx, []string{
+			"ALTER TABLE `users` MODIFY `account_number` varchar(20)",
+			"CREATE UNIQUE INDEX `idx_users_account_number` ON `users` (`account_number`)",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP INDEX `idx_users_account_number` ON `users`",
+			"ALTER TABLE `users` MODIFY `account_number` varchar(255)",
+		})
+	},
+}
+
+func unusedAccountNumber(tx *gorm.DB) (uint64, error) {
+	for {
+		accountNumber, err := helper.GenerateAccountNumber(helper.DefaultAccountNumberPrefix)
+		if err != nil {
+			return 0, err
+		}
+		var count int64
+		if err := tx.Model(&entity.User{}).Where("account_number = ?", accountNumber).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		if count == 0 {
+			return accountNumber, nil
+		}
+	}
+}
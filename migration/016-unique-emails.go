@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+
+	"gorm.io/gorm"
+)
+
+// uniqueEmails makes emails unique, so two sign ups with the same email at
+// once cannot both succeed. Emails shared by several users cannot be told
+// apart automatically and have to be changed by hand first.
+var uniqueEmails = Migration{
+	Version: 16,
+	Name:    "unique_emails",
+	Up: func(tx *gorm.DB) error {
+		var shared []string
+		err := tx.Model(&entity.User{}).Select("email").Where("email IS NOT NULL").
+			Group("email").Having("COUNT(*) > 1").Pluck("email", &shared).Error
+		if err != nil {
+			return err
+		}
+		if len(shared) > 0 {
+			return fmt.Errorf("%d emails are shared by several users, give each user their own email first", len(shared))
+		}
+
+		return execAll(tx, []string{
+			"CREATE UNIQUE INDEX `idx_users_email` ON `users` (`email`)",
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		return execAll(tx, []string{
+			"DROP INDEX `idx_users_email` ON `users`",
+		})
+	},
+}
@@ -0,0 +1,22 @@
+package migration
+
+// All lists every migration of SelfBank. New migrations are appended with the
+// next version and never edited once released.
+var All = []Migration{
+	baseline,
+	addTransferDetails,
+	createFeeLimitRules,
+	createKycSubmissions,
+	addProfileThumbnails,
+	createWallets,
+	widenAmountColumns,
+	createRoles,
+	createChatConversations,
+	createChatUsages,
+	createFaqArticles,
+	createAuditLogs,
+	chainAuditLogs,
+	addAccountStates,
+	uniqueAccountNumbers,
+	uniqueEmails,
+}
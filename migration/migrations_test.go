@@ -0,0 +1,395 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+)
+
+// The entities as they were when AutoMigrate still built the schema on
+// every boot.
+type autoMigrateUser struct {
+	ID            uint64 `gorm:"primary_key:auto_increment"`
+	Namadepan     string `gorm:"type:varchar(255)"`
+	Namabelakang  string `gorm:"type:varchar(255)"`
+	Email         string `gorm:"type:varchar(255)"`
+	Username      string `gorm:"type:varchar(255)"`
+	Password      string `gorm:"->;<-;not null"`
+	Telephone     string `gorm:"type:varchar(255)"`
+	Jk            string `gorm:"type:varchar(255)"`
+	Profile       string `gorm:"type:varchar(255)"`
+	Token         string `gorm:"-"`
+	Balance       string `gorm:"-"`
+	AccountNumber uint64 `gorm:"type:varchar(255)"`
+	IdRole        uint64 `gorm:"type:bigint"`
+	Status        uint64 `gorm:"type:int(100);default:1"`
+	IsVerified    bool   `gorm:"type:boolean"`
+}
+
+func (autoMigrateUser) TableName() string { return "users" }
+
+type autoMigrateDeposit struct {
+	ID      string          `gorm:"primary_key"`
+	ID_User uint64          `gorm:"type:int(100);index"`
+	User    autoMigrateUser `gorm:"foreignKey:ID_User"`
+	Date    int64           `gorm:"type:bigint"`
+	Amount  uint64          `gorm:"type:int(100)"`
+	Status  uint64          `gorm:"type:int(100);default:1"`
+}
+
+func (autoMigrateDeposit) TableName() string { return "deposits" }
+
+type autoMigratePaymentToken struct {
+	gorm.Model
+	DepositID    string `gorm:"index;not null"`
+	PaymentToken string `gorm:"type:varchar(255);not null"`
+	VirtualAcc   string `gorm:"type:varchar(255);not null"`
+	CallbackUrl  string `gorm:"type:varchar(255);not null"`
+}
+
+func (autoMigratePaymentToken) TableName() string { return "payment_tokens" }
+
+type autoMigrateWithdrawal struct {
+	ID      uint64          `gorm:"primary_key:auto_increment"`
+	ID_User uint64          `gorm:"type:int(100);index"`
+	User    autoMigrateUser `gorm:"foreignKey:ID_User"`
+	Date    int64           `gorm:"type:bigint"`
+	Amount  uint64          `gorm:"type:int(100)"`
+	To      string          `gorm:"type:varchar(255);not null"`
+	Status  uint64          `gorm:"type:int(100);default:1"`
+}
+
+func (autoMigrateWithdrawal) TableName() string { return "withdrawals" }
+
+type autoMigrateTransaction struct {
+	ID              uint64          `gorm:"primary_key:auto_increment"`
+	ID_User         uint64          `gorm:"type:int(100);index"`
+	User            autoMigrateUser `gorm:"foreignKey:ID_User"`
+	TransactionFrom uint64          `gorm:"type:varchar(255)"`
+	TransactionTo   uint64          `gorm:"type:varchar(255)"`
+	Date            int64           `gorm:"type:bigint"`
+	Amount          uint64          `gorm:"type:int(100)"`
+	Status          uint64          `gorm:"type:int(100);default:1"`
+}
+
+func (autoMigrateTransaction) TableName() string { return "transactions" }
+
+// persisted lists every entity stored in a table.
+var persisted = []interface{}{
+	&entity.User{}, &entity.Deposit{}, &entity.PaymentToken{}, &entity.Withdrawal{}, &entity.Transaction{},
+	&entity.LimitRule{}, &entity.FeeRule{}, &entity.FeePosting{}, &entity.KycSubmission{},
+	&entity.Wallet{}, &entity.WalletTransfer{}, &entity.Role{},
+	&entity.ChatConversation{}, &entity.ChatMessage{}, &entity.ChatUsage{},
+	&entity.FaqArticle{}, &entity.FaqChunk{}, &entity.AuditLog{}, &entity.AuditHead{},
+}
+
+func TestBaselineMatchesAutoMigrate(t *testing.T) {
+	assert.Equal(t, autoMigrateSchema(t), migrated(t, newFakeSchema(), All[:1]))
+}
+
+func TestMigrationsUpgradeAutoMigrateSchema(t *testing.T) {
+	upgraded := migrated(t, autoMigrateSchema(t), All)
+	assert.Equal(t, migrated(t, newFakeSchema(), All), upgraded)
+
+	for _, value := range persisted {
+		parsed, err := schema.Parse(value, &sync.Map{}, schema.NamingStrategy{})
+		require.NoError(t, err)
+
+		table, ok := upgraded.tables[parsed.Table]
+		if !assert.True(t, ok, "table %s exists", parsed.Table) {
+			continue
+		}
+		for _, field := range parsed.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			_, ok := table.columns[field.DBName]
+			assert.True(t, ok, "column %s.%s exists", parsed.Table, field.DBName)
+		}
+	}
+}
+
+func TestMigrationsDown(t *testing.T) {
+	db, statements := dryRun(t)
+	fake := migrated(t, newFakeSchema(), All)
+	for i := len(All) - 1; i >= 0; i-- {
+		*statements = nil
+		require.NoError(t, All[i].Down(db), All[i].Name)
+		for _, statement := range *statements {
+			require.NoError(t, fake.apply(statement), "%s: %s", All[i].Name, statement)
+		}
+	}
+	assert.Empty(t, fake.tables)
+}
+
+func TestMigrationVersions(t *testing.T) {
+	for i, migration := range All {
+		assert.Equal(t, int64(i+1), migration.Version, migration.Name)
+	}
+}
+
+// autoMigrateSchema returns the schema AutoMigrate created for the entities
+// of that time.
+func autoMigrateSchema(t *testing.T) *fakeSchema {
+	db, statements := dryRun(t)
+	err := db.Migrator().CreateTable(&autoMigrateUser{}, &autoMigrateDeposit{}, &autoMigratePaymentToken{}, &autoMigrateWithdrawal{}, &autoMigrateTransaction{})
+	require.NoError(t, err)
+
+	fake := newFakeSchema()
+	for _, statement := range *statements {
+		require.NoError(t, fake.apply(statement), statement)
+	}
+	return fake
+}
+
+// migrated runs migrations on fake in order.
+func migrated(t *testing.T, fake *fakeSchema, migrations []Migration) *fakeSchema {
+	db, statements := dryRun(t)
+	for _, migration := range migrations {
+		*statements = nil
+		require.NoError(t, migration.Up(db), migration.Name)
+		for _, statement := range *statements {
+			require.NoError(t, fake.apply(statement), "%s: %s", migration.Name, statement)
+		}
+	}
+	return fake
+}
+
+// dryRun returns a MySQL connection that executes nothing and collects the
+// statements it would have run.
+func dryRun(t *testing.T) (*gorm.DB, *[]string) {
+	statements := &[]string{}
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "selfbank@tcp(127.0.0.1:3306)/selfbank", SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               statementLogger{statements: statements},
+	})
+	require.NoError(t, err)
+	return db, statements
+}
+
+type statementLogger struct {
+	logger.Interface
+	statements *[]string
+}
+
+func (l statementLogger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l statementLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, _ := fc()
+	*l.statements = append(*l.statements, sql)
+}
+
+// fakeSchema follows the tables, columns and indexes the statements of the
+// migrations create, and fails like MySQL would on a missing or duplicated
+// one.
+type fakeSchema struct {
+	tables map[string]*fakeTable
+}
+
+type fakeTable struct {
+	columns map[string]string
+	indexes map[string]string
+}
+
+func newFakeSchema() *fakeSchema {
+	return &fakeSchema{tables: map[string]*fakeTable{}}
+}
+
+var (
+	createTablePattern = regexp.MustCompile("(?is)^CREATE TABLE (IF NOT EXISTS )?`(\\w+)` \\((.*)\\)$")
+	alterTablePattern  = regexp.MustCompile("(?is)^ALTER TABLE `(\\w+)` (.*)$")
+	createIndexPattern = regexp.MustCompile("(?is)^CREATE (UNIQUE )?INDEX `(\\w+)` ON `(\\w+)` \\((.*)\\)$")
+	dropIndexPattern   = regexp.MustCompile("(?is)^DROP INDEX `(\\w+)` ON `(\\w+)`$")
+	dropTablePattern   = regexp.MustCompile("(?is)^DROP TABLE IF EXISTS `(\\w+)`$")
+	updatePattern      = regexp.MustCompile("(?is)^UPDATE `(\\w+)` SET `(\\w+)`")
+	insertPattern      = regexp.MustCompile("(?is)^INSERT (IGNORE )?INTO `(\\w+)` \\(([^)]*)\\)")
+	selectPattern      = regexp.MustCompile("(?is)^SELECT .* FROM `(\\w+)`")
+	namePattern        = regexp.MustCompile("`(\\w+)`")
+)
+
+func (s *fakeSchema) apply(statement string) error {
+	statement = strings.TrimSpace(statement)
+	switch {
+	case createTablePattern.MatchString(statement):
+		match := createTablePattern.FindStringSubmatch(statement)
+		if _, ok := s.tables[match[2]]; ok {
+			if match[1] != "" {
+				return nil
+			}
+			return fmt.Errorf("Table %s already exists", match[2])
+		}
+		table := &fakeTable{columns: map[string]string{}, indexes: map[string]string{}}
+		for _, definition := range splitDefinitions(match[3]) {
+			if err := table.define(definition); err != nil {
+				return err
+			}
+		}
+		s.tables[match[2]] = table
+	case alterTablePattern.MatchString(statement):
+		match := alterTablePattern.FindStringSubmatch(statement)
+		table, err := s.table(match[1])
+		if err != nil {
+			return err
+		}
+		for _, change := range splitDefinitions(match[2]) {
+			if err := table.alter(change); err != nil {
+				return err
+			}
+		}
+	case createIndexPattern.MatchString(statement):
+		match := createIndexPattern.FindStringSubmatch(statement)
+		table, err := s.table(match[3])
+		if err != nil {
+			return err
+		}
+		return table.define(strings.TrimSpace(match[1] + "INDEX `" + match[2] + "` (" + match[4] + ")"))
+	case dropIndexPattern.MatchString(statement):
+		match := dropIndexPattern.FindStringSubmatch(statement)
+		table, err := s.table(match[2])
+		if err != nil {
+			return err
+		}
+		if _, ok := table.indexes[match[1]]; !ok {
+			return fmt.Errorf("Unknown index %s", match[1])
+		}
+		delete(table.indexes, match[1])
+	case dropTablePattern.MatchString(statement):
+		delete(s.tables, dropTablePattern.FindStringSubmatch(statement)[1])
+	case updatePattern.MatchString(statement):
+		match := updatePattern.FindStringSubmatch(statement)
+		return s.hasColumns(match[1], match[2])
+	case insertPattern.MatchString(statement):
+		match := insertPattern.FindStringSubmatch(statement)
+		return s.hasColumns(match[2], columnNames(match[3])...)
+	case selectPattern.MatchString(statement):
+		_, err := s.table(selectPattern.FindStringSubmatch(statement)[1])
+		return err
+	default:
+		return fmt.Errorf("Unsupported statement")
+	}
+	return nil
+}
+
+func (s *fakeSchema) table(name string) (*fakeTable, error) {
+	table, ok := s.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("Table %s doesn't exist", name)
+	}
+	return table, nil
+}
+
+func (s *fakeSchema) hasColumns(tableName string, columns ...string) error {
+	table, err := s.table(tableName)
+	if err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if _, ok := table.columns[column]; !ok {
+			return fmt.Errorf("Unknown column %s.%s", tableName, column)
+		}
+	}
+	return nil
+}
+
+// define adds a column, key, index or constraint of a CREATE TABLE.
+func (t *fakeTable) define(definition string) error {
+	upper := strings.ToUpper(definition)
+	switch {
+	case strings.HasPrefix(definition, "`"):
+		name := namePattern.FindStringSubmatch(definition)[1]
+		if _, ok := t.columns[name]; ok {
+			return fmt.Errorf("Duplicate column %s", name)
+		}
+		t.columns[name] = strings.TrimSpace(definition[len(name)+2:])
+		return nil
+	case strings.HasPrefix(upper, "PRIMARY KEY"):
+		return t.index("PRIMARY", definition)
+	case strings.HasPrefix(upper, "INDEX"), strings.HasPrefix(upper, "UNIQUE INDEX"), strings.HasPrefix(upper, "CONSTRAINT"):
+		names := namePattern.FindAllStringSubmatch(definition, -1)
+		return t.index(names[0][1], definition)
+	}
+	return fmt.Errorf("Unsupported definition %s", definition)
+}
+
+func (t *fakeTable) index(name string, definition string) error {
+	if _, ok := t.indexes[name]; ok {
+		return fmt.Errorf("Duplicate index %s", name)
+	}
+	start := strings.Index(definition, "(")
+	for _, column := range columnNames(definition[start+1 : strings.Index(definition, ")")]) {
+		if _, ok := t.columns[column]; !ok {
+			return fmt.Errorf("Index %s uses unknown column %s", name, column)
+		}
+	}
+	t.indexes[name] = definition
+	return nil
+}
+
+func (t *fakeTable) alter(change string) error {
+	upper := strings.ToUpper(change)
+	switch {
+	case strings.HasPrefix(upper, "ADD COLUMN "):
+		return t.define(strings.TrimSpace(change[len("ADD COLUMN "):]))
+	case strings.HasPrefix(upper, "MODIFY "):
+		definition := strings.TrimSpace(change[len("MODIFY "):])
+		name := namePattern.FindStringSubmatch(definition)[1]
+		if _, ok := t.columns[name]; !ok {
+			return fmt.Errorf("Unknown column %s", name)
+		}
+		t.columns[name] = strings.TrimSpace(definition[len(name)+2:])
+		return nil
+	case strings.HasPrefix(upper, "DROP COLUMN "):
+		name := namePattern.FindStringSubmatch(change)[1]
+		if _, ok := t.columns[name]; !ok {
+			return fmt.Errorf("Unknown column %s", name)
+		}
+		delete(t.columns, name)
+		return nil
+	}
+	return fmt.Errorf("Unsupported change %s", change)
+}
+
+// splitDefinitions splits a list of definitions on the commas outside of
+// parentheses.
+func splitDefinitions(list string) []string {
+	var definitions []string
+	depth, start := 0, 0
+	for i, r := range list {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				definitions = append(definitions, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(definitions, strings.TrimSpace(list[start:]))
+}
+
+func columnNames(list string) []string {
+	var names []string
+	for _, match := range namePattern.FindAllStringSubmatch(list, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
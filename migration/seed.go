@@ -0,0 +1,48 @@
+package migration
+
+import (
+	"errors"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+
+	"gorm.io/gorm"
+)
+
+// SeedAdmin creates the first admin account, its account number starts with
+// accountNumberPrefix. It does nothing and returns false when a user with
+// that email already exists.
+func SeedAdmin(db *gorm.DB, email string, password string, accountNumberPrefix string) (bool, error) {
+	if email == "" || password == "" {
+		return false, errors.New("Admin email and password are required")
+	}
+
+	var existing entity.User
+	err := db.Where("email = ?", email).Take(&existing).Error
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	accountNumber, err := helper.GenerateAccountNumber(accountNumberPrefix)
+	if err != nil {
+		return false, err
+	}
+
+	admin := entity.User{
+		Namadepan:     "Admin",
+		Email:         email,
+		Username:      email,
+		Password:      helper.HashAndSalt([]byte(password)),
+		AccountNumber: accountNumber,
+		IdRole:        entity.RoleAdmin,
+		IsVerified:    true,
+		KycTier:       entity.KycTierVerified,
+	}
+	if err := db.Create(&admin).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
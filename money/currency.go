@@ -0,0 +1,35 @@
+package money
+
+const (
+	IDR = "IDR"
+	USD = "USD"
+	SGD = "SGD"
+)
+
+type currency struct {
+	exponent  int
+	symbol    string
+	thousands string
+	decimal   string
+}
+
+// Rupiah amounts have always been whole numbers in SelfBank, so IDR keeps an
+// exponent of 0 and the amounts stored before Money existed stay valid minor
+// units.
+var currencies = map[string]currency{
+	IDR: {exponent: 0, symbol: "Rp", thousands: ".", decimal: ","},
+	USD: {exponent: 2, symbol: "US$", thousands: ",", decimal: "."},
+	SGD: {exponent: 2, symbol: "S$", thousands: ",", decimal: "."},
+}
+
+// Exponent returns the minor unit digits of an ISO 4217 code and whether the
+// currency is supported.
+func Exponent(code string) (int, bool) {
+	c, ok := currencies[code]
+	return c.exponent, ok
+}
+
+func IsSupported(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
@@ -0,0 +1,101 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type jsonMoney struct {
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Formatted string `json:"formatted,omitempty"`
+}
+
+// MarshalJSON writes the amount in minor units together with its currency
+// and display form.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.Amount, Currency: m.Currency, Formatted: m.Format()})
+}
+
+// UnmarshalJSON accepts the object written by MarshalJSON, or for requests a
+// bare number of minor units or a decimal string, both read as Rupiah.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	switch data[0] {
+	case '{':
+		var value jsonMoney
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		if value.Currency == "" {
+			value.Currency = IDR
+		}
+		*m = Money{Amount: value.Amount, Currency: value.Currency}
+		return nil
+	case '"':
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		return m.UnmarshalParam(value)
+	}
+
+	amount, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid amount %s, use whole minor units", data)
+	}
+	*m = Rupiah(amount)
+	return nil
+}
+
+// UnmarshalParam binds form and query values, which are read as Rupiah.
+func (m *Money) UnmarshalParam(param string) error {
+	value, err := Parse(param, IDR)
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// Value stores the minor units only. Tables keep the currency in a column of
+// their own or hold Rupiah only.
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount, nil
+}
+
+// Scan reads minor units and assumes Rupiah, rows in other currencies set the
+// currency after they are loaded.
+func (m *Money) Scan(src interface{}) error {
+	var amount int64
+	switch value := src.(type) {
+	case nil:
+		amount = 0
+	case int64:
+		amount = value
+	case []byte:
+		parsed, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			return fmt.Errorf("money: cannot scan %q", value)
+		}
+		amount = parsed
+	case string:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("money: cannot scan %q", value)
+		}
+		amount = parsed
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+
+	*m = Money{Amount: amount, Currency: IDR}
+	return nil
+}
@@ -0,0 +1,80 @@
+package money
+
+import (
+	"strconv"
+	"strings"
+)
+
+// String returns the amount as a plain decimal with its currency code, such as
+// "IDR 15000" or "USD 12.50".
+func (m Money) String() string {
+	return strings.TrimSpace(m.Currency + " " + m.Decimal())
+}
+
+// Decimal returns the amount in major units without grouping, such as
+// "12.50".
+func (m Money) Decimal() string {
+	c := currencies[m.Currency]
+	whole, fraction := m.split(c.exponent)
+	if fraction == "" {
+		return whole
+	}
+	return whole + "." + fraction
+}
+
+// Format returns the amount the way it is shown to customers, such as
+// "Rp15.000" or "US$1,250.00".
+func (m Money) Format() string {
+	c, ok := currencies[m.Currency]
+	if !ok {
+		return m.String()
+	}
+
+	whole, fraction := m.split(c.exponent)
+	sign := ""
+	if strings.HasPrefix(whole, "-") {
+		sign, whole = "-", whole[1:]
+	}
+
+	formatted := sign + c.symbol + group(whole, c.thousands)
+	if fraction != "" {
+		formatted += c.decimal + fraction
+	}
+	return formatted
+}
+
+func (m Money) split(exponent int) (string, string) {
+	digits := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if exponent == 0 {
+		return sign + digits, ""
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	point := len(digits) - exponent
+	return sign + digits[:point], digits[point:]
+}
+
+func group(digits string, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("Cannot combine amounts in different currencies")
+	ErrOverflow         = errors.New("Amount is out of range")
+)
+
+// Money is an amount in minor units of its currency. The zero value has no
+// currency and adopts the currency of the first amount it is combined with,
+// so it can be used to start a sum.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Rupiah returns an IDR amount.
+func Rupiah(amount int64) Money {
+	return Money{Amount: amount, Currency: IDR}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Sum adds all values, the result of no values is the zero value.
+func Sum(values ...Money) (Money, error) {
+	var total Money
+	for _, value := range values {
+		var err error
+		total, err = total.Add(value)
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Bps returns basis points of m (100 bps is 1%), rounded down.
+func (m Money) Bps(bps int64) (Money, error) {
+	result := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(bps))
+	result.Quo(result, big.NewInt(10000))
+	if !result.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: result.Int64(), Currency: m.Currency}, nil
+}
+
+// Cmp compares two amounts and returns -1, 0 or +1. Comparing different
+// currencies is a programming error and panics.
+func (m Money) Cmp(other Money) int {
+	if _, err := m.commonCurrency(other); err != nil {
+		panic(fmt.Sprintf("money: cannot compare %s with %s", m.Currency, other.Currency))
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1
+	case m.Amount > other.Amount:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) LessThan(other Money) bool {
+	return m.Cmp(other) < 0
+}
+
+func (m Money) GreaterThan(other Money) bool {
+	return m.Cmp(other) > 0
+}
+
+func (m Money) Min(other Money) Money {
+	result := other
+	if m.LessThan(other) {
+		result = m
+	}
+	result.Currency, _ = m.commonCurrency(other)
+	return result
+}
+
+func (m Money) Max(other Money) Money {
+	result := other
+	if m.GreaterThan(other) {
+		result = m
+	}
+	result.Currency, _ = m.commonCurrency(other)
+	return result
+}
+
+func (m Money) commonCurrency(other Money) (string, error) {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency, nil
+	case m.Currency == "":
+		return other.Currency, nil
+	case other.Currency == "":
+		return m.Currency, nil
+	}
+	return "", ErrCurrencyMismatch
+}
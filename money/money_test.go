@@ -0,0 +1,154 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func() (Money, error)
+		want    Money
+		wantErr error
+	}{
+		{"Add", func() (Money, error) { return Rupiah(1500).Add(Rupiah(2500)) }, Rupiah(4000), nil},
+		{"Add Negative", func() (Money, error) { return Rupiah(1500).Add(Rupiah(-2500)) }, Rupiah(-1000), nil},
+		{"Add Overflow", func() (Money, error) { return Rupiah(math.MaxInt64).Add(Rupiah(1)) }, Money{}, ErrOverflow},
+		{"Add Underflow", func() (Money, error) { return Rupiah(math.MinInt64).Add(Rupiah(-1)) }, Money{}, ErrOverflow},
+		{"Add Up To Max", func() (Money, error) { return Rupiah(math.MaxInt64 - 1).Add(Rupiah(1)) }, Rupiah(math.MaxInt64), nil},
+		{"Add Currency Mismatch", func() (Money, error) { return Rupiah(1).Add(New(1, USD)) }, Money{}, ErrCurrencyMismatch},
+		{"Zero Value Adopts Currency", func() (Money, error) { return Money{}.Add(New(250, USD)) }, New(250, USD), nil},
+		{"Sub", func() (Money, error) { return Rupiah(4000).Sub(Rupiah(1500)) }, Rupiah(2500), nil},
+		{"Sub Below Zero", func() (Money, error) { return Rupiah(1000).Sub(Rupiah(1500)) }, Rupiah(-500), nil},
+		{"Sub Overflow", func() (Money, error) { return Rupiah(math.MinInt64).Sub(Rupiah(1)) }, Money{}, ErrOverflow},
+		{"Sub Min Int", func() (Money, error) { return Rupiah(0).Sub(Rupiah(math.MinInt64)) }, Money{}, ErrOverflow},
+		{"Sub Currency Mismatch", func() (Money, error) { return New(100, SGD).Sub(New(100, USD)) }, Money{}, ErrCurrencyMismatch},
+		{"Sum", func() (Money, error) { return Sum(Rupiah(1), Rupiah(2), Rupiah(3)) }, Rupiah(6), nil},
+		{"Sum Of Nothing", func() (Money, error) { return Sum() }, Money{}, nil},
+		{"Sum Overflow", func() (Money, error) { return Sum(Rupiah(math.MaxInt64/2), Rupiah(math.MaxInt64/2), Rupiah(2)) }, Money{}, ErrOverflow},
+		{"Sum Currency Mismatch", func() (Money, error) { return Sum(Rupiah(1), New(1, USD)) }, Money{}, ErrCurrencyMismatch},
+		{"Bps", func() (Money, error) { return Rupiah(100000).Bps(200) }, Rupiah(2000), nil},
+		{"Bps Rounds Down", func() (Money, error) { return Rupiah(999).Bps(100) }, Rupiah(9), nil},
+		{"Bps Rounds Negative Toward Zero", func() (Money, error) { return Rupiah(-999).Bps(100) }, Rupiah(-9), nil},
+		{"Bps Below One Unit", func() (Money, error) { return Rupiah(49).Bps(200) }, Rupiah(0), nil},
+		{"Bps Keeps Currency", func() (Money, error) { return New(1050, USD).Bps(5000) }, New(525, USD), nil},
+		{"Bps Large Amount", func() (Money, error) { return Rupiah(math.MaxInt64).Bps(10000) }, Rupiah(math.MaxInt64), nil},
+		{"Bps Overflow", func() (Money, error) { return Rupiah(math.MaxInt64).Bps(20000) }, Money{}, ErrOverflow},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.op()
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestMoney_Compare(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Money
+		cmp   int
+		min   Money
+		max   Money
+		panic bool
+	}{
+		{name: "Less", a: Rupiah(1), b: Rupiah(2), cmp: -1, min: Rupiah(1), max: Rupiah(2)},
+		{name: "Equal", a: Rupiah(2), b: Rupiah(2), cmp: 0, min: Rupiah(2), max: Rupiah(2)},
+		{name: "Greater", a: Rupiah(3), b: Rupiah(-3), cmp: 1, min: Rupiah(-3), max: Rupiah(3)},
+		{name: "Zero Value", a: Money{}, b: New(5, USD), cmp: -1, min: New(0, USD), max: New(5, USD)},
+		{name: "Currency Mismatch", a: Rupiah(1), b: New(1, USD), panic: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.panic {
+				assert.Panics(t, func() { test.a.Cmp(test.b) })
+				assert.Panics(t, func() { test.a.LessThan(test.b) })
+				assert.Panics(t, func() { test.a.Max(test.b) })
+				return
+			}
+			assert.Equal(t, test.cmp, test.a.Cmp(test.b))
+			assert.Equal(t, test.cmp < 0, test.a.LessThan(test.b))
+			assert.Equal(t, test.cmp > 0, test.a.GreaterThan(test.b))
+			assert.Equal(t, test.min, test.a.Min(test.b))
+			assert.Equal(t, test.max, test.a.Max(test.b))
+		})
+	}
+}
+
+func TestMoney_FormatAndParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		money     Money
+		decimal   string
+		formatted string
+		str       string
+	}{
+		{"Rupiah", Rupiah(15000), "15000", "Rp15.000", "IDR 15000"},
+		{"Small Rupiah", Rupiah(500), "500", "Rp500", "IDR 500"},
+		{"Zero Rupiah", Rupiah(0), "0", "Rp0", "IDR 0"},
+		{"Negative Rupiah", Rupiah(-1250000), "-1250000", "-Rp1.250.000", "IDR -1250000"},
+		{"Dollars", New(125000, USD), "1250.00", "US$1,250.00", "USD 1250.00"},
+		{"Cents", New(5, USD), "0.05", "US$0.05", "USD 0.05"},
+		{"Negative Cents", New(-50, SGD), "-0.50", "-S$0.50", "SGD -0.50"},
+		{"Largest Rupiah", Rupiah(math.MaxInt64), "9223372036854775807", "Rp9.223.372.036.854.775.807", "IDR 9223372036854775807"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.decimal, test.money.Decimal())
+			assert.Equal(t, test.formatted, test.money.Format())
+			assert.Equal(t, test.str, test.money.String())
+
+			parsed, err := Parse(test.money.Decimal(), test.money.Currency)
+			assert.NoError(t, err)
+			assert.Equal(t, test.money, parsed)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{name: "Whole Rupiah", value: "15000", currency: IDR, want: Rupiah(15000)},
+		{name: "Spaces", value: " 15000 ", currency: IDR, want: Rupiah(15000)},
+		{name: "Padded Fraction", value: "12.5", currency: USD, want: New(1250, USD)},
+		{name: "No Fraction", value: "12", currency: USD, want: New(1200, USD)},
+		{name: "Negative", value: "-0.50", currency: SGD, want: New(-50, SGD)},
+		{name: "Too Many Decimals", value: "12.505", currency: USD, wantErr: true},
+		{name: "Rupiah Has No Decimals", value: "15000.5", currency: IDR, wantErr: true},
+		{name: "Grouped", value: "15.000", currency: IDR, wantErr: true},
+		{name: "Not A Number", value: "abc", currency: IDR, wantErr: true},
+		{name: "Empty", value: "", currency: IDR, wantErr: true},
+		{name: "Unsupported Currency", value: "1", currency: "EUR", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.value, test.currency)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	_, err := Parse("9223372036854775808", IDR)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
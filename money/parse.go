@@ -0,0 +1,32 @@
+package money
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse reads a decimal amount in major units, such as "12.50", into minor
+// units of currency. More decimals than the currency has are rejected.
+func Parse(value string, currency string) (Money, error) {
+	exponent, ok := Exponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("Currency %s is not supported", currency)
+	}
+
+	value = strings.TrimSpace(value)
+	whole, fraction, _ := strings.Cut(value, ".")
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("%s amounts have at most %d decimals", currency, exponent)
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return Money{}, ErrOverflow
+		}
+		return Money{}, fmt.Errorf("Invalid amount %q", value)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
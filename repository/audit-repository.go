@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	FindLogs(ctx context.Context, filter entity.AuditFilter, page int, pageSize int) ([]entity.AuditLog, error)
+	TotalLogs(ctx context.Context, filter entity.AuditFilter) int64
+	LogsAfter(ctx context.Context, id uint64, limit int) ([]entity.AuditLog, error)
+	Head(ctx context.Context) (entity.AuditHead, error)
+}
+
+type auditConnection struct {
+	connection *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditConnection{
+		connection: db,
+	}
+}
+
+func (db *auditConnection) FindLogs(ctx context.Context, filter entity.AuditFilter, page int, pageSize int) ([]entity.AuditLog, error) {
+	if page <= 0 || pageSize <= 0 {
+		return nil, apperror.ErrInvalidPagination
+	}
+
+	var logs []entity.AuditLog
+	offset := (page - 1) * pageSize
+
+	result := db.filterLogs(ctx, filter).Order("id desc").Offset(offset).Limit(pageSize).Find(&logs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return logs, nil
+}
+
+func (db *auditConnection) TotalLogs(ctx context.Context, filter entity.AuditFilter) int64 {
+	var count int64
+	result := db.filterLogs(ctx, filter).Model(&entity.AuditLog{}).Count(&count)
+	if result.Error != nil {
+		return 0
+	}
+	return count
+}
+
+func (db *auditConnection) filterLogs(ctx context.Context, filter entity.AuditFilter) *gorm.DB {
+	tx := db.connection.WithContext(ctx)
+	if filter.Entity != "" {
+		tx = tx.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != "" {
+		tx = tx.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != 0 {
+		tx = tx.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		tx = tx.Where("action = ?", filter.Action)
+	}
+	if filter.StartDate != 0 {
+		tx = tx.Where("created_at >= ?", filter.StartDate)
+	}
+	if filter.EndDate != 0 {
+		tx = tx.Where("created_at <= ?", filter.EndDate)
+	}
+	return tx
+}
+
+// LogsAfter returns the logs following id in the order they were chained.
+func (db *auditConnection) LogsAfter(ctx context.Context, id uint64, limit int) ([]entity.AuditLog, error) {
+	var logs []entity.AuditLog
+	result := db.connection.WithContext(ctx).Where("id > ?", id).Order("id asc").Limit(limit).Find(&logs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return logs, nil
+}
+
+func (db *auditConnection) Head(ctx context.Context) (entity.AuditHead, error) {
+	var head entity.AuditHead
+	err := db.connection.WithContext(ctx).Where("id = ?", audit.HeadID).Take(&head).Error
+	return head, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+	"github.com/IrvanWijayaSardam/SelfBank/tracing"
+	"github.com/go-redis/redis"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// ErrCacheUnavailable is returned by the Redis backed repositories while
+// Redis cannot be reached. The API keeps serving everything else, the error
+// is answered with 503 rather than failing the whole request chain.
+var ErrCacheUnavailable = apperror.ErrServiceUnavailable
+
+// cacheError hides connection failures behind ErrCacheUnavailable and passes
+// redis.Nil through so callers can still tell a missing key apart.
+func cacheError(ctx context.Context, err error) error {
+	if err == nil || err == redis.Nil {
+		return err
+	}
+	logging.FromContext(ctx).WithError(err).Error("Redis is unavailable")
+	return ErrCacheUnavailable
+}
+
+// cacheClient binds the client to ctx and traces every command it runs as a
+// child of the span carried by ctx.
+func cacheClient(ctx context.Context, client *redis.Client) *redis.Client {
+	bound := client.WithContext(ctx)
+	bound.WrapProcess(func(process func(cmd redis.Cmder) error) func(cmd redis.Cmder) error {
+		return func(cmd redis.Cmder) error {
+			_, span := tracing.Start(ctx, "redis "+cmd.Name(), semconv.DBSystemRedis, semconv.DBOperation(cmd.Name()))
+			err := process(cmd)
+			if err == redis.Nil {
+				tracing.End(span, nil)
+			} else {
+				tracing.End(span, err)
+			}
+			return err
+		}
+	})
+	return bound
+}
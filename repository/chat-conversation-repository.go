@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+
+	"gorm.io/gorm"
+)
+
+type ChatConversationRepository interface {
+	InsertConversation(ctx context.Context, conversation *entity.ChatConversation) error
+	FindConversation(ctx context.Context, idUser uint64, id uint64) entity.ChatConversation
+	FindConversationsByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.ChatConversation, error)
+	TotalConversationsByIDUser(ctx context.Context, idUser uint64) int64
+	DeleteConversation(ctx context.Context, id uint64) error
+	InsertMessages(ctx context.Context, conversationID uint64, messages []entity.ChatMessage) error
+	RecentMessages(ctx context.Context, conversationID uint64, limit int) ([]entity.ChatMessage, error)
+}
+
+type chatConversationConnection struct {
+	connection *gorm.DB
+}
+
+func NewChatConversationRepository(db *gorm.DB) ChatConversationRepository {
+	return &chatConversationConnection{
+		connection: db,
+	}
+}
+
+func (db *chatConversationConnection) InsertConversation(ctx context.Context, conversation *entity.ChatConversation) error {
+	conversation.CreatedAt = helper.GetCurrentTimeInLocation()
+	conversation.UpdatedAt = conversation.CreatedAt
+	return db.connection.WithContext(ctx).Create(conversation).Error
+}
+
+// FindConversation only finds the conversations of idUser, an empty one is
+// returned for anybody else's.
+func (db *chatConversationConnection) FindConversation(ctx context.Context, idUser uint64, id uint64) entity.ChatConversation {
+	var conversation entity.ChatConversation
+	db.connection.WithContext(ctx).Where("id = ? && id_user = ?", id, idUser).Take(&conversation)
+	return conversation
+}
+
+// FindConversationsByIDUser returns the conversations of a user, the most
+// recently active first.
+func (db *chatConversationConnection) FindConversationsByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.ChatConversation, error) {
+	if page <= 0 || pageSize <= 0 {
+		return nil, apperror.ErrInvalidPagination
+	}
+
+	var conversations []entity.ChatConversation
+	offset := (page - 1) * pageSize
+
+	result := db.connection.WithContext(ctx).Where("id_user = ?", idUser).Order("updated_at desc, id desc").Offset(offset).Limit(pageSize).Find(&conversations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return conversations, nil
+}
+
+func (db *chatConversationConnection) TotalConversationsByIDUser(ctx context.Context, idUser uint64) int64 {
+	var count int64
+	result := db.connection.WithContext(ctx).Model(&entity.ChatConversation{}).Where("id_user = ?", idUser).Count(&count)
+	if result.Error != nil {
+		return 0
+	}
+	return count
+}
+
+// DeleteConversation removes a conversation with its messages.
+func (db *chatConversationConnection) DeleteConversation(ctx context.Context, id uint64) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("conversation_id = ?", id).Delete(&entity.ChatMessage{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.ChatConversation{}, id).Error
+	})
+}
+
+// InsertMessages appends messages to a conversation and marks it as active.
+func (db *chatConversationConnection) InsertMessages(ctx context.Context, conversationID uint64, messages []entity.ChatMessage) error {
+	now := helper.GetCurrentTimeInLocation()
+	for i := range messages {
+		messages[i].ConversationID = conversationID
+		messages[i].CreatedAt = now
+	}
+
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&messages).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.ChatConversation{}).Where("id = ?", conversationID).Update("updated_at", now).Error
+	})
+}
+
+// RecentMessages returns the last limit messages of a conversation, oldest
+// first.
+func (db *chatConversationConnection) RecentMessages(ctx context.Context, conversationID uint64, limit int) ([]entity.ChatMessage, error) {
+	var messages []entity.ChatMessage
+	result := db.connection.WithContext(ctx).Where("conversation_id = ?", conversationID).Order("id desc").Limit(limit).Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/go-redis/redis"
+	"gorm.io/gorm"
+)
+
+type ChatUsageRepository interface {
+	InsertUsage(ctx context.Context, usage entity.ChatUsage) error
+	TokensUsedSince(ctx context.Context, idUser uint64, since int64) int64
+	UsageByUser(ctx context.Context, from int64, to int64) ([]entity.ChatUsageTotal, error)
+	CountRequest(ctx context.Context, idUser uint64, window time.Duration) (int64, error)
+}
+
+type chatUsageConnection struct {
+	connection *gorm.DB
+	cache      *redis.Client
+}
+
+func NewChatUsageRepository(cache *redis.Client, db *gorm.DB) ChatUsageRepository {
+	return &chatUsageConnection{connection: db, cache: cache}
+}
+
+func (db *chatUsageConnection) InsertUsage(ctx context.Context, usage entity.ChatUsage) error {
+	usage.CreatedAt = helper.GetCurrentTimeInLocation()
+	return db.connection.WithContext(ctx).Create(&usage).Error
+}
+
+// TokensUsedSince sums the prompt and completion tokens of a user since the
+// unix time.
+func (db *chatUsageConnection) TokensUsedSince(ctx context.Context, idUser uint64, since int64) int64 {
+	var tokens int64
+	result := db.connection.WithContext(ctx).Model(&entity.ChatUsage{}).Select("COALESCE(SUM(prompt_tokens + completion_tokens), 0)").Where("id_user = ? && created_at >= ?", idUser, since).Scan(&tokens)
+	if result.Error != nil {
+		return 0
+	}
+	return tokens
+}
+
+// UsageByUser sums the usage of every user between from and to, unix times
+// with to excluded, the heaviest users first.
+func (db *chatUsageConnection) UsageByUser(ctx context.Context, from int64, to int64) ([]entity.ChatUsageTotal, error) {
+	var totals []entity.ChatUsageTotal
+	result := db.connection.WithContext(ctx).Model(&entity.ChatUsage{}).
+		Select("id_user, COUNT(*) AS replies, SUM(prompt_tokens) AS prompt_tokens, SUM(completion_tokens) AS completion_tokens").
+		Where("created_at >= ? && created_at < ?", from, to).
+		Group("id_user").
+		Order("SUM(prompt_tokens + completion_tokens) desc").
+		Scan(&totals)
+	return totals, result.Error
+}
+
+// CountRequest counts a chatbot request of a user and returns how many were
+// made in the current window, this one included.
+func (db *chatUsageConnection) CountRequest(ctx context.Context, idUser uint64, window time.Duration) (int64, error) {
+	slot := time.Now().UnixNano() / int64(window)
+	key := "chatbot:requests:" + strconv.FormatUint(idUser, 10) + ":" + strconv.FormatInt(slot, 10)
+
+	client := cacheClient(ctx, db.cache)
+	count, err := client.Incr(key).Result()
+	if err != nil {
+		return 0, cacheError(ctx, err)
+	}
+	if count == 1 {
+		client.Expire(key, window)
+	}
+	return count, nil
+}
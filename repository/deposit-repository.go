@@ -1,8 +1,9 @@
 package repository
 
 import (
-	"errors"
+	"context"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
 
@@ -10,38 +11,39 @@ import (
 )
 
 type DepositRepository interface {
-	InsertDeposit(brg *entity.Deposit) entity.Deposit
-	All(page int, pageSize int) ([]entity.Deposit, error)
-	UpdateDeposit(plg entity.Deposit) entity.Deposit
-	FindDepositByID(id string) entity.Deposit
-	FindDepositByIDUser(id uint64, page int, pageSize int) ([]entity.Deposit, error)
-	TotalDeposit() int64
-	TotalDepositByUserID(idUser uint64) int64
-	StorePaymentToken(depositID string, paymentToken string, virtualAcc string, callbackUrl string) error
-	UpdateDepositStatus(id string, newStatus uint64) error
-	FindPaymentInfoById(id string) *entity.PaymentToken
-	SearchByDateAll(dateStart int64, dateEnd int64) ([]entity.Deposit, error)
-	SearchByDateIDUser(idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error)
-	TotalDepositByDate(dateStart int64, dateEnd int64) int64
-	TotalDepositByDateIdUser(idUser uint64, dateStart int64, dateEnd int64) int64
+	InsertDeposit(ctx context.Context, brg *entity.Deposit) entity.Deposit
+	All(ctx context.Context, page int, pageSize int) ([]entity.Deposit, error)
+	UpdateDeposit(ctx context.Context, plg entity.Deposit) entity.Deposit
+	FindDepositByID(ctx context.Context, id string) entity.Deposit
+	FindDepositByIDUser(ctx context.Context, id uint64, page int, pageSize int) ([]entity.Deposit, error)
+	RecentDeposits(ctx context.Context, idUser uint64, limit int) ([]entity.Deposit, error)
+	TotalDeposit(ctx context.Context) int64
+	TotalDepositByUserID(ctx context.Context, idUser uint64) int64
+	StorePaymentToken(ctx context.Context, depositID string, paymentToken string, virtualAcc string, callbackUrl string) error
+	UpdateDepositStatus(ctx context.Context, id string, newStatus uint64) error
+	FindPaymentInfoById(ctx context.Context, id string) *entity.PaymentToken
+	SearchByDateAll(ctx context.Context, dateStart int64, dateEnd int64) ([]entity.Deposit, error)
+	SearchByDateIDUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error)
+	TotalDepositByDate(ctx context.Context, dateStart int64, dateEnd int64) int64
+	TotalDepositByDateIdUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) int64
 }
 
 type DepositConnection struct {
 	connection *gorm.DB
 }
 
-func (db *DepositConnection) TotalDeposit() int64 {
+func (db *DepositConnection) TotalDeposit(ctx context.Context) int64 {
 	var count int64
-	result := db.connection.Model(&entity.Deposit{}).Where("status != ?", 1).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Deposit{}).Where("status != ?", 1).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
 	return count
 }
 
-func (db *DepositConnection) TotalDepositByUserID(idUser uint64) int64 {
+func (db *DepositConnection) TotalDepositByUserID(ctx context.Context, idUser uint64) int64 {
 	var count int64
-	result := db.connection.Model(&entity.Deposit{}).Where("id_user = ? && status = ?", idUser, 5).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Deposit{}).Where("id_user = ? && status = ?", idUser, 5).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
@@ -54,35 +56,35 @@ func NewDepositRepository(db *gorm.DB) DepositRepository {
 	}
 }
 
-func (db *DepositConnection) InsertDeposit(Deposit *entity.Deposit) entity.Deposit {
+func (db *DepositConnection) InsertDeposit(ctx context.Context, Deposit *entity.Deposit) entity.Deposit {
 	Deposit.Date = helper.GetCurrentTimeInLocation()
-	db.connection.Save(Deposit)
+	db.connection.WithContext(ctx).Save(Deposit)
 	return *Deposit
 }
 
-func (db *DepositConnection) StorePaymentToken(depositID string, paymentToken string, virtualAcc string, callbackUrl string) error {
+func (db *DepositConnection) StorePaymentToken(ctx context.Context, depositID string, paymentToken string, virtualAcc string, callbackUrl string) error {
 	paymentTokenRecord := entity.PaymentToken{
 		DepositID:    depositID,
 		PaymentToken: paymentToken,
 		VirtualAcc:   virtualAcc,
 		CallbackUrl:  callbackUrl,
 	}
-	result := db.connection.Create(&paymentTokenRecord)
+	result := db.connection.WithContext(ctx).Create(&paymentTokenRecord)
 	if result.Error != nil {
 		return result.Error
 	}
 	return nil
 }
 
-func (db *DepositConnection) All(page int, pageSize int) ([]entity.Deposit, error) {
+func (db *DepositConnection) All(ctx context.Context, page int, pageSize int) ([]entity.Deposit, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
 	var transactions []entity.Deposit
 	offset := (page - 1) * pageSize
 
-	result := db.connection.Where("status != ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	result := db.connection.WithContext(ctx).Where("status != ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -90,12 +92,12 @@ func (db *DepositConnection) All(page int, pageSize int) ([]entity.Deposit, erro
 	return transactions, nil
 }
 
-func (db *DepositConnection) SearchByDateAll(dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
+func (db *DepositConnection) SearchByDateAll(ctx context.Context, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
 	var deposits []entity.Deposit
 
 	condition := "date >= ? AND date <= ? AND status != ?"
 
-	result := db.connection.Where(condition, dateStart, dateEnd, 1).Find(&deposits)
+	result := db.connection.WithContext(ctx).Where(condition, dateStart, dateEnd, 1).Find(&deposits)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -103,12 +105,12 @@ func (db *DepositConnection) SearchByDateAll(dateStart int64, dateEnd int64) ([]
 	return deposits, nil
 }
 
-func (db *DepositConnection) SearchByDateIDUser(idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
+func (db *DepositConnection) SearchByDateIDUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
 	var deposits []entity.Deposit
 
 	condition := "id_user = ? AND date >= ? AND date <= ? AND status != ?"
 
-	result := db.connection.Where(condition, idUser, dateStart, dateEnd, 1).Find(&deposits)
+	result := db.connection.WithContext(ctx).Where(condition, idUser, dateStart, dateEnd, 1).Find(&deposits)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -116,12 +118,12 @@ func (db *DepositConnection) SearchByDateIDUser(idUser uint64, dateStart int64,
 	return deposits, nil
 }
 
-func (db *DepositConnection) TotalDepositByDate(dateStart int64, dateEnd int64) int64 {
+func (db *DepositConnection) TotalDepositByDate(ctx context.Context, dateStart int64, dateEnd int64) int64 {
 	var count int64
 
 	condition := "date >= ? AND date <= ? AND status != ?"
 
-	result := db.connection.Model(&entity.Deposit{}).Where(condition, dateStart, dateEnd, 1).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Deposit{}).Where(condition, dateStart, dateEnd, 1).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
@@ -129,12 +131,12 @@ func (db *DepositConnection) TotalDepositByDate(dateStart int64, dateEnd int64)
 	return count
 }
 
-func (db *DepositConnection) TotalDepositByDateIdUser(idUser uint64, dateStart int64, dateEnd int64) int64 {
+func (db *DepositConnection) TotalDepositByDateIdUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) int64 {
 	var count int64
 
 	condition := "id_user = ? AND date >= ? AND date <= ? AND status != ?"
 
-	result := db.connection.Model(&entity.Deposit{}).Where(condition, idUser, dateStart, dateEnd, 1).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Deposit{}).Where(condition, idUser, dateStart, dateEnd, 1).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
@@ -142,15 +144,15 @@ func (db *DepositConnection) TotalDepositByDateIdUser(idUser uint64, dateStart i
 	return count
 }
 
-func (db *DepositConnection) FindDepositByIDUser(idUser uint64, page int, pageSize int) ([]entity.Deposit, error) {
+func (db *DepositConnection) FindDepositByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.Deposit, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
 	var transactions []entity.Deposit
 	offset := (page - 1) * pageSize
 
-	result := db.connection.Where("id_user = ? && status != ?", idUser, 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	result := db.connection.WithContext(ctx).Where("id_user = ? && status != ?", idUser, 1).Offset(offset).Limit(pageSize).Find(&transactions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -158,14 +160,26 @@ func (db *DepositConnection) FindDepositByIDUser(idUser uint64, page int, pageSi
 	return transactions, nil
 }
 
-func (db *DepositConnection) UpdateDeposit(Deposit entity.Deposit) entity.Deposit {
-	db.connection.Save(&Deposit)
+// RecentDeposits returns the latest deposits of the user whatever their
+// status, newest first.
+func (db *DepositConnection) RecentDeposits(ctx context.Context, idUser uint64, limit int) ([]entity.Deposit, error) {
+	var deposits []entity.Deposit
+	result := db.connection.WithContext(ctx).Where("id_user = ?", idUser).Order("date desc").Limit(limit).Find(&deposits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deposits, nil
+}
+
+func (db *DepositConnection) UpdateDeposit(ctx context.Context, Deposit entity.Deposit) entity.Deposit {
+	db.connection.WithContext(ctx).Save(&Deposit)
 	return Deposit
 }
 
-func (db *DepositConnection) FindDepositByID(id string) entity.Deposit {
+func (db *DepositConnection) FindDepositByID(ctx context.Context, id string) entity.Deposit {
 	var Deposit entity.Deposit
-	result := db.connection.Where("id = ? ", id).Take(&Deposit)
+	result := db.connection.WithContext(ctx).Where("id = ? ", id).Take(&Deposit)
 	if result.Error != nil || result.RowsAffected == 0 {
 		return Deposit
 	}
@@ -173,9 +187,9 @@ func (db *DepositConnection) FindDepositByID(id string) entity.Deposit {
 	return Deposit
 }
 
-func (db *DepositConnection) FindPaymentInfoById(id string) *entity.PaymentToken {
+func (db *DepositConnection) FindPaymentInfoById(ctx context.Context, id string) *entity.PaymentToken {
 	var payment entity.PaymentToken
-	result := db.connection.Where("deposit_id = ? ", id).Take(&payment)
+	result := db.connection.WithContext(ctx).Where("deposit_id = ? ", id).Take(&payment)
 	if result.Error != nil || result.RowsAffected == 0 {
 		return nil
 	}
@@ -183,16 +197,16 @@ func (db *DepositConnection) FindPaymentInfoById(id string) *entity.PaymentToken
 	return &payment
 }
 
-func (db *DepositConnection) UpdateDepositStatus(id string, newStatus uint64) error {
+func (db *DepositConnection) UpdateDepositStatus(ctx context.Context, id string, newStatus uint64) error {
 	var trx entity.Deposit
-	result := db.connection.First(&trx, id)
+	result := db.connection.WithContext(ctx).First(&trx, id)
 	if result.Error != nil {
 		return result.Error
 	}
 
 	trx.Status = newStatus
 
-	result = db.connection.Save(&trx)
+	result = db.connection.WithContext(ctx).Save(&trx)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+
+	"gorm.io/gorm"
+)
+
+type FaqRepository interface {
+	InsertArticle(ctx context.Context, article *entity.FaqArticle, chunks []entity.FaqChunk) error
+	UpdateArticle(ctx context.Context, article *entity.FaqArticle, chunks []entity.FaqChunk) error
+	DeleteArticle(ctx context.Context, id uint64) error
+	FindArticle(ctx context.Context, id uint64) entity.FaqArticle
+	FindArticles(ctx context.Context, page int, pageSize int) ([]entity.FaqArticle, error)
+	FindArticlesByIDs(ctx context.Context, ids []uint64) ([]entity.FaqArticle, error)
+	TotalArticles(ctx context.Context) int64
+	AllArticles(ctx context.Context) ([]entity.FaqArticle, error)
+	ReplaceChunks(ctx context.Context, articleID uint64, chunks []entity.FaqChunk) error
+	PublishedChunks(ctx context.Context, model string) ([]entity.FaqChunk, error)
+}
+
+type faqConnection struct {
+	connection *gorm.DB
+}
+
+func NewFaqRepository(db *gorm.DB) FaqRepository {
+	return &faqConnection{
+		connection: db,
+	}
+}
+
+// InsertArticle stores a new article together with its indexed passages.
+func (db *faqConnection) InsertArticle(ctx context.Context, article *entity.FaqArticle, chunks []entity.FaqChunk) error {
+	article.CreatedAt = helper.GetCurrentTimeInLocation()
+	article.UpdatedAt = article.CreatedAt
+
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(article).Error; err != nil {
+			return err
+		}
+		return replaceChunks(tx, article.ID, chunks)
+	})
+}
+
+// UpdateArticle saves an article and replaces its passages.
+func (db *faqConnection) UpdateArticle(ctx context.Context, article *entity.FaqArticle, chunks []entity.FaqChunk) error {
+	article.UpdatedAt = helper.GetCurrentTimeInLocation()
+
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(article).Error; err != nil {
+			return err
+		}
+		return replaceChunks(tx, article.ID, chunks)
+	})
+}
+
+func (db *faqConnection) DeleteArticle(ctx context.Context, id uint64) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", id).Delete(&entity.FaqChunk{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.FaqArticle{}, id).Error
+	})
+}
+
+func (db *faqConnection) FindArticle(ctx context.Context, id uint64) entity.FaqArticle {
+	var article entity.FaqArticle
+	db.connection.WithContext(ctx).Take(&article, id)
+	return article
+}
+
+func (db *faqConnection) FindArticles(ctx context.Context, page int, pageSize int) ([]entity.FaqArticle, error) {
+	if page <= 0 || pageSize <= 0 {
+		return nil, apperror.ErrInvalidPagination
+	}
+
+	var articles []entity.FaqArticle
+	offset := (page - 1) * pageSize
+
+	result := db.connection.WithContext(ctx).Order("id desc").Offset(offset).Limit(pageSize).Find(&articles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return articles, nil
+}
+
+func (db *faqConnection) FindArticlesByIDs(ctx context.Context, ids []uint64) ([]entity.FaqArticle, error) {
+	var articles []entity.FaqArticle
+	result := db.connection.WithContext(ctx).Where("id IN ?", ids).Find(&articles)
+	return articles, result.Error
+}
+
+func (db *faqConnection) TotalArticles(ctx context.Context) int64 {
+	var count int64
+	result := db.connection.WithContext(ctx).Model(&entity.FaqArticle{}).Count(&count)
+	if result.Error != nil {
+		return 0
+	}
+	return count
+}
+
+func (db *faqConnection) AllArticles(ctx context.Context) ([]entity.FaqArticle, error) {
+	var articles []entity.FaqArticle
+	result := db.connection.WithContext(ctx).Order("id").Find(&articles)
+	return articles, result.Error
+}
+
+func (db *faqConnection) ReplaceChunks(ctx context.Context, articleID uint64, chunks []entity.FaqChunk) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceChunks(tx, articleID, chunks)
+	})
+}
+
+// PublishedChunks loads the passages of the published articles that were
+// embedded with model. The knowledge base is small enough to be searched in
+// memory.
+func (db *faqConnection) PublishedChunks(ctx context.Context, model string) ([]entity.FaqChunk, error) {
+	var chunks []entity.FaqChunk
+	result := db.connection.WithContext(ctx).
+		Joins("JOIN faq_articles ON faq_articles.id = faq_chunks.article_id").
+		Where("faq_articles.published = ? && faq_chunks.model = ?", true, model).
+		Find(&chunks)
+	return chunks, result.Error
+}
+
+func replaceChunks(tx *gorm.DB, articleID uint64, chunks []entity.FaqChunk) error {
+	if err := tx.Where("article_id = ?", articleID).Delete(&entity.FaqChunk{}).Error; err != nil {
+		return err
+	}
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	for i := range chunks {
+		chunks[i].ArticleID = articleID
+	}
+	return tx.Create(&chunks).Error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+
+	"gorm.io/gorm"
+)
+
+type FeeLimitRepository interface {
+	LimitRules(ctx context.Context, trxType string) ([]entity.LimitRule, error)
+	FeeRules(ctx context.Context, trxType string) ([]entity.FeeRule, error)
+	AllLimitRules(ctx context.Context) ([]entity.LimitRule, error)
+	AllFeeRules(ctx context.Context) ([]entity.FeeRule, error)
+	SaveLimitRule(ctx context.Context, rule entity.LimitRule) (entity.LimitRule, error)
+	SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error)
+	InsertFeePosting(ctx context.Context, posting *entity.FeePosting) error
+	SumAmountSince(ctx context.Context, idUser uint64, trxType string, since int64) (money.Money, error)
+}
+
+type feeLimitConnection struct {
+	connection *gorm.DB
+}
+
+func NewFeeLimitRepository(db *gorm.DB) FeeLimitRepository {
+	return &feeLimitConnection{
+		connection: db,
+	}
+}
+
+func (db *feeLimitConnection) LimitRules(ctx context.Context, trxType string) ([]entity.LimitRule, error) {
+	var rules []entity.LimitRule
+	result := db.connection.WithContext(ctx).Where("transaction_type = ?", trxType).Find(&rules)
+	return rules, result.Error
+}
+
+func (db *feeLimitConnection) FeeRules(ctx context.Context, trxType string) ([]entity.FeeRule, error) {
+	var rules []entity.FeeRule
+	result := db.connection.WithContext(ctx).Where("transaction_type = ?", trxType).Find(&rules)
+	return rules, result.Error
+}
+
+func (db *feeLimitConnection) AllLimitRules(ctx context.Context) ([]entity.LimitRule, error) {
+	var rules []entity.LimitRule
+	result := db.connection.WithContext(ctx).Order("transaction_type").Find(&rules)
+	return rules, result.Error
+}
+
+func (db *feeLimitConnection) AllFeeRules(ctx context.Context) ([]entity.FeeRule, error) {
+	var rules []entity.FeeRule
+	result := db.connection.WithContext(ctx).Order("transaction_type").Find(&rules)
+	return rules, result.Error
+}
+
+func (db *feeLimitConnection) SaveLimitRule(ctx context.Context, rule entity.LimitRule) (entity.LimitRule, error) {
+	result := db.connection.WithContext(ctx).Save(&rule)
+	return rule, result.Error
+}
+
+func (db *feeLimitConnection) SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error) {
+	result := db.connection.WithContext(ctx).Save(&rule)
+	return rule, result.Error
+}
+
+func (db *feeLimitConnection) InsertFeePosting(ctx context.Context, posting *entity.FeePosting) error {
+	posting.Date = helper.GetCurrentTimeInLocation()
+	return db.connection.WithContext(ctx).Create(posting).Error
+}
+
+// SumAmountSince returns how much a user has moved for the given transaction
+// type since the unix time, fees excluded. Failed or cancelled entries are not
+// counted. A failed query is returned as an error, never as Rp0, so limits
+// are not skipped while the database is unavailable.
+func (db *feeLimitConnection) SumAmountSince(ctx context.Context, idUser uint64, trxType string, since int64) (money.Money, error) {
+	var totalAmount int64
+	var result *gorm.DB
+
+	switch trxType {
+	case entity.TrxTypeTransfer:
+		result = db.connection.WithContext(ctx).Model(&entity.Transaction{}).Select("COALESCE(SUM(amount), 0)").Where("id_user = ? && status = ? && date >= ?", idUser, 1, since).Scan(&totalAmount)
+		if result.Error != nil {
+			return money.Rupiah(0), result.Error
+		}
+
+		// Wallet transfers count with their IDR value, conversions between
+		// the user's own wallets are not transfers
+		var walletAmount int64
+		result = db.connection.WithContext(ctx).Model(&entity.WalletTransfer{}).Select("COALESCE(SUM(amount_idr), 0)").Where("id_user = ? && to_user <> ? && status = ? && date >= ?", idUser, idUser, 1, since).Scan(&walletAmount)
+		if result.Error != nil {
+			return money.Rupiah(0), result.Error
+		}
+		return money.Sum(money.Rupiah(totalAmount), money.Rupiah(walletAmount))
+	case entity.TrxTypeWithdrawal:
+		result = db.connection.WithContext(ctx).Model(&entity.Withdrawal{}).Select("COALESCE(SUM(amount), 0)").Where("id_user = ? && status = ? && date >= ?", idUser, 1, since).Scan(&totalAmount)
+	case entity.TrxTypeDeposit:
+		result = db.connection.WithContext(ctx).Model(&entity.Deposit{}).Select("COALESCE(SUM(amount), 0)").Where("id_user = ? && status IN ? && date >= ?", idUser, []uint64{1, 2, 5}, since).Scan(&totalAmount)
+	default:
+		return money.Rupiah(0), nil
+	}
+
+	if result.Error != nil {
+		return money.Rupiah(0), result.Error
+	}
+	return money.Rupiah(totalAmount), nil
+}
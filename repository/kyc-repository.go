@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+
+	"gorm.io/gorm"
+)
+
+type KycRepository interface {
+	InsertSubmission(ctx context.Context, submission *entity.KycSubmission) error
+	FindSubmissionByID(ctx context.Context, id uint64) entity.KycSubmission
+	LatestSubmissionByIDUser(ctx context.Context, idUser uint64) entity.KycSubmission
+	PendingSubmissions(ctx context.Context, page int, pageSize int) ([]entity.KycSubmission, error)
+	TotalPendingSubmissions(ctx context.Context) int64
+	IsNIKTaken(ctx context.Context, nik string, idUser uint64) bool
+	ApproveSubmission(ctx context.Context, submission entity.KycSubmission) error
+	RejectSubmission(ctx context.Context, submission entity.KycSubmission) error
+}
+
+type kycConnection struct {
+	connection *gorm.DB
+}
+
+func NewKycRepository(db *gorm.DB) KycRepository {
+	return &kycConnection{
+		connection: db,
+	}
+}
+
+func (db *kycConnection) InsertSubmission(ctx context.Context, submission *entity.KycSubmission) error {
+	submission.SubmittedAt = helper.GetCurrentTimeInLocation()
+	return db.connection.WithContext(ctx).Create(submission).Error
+}
+
+func (db *kycConnection) FindSubmissionByID(ctx context.Context, id uint64) entity.KycSubmission {
+	var submission entity.KycSubmission
+	db.connection.WithContext(ctx).Where("id = ?", id).Take(&submission)
+	return submission
+}
+
+func (db *kycConnection) LatestSubmissionByIDUser(ctx context.Context, idUser uint64) entity.KycSubmission {
+	var submission entity.KycSubmission
+	db.connection.WithContext(ctx).Where("id_user = ?", idUser).Order("id desc").Take(&submission)
+	return submission
+}
+
+func (db *kycConnection) PendingSubmissions(ctx context.Context, page int, pageSize int) ([]entity.KycSubmission, error) {
+	if page <= 0 || pageSize <= 0 {
+		return nil, apperror.ErrInvalidPagination
+	}
+
+	var submissions []entity.KycSubmission
+	offset := (page - 1) * pageSize
+
+	result := db.connection.WithContext(ctx).Where("status = ?", entity.KycStatusPending).Order("submitted_at asc").Offset(offset).Limit(pageSize).Find(&submissions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return submissions, nil
+}
+
+func (db *kycConnection) TotalPendingSubmissions(ctx context.Context) int64 {
+	var count int64
+	result := db.connection.WithContext(ctx).Model(&entity.KycSubmission{}).Where("status = ?", entity.KycStatusPending).Count(&count)
+	if result.Error != nil {
+		return 0
+	}
+	return count
+}
+
+// IsNIKTaken reports whether another user already has a pending or approved
+// submission for the same NIK.
+func (db *kycConnection) IsNIKTaken(ctx context.Context, nik string, idUser uint64) bool {
+	var count int64
+	db.connection.WithContext(ctx).Model(&entity.KycSubmission{}).
+		Where("nik = ? AND id_user != ? AND status IN ?", nik, idUser, []uint64{entity.KycStatusPending, entity.KycStatusApproved}).
+		Count(&count)
+	return count > 0
+}
+
+// ApproveSubmission marks the submission approved and raises the user's KYC
+// tier in the same database transaction.
+func (db *kycConnection) ApproveSubmission(ctx context.Context, submission entity.KycSubmission) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		submission.Status = entity.KycStatusApproved
+		submission.ReviewedAt = helper.GetCurrentTimeInLocation()
+		if err := tx.Save(&submission).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.User{}).Where("id = ?", submission.ID_User).Update("kyc_tier", entity.KycTierVerified).Error
+	})
+}
+
+func (db *kycConnection) RejectSubmission(ctx context.Context, submission entity.KycSubmission) error {
+	submission.Status = entity.KycStatusRejected
+	submission.ReviewedAt = helper.GetCurrentTimeInLocation()
+	return db.connection.WithContext(ctx).Save(&submission).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+
+	"gorm.io/gorm"
+)
+
+type LedgerRepository interface {
+	UserIDs(ctx context.Context) ([]uint64, error)
+	FeesCharged(ctx context.Context, idUser uint64, trxType string) money.Money
+	FeesPosted(ctx context.Context, idUser uint64, trxType string) money.Money
+}
+
+type ledgerConnection struct {
+	connection *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) LedgerRepository {
+	return &ledgerConnection{
+		connection: db,
+	}
+}
+
+func (db *ledgerConnection) UserIDs(ctx context.Context) ([]uint64, error) {
+	var ids []uint64
+	result := db.connection.WithContext(ctx).Model(&entity.User{}).Order("id").Pluck("id", &ids)
+	return ids, result.Error
+}
+
+// FeesCharged sums the fees on a user's completed transactions of a type.
+func (db *ledgerConnection) FeesCharged(ctx context.Context, idUser uint64, trxType string) money.Money {
+	var totalFee int64
+	var result *gorm.DB
+
+	switch trxType {
+	case entity.TrxTypeTransfer:
+		result = db.connection.WithContext(ctx).Model(&entity.Transaction{}).Select("COALESCE(SUM(fee), 0)").Where("id_user = ? && status = ?", idUser, 1).Scan(&totalFee)
+	case entity.TrxTypeWithdrawal:
+		result = db.connection.WithContext(ctx).Model(&entity.Withdrawal{}).Select("COALESCE(SUM(fee), 0)").Where("id_user = ? && status = ?", idUser, 1).Scan(&totalFee)
+	case entity.TrxTypeDeposit:
+		result = db.connection.WithContext(ctx).Model(&entity.Deposit{}).Select("COALESCE(SUM(fee), 0)").Where("id_user = ? && status = ?", idUser, 5).Scan(&totalFee)
+	default:
+		return money.Rupiah(0)
+	}
+
+	if result.Error != nil {
+		return money.Rupiah(0)
+	}
+	return money.Rupiah(totalFee)
+}
+
+func (db *ledgerConnection) FeesPosted(ctx context.Context, idUser uint64, trxType string) money.Money {
+	var totalFee int64
+	result := db.connection.WithContext(ctx).Model(&entity.FeePosting{}).Select("COALESCE(SUM(amount), 0)").Where("id_user = ? && transaction_type = ?", idUser, trxType).Scan(&totalFee)
+	if result.Error != nil {
+		return money.Rupiah(0)
+	}
+	return money.Rupiah(totalFee)
+}
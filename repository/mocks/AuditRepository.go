@@ -0,0 +1,119 @@
+// Code generated by mockery v2.35.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditRepository is an autogenerated mock type for the AuditRepository type
+type AuditRepository struct {
+	mock.Mock
+}
+
+// FindLogs provides a mock function with given fields: ctx, filter, page, pageSize
+func (_m *AuditRepository) FindLogs(ctx context.Context, filter entity.AuditFilter, page int, pageSize int) ([]entity.AuditLog, error) {
+	ret := _m.Called(ctx, filter, page, pageSize)
+
+	var r0 []entity.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditFilter, int, int) ([]entity.AuditLog, error)); ok {
+		return rf(ctx, filter, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditFilter, int, int) []entity.AuditLog); ok {
+		r0 = rf(ctx, filter, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AuditFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Head provides a mock function with given fields: ctx
+func (_m *AuditRepository) Head(ctx context.Context) (entity.AuditHead, error) {
+	ret := _m.Called(ctx)
+
+	var r0 entity.AuditHead
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (entity.AuditHead, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) entity.AuditHead); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.AuditHead)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogsAfter provides a mock function with given fields: ctx, id, limit
+func (_m *AuditRepository) LogsAfter(ctx context.Context, id uint64, limit int) ([]entity.AuditLog, error) {
+	ret := _m.Called(ctx, id, limit)
+
+	var r0 []entity.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) ([]entity.AuditLog, error)); ok {
+		return rf(ctx, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) []entity.AuditLog); ok {
+		r0 = rf(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int) error); ok {
+		r1 = rf(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalLogs provides a mock function with given fields: ctx, filter
+func (_m *AuditRepository) TotalLogs(ctx context.Context, filter entity.AuditFilter) int64 {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditFilter) int64); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// NewAuditRepository creates a new instance of AuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepository {
+	mock := &AuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
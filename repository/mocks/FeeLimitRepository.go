@@ -0,0 +1,221 @@
+// Code generated by mockery v2.35.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/IrvanWijayaSardam/SelfBank/money"
+)
+
+// FeeLimitRepository is an autogenerated mock type for the FeeLimitRepository type
+type FeeLimitRepository struct {
+	mock.Mock
+}
+
+// AllFeeRules provides a mock function with given fields: ctx
+func (_m *FeeLimitRepository) AllFeeRules(ctx context.Context) ([]entity.FeeRule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.FeeRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.FeeRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.FeeRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.FeeRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AllLimitRules provides a mock function with given fields: ctx
+func (_m *FeeLimitRepository) AllLimitRules(ctx context.Context) ([]entity.LimitRule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.LimitRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.LimitRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.LimitRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LimitRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FeeRules provides a mock function with given fields: ctx, trxType
+func (_m *FeeLimitRepository) FeeRules(ctx context.Context, trxType string) ([]entity.FeeRule, error) {
+	ret := _m.Called(ctx, trxType)
+
+	var r0 []entity.FeeRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.FeeRule, error)); ok {
+		return rf(ctx, trxType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.FeeRule); ok {
+		r0 = rf(ctx, trxType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.FeeRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, trxType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertFeePosting provides a mock function with given fields: ctx, posting
+func (_m *FeeLimitRepository) InsertFeePosting(ctx context.Context, posting *entity.FeePosting) error {
+	ret := _m.Called(ctx, posting)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.FeePosting) error); ok {
+		r0 = rf(ctx, posting)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LimitRules provides a mock function with given fields: ctx, trxType
+func (_m *FeeLimitRepository) LimitRules(ctx context.Context, trxType string) ([]entity.LimitRule, error) {
+	ret := _m.Called(ctx, trxType)
+
+	var r0 []entity.LimitRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.LimitRule, error)); ok {
+		return rf(ctx, trxType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.LimitRule); ok {
+		r0 = rf(ctx, trxType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LimitRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, trxType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveFeeRule provides a mock function with given fields: ctx, rule
+func (_m *FeeLimitRepository) SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	var r0 entity.FeeRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FeeRule) (entity.FeeRule, error)); ok {
+		return rf(ctx, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FeeRule) entity.FeeRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Get(0).(entity.FeeRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.FeeRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveLimitRule provides a mock function with given fields: ctx, rule
+func (_m *FeeLimitRepository) SaveLimitRule(ctx context.Context, rule entity.LimitRule) (entity.LimitRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	var r0 entity.LimitRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LimitRule) (entity.LimitRule, error)); ok {
+		return rf(ctx, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LimitRule) entity.LimitRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Get(0).(entity.LimitRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.LimitRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumAmountSince provides a mock function with given fields: ctx, idUser, trxType, since
+func (_m *FeeLimitRepository) SumAmountSince(ctx context.Context, idUser uint64, trxType string, since int64) (money.Money, error) {
+	ret := _m.Called(ctx, idUser, trxType, since)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, int64) (money.Money, error)); ok {
+		return rf(ctx, idUser, trxType, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, int64) money.Money); ok {
+		r0 = rf(ctx, idUser, trxType, since)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string, int64) error); ok {
+		r1 = rf(ctx, idUser, trxType, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFeeLimitRepository creates a new instance of FeeLimitRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFeeLimitRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FeeLimitRepository {
+	mock := &FeeLimitRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,219 @@
+// Code generated by mockery v2.35.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionRepository is an autogenerated mock type for the TransactionRepository type
+type TransactionRepository struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields: ctx, page, pageSize
+func (_m *TransactionRepository) All(ctx context.Context, page int, pageSize int) ([]entity.Transaction, error) {
+	ret := _m.Called(ctx, page, pageSize)
+
+	var r0 []entity.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Transaction, error)); ok {
+		return rf(ctx, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Transaction); ok {
+		r0 = rf(ctx, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTransactionByID provides a mock function with given fields: ctx, id
+func (_m *TransactionRepository) FindTransactionByID(ctx context.Context, id uint64) entity.Transaction {
+	ret := _m.Called(ctx, id)
+
+	var r0 entity.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) entity.Transaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.Transaction)
+	}
+
+	return r0
+}
+
+// FindTransactionByIDUser provides a mock function with given fields: ctx, id, page, pageSize
+func (_m *TransactionRepository) FindTransactionByIDUser(ctx context.Context, id uint64, page int, pageSize int) ([]entity.Transaction, error) {
+	ret := _m.Called(ctx, id, page, pageSize)
+
+	var r0 []entity.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) ([]entity.Transaction, error)); ok {
+		return rf(ctx, id, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) []entity.Transaction); ok {
+		r0 = rf(ctx, id, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int, int) error); ok {
+		r1 = rf(ctx, id, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUserByAccNumber provides a mock function with given fields: ctx, accNumber
+func (_m *TransactionRepository) FindUserByAccNumber(ctx context.Context, accNumber uint64) entity.User {
+	ret := _m.Called(ctx, accNumber)
+
+	var r0 entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) entity.User); ok {
+		r0 = rf(ctx, accNumber)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	return r0
+}
+
+// InsertTransaction provides a mock function with given fields: ctx, brg
+func (_m *TransactionRepository) InsertTransaction(ctx context.Context, brg *entity.Transaction) entity.Transaction {
+	ret := _m.Called(ctx, brg)
+
+	var r0 entity.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Transaction) entity.Transaction); ok {
+		r0 = rf(ctx, brg)
+	} else {
+		r0 = ret.Get(0).(entity.Transaction)
+	}
+
+	return r0
+}
+
+// RecentTransactions provides a mock function with given fields: ctx, idUser, accNumber, limit
+func (_m *TransactionRepository) RecentTransactions(ctx context.Context, idUser uint64, accNumber uint64, limit int) ([]entity.Transaction, error) {
+	ret := _m.Called(ctx, idUser, accNumber, limit)
+
+	var r0 []entity.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, int) ([]entity.Transaction, error)); ok {
+		return rf(ctx, idUser, accNumber, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, int) []entity.Transaction); ok {
+		r0 = rf(ctx, idUser, accNumber, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, int) error); ok {
+		r1 = rf(ctx, idUser, accNumber, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalTransaction provides a mock function with given fields: ctx
+func (_m *TransactionRepository) TotalTransaction(ctx context.Context) int64 {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TotalTransactionByUserID provides a mock function with given fields: ctx, idUser
+func (_m *TransactionRepository) TotalTransactionByUserID(ctx context.Context, idUser uint64) int64 {
+	ret := _m.Called(ctx, idUser)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) int64); ok {
+		r0 = rf(ctx, idUser)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// UpdateTransaction provides a mock function with given fields: ctx, plg
+func (_m *TransactionRepository) UpdateTransaction(ctx context.Context, plg entity.Transaction) entity.Transaction {
+	ret := _m.Called(ctx, plg)
+
+	var r0 entity.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Transaction) entity.Transaction); ok {
+		r0 = rf(ctx, plg)
+	} else {
+		r0 = ret.Get(0).(entity.Transaction)
+	}
+
+	return r0
+}
+
+// UpdateTransactionStatus provides a mock function with given fields: ctx, id, newStatus
+func (_m *TransactionRepository) UpdateTransactionStatus(ctx context.Context, id uint64, newStatus uint64) error {
+	ret := _m.Called(ctx, id, newStatus)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) error); ok {
+		r0 = rf(ctx, id, newStatus)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateAccNumber provides a mock function with given fields: ctx, accNumber
+func (_m *TransactionRepository) ValidateAccNumber(ctx context.Context, accNumber uint64) bool {
+	ret := _m.Called(ctx, accNumber)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) bool); ok {
+		r0 = rf(ctx, accNumber)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewTransactionRepository creates a new instance of TransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransactionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransactionRepository {
+	mock := &TransactionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
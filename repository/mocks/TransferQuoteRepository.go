@@ -0,0 +1,83 @@
+// Code generated by mockery v2.35.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TransferQuoteRepository is an autogenerated mock type for the TransferQuoteRepository type
+type TransferQuoteRepository struct {
+	mock.Mock
+}
+
+// ConsumeQuote provides a mock function with given fields: ctx, quoteID
+func (_m *TransferQuoteRepository) ConsumeQuote(ctx context.Context, quoteID string) error {
+	ret := _m.Called(ctx, quoteID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, quoteID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindQuote provides a mock function with given fields: ctx, quoteID
+func (_m *TransferQuoteRepository) FindQuote(ctx context.Context, quoteID string) (entity.TransferQuote, error) {
+	ret := _m.Called(ctx, quoteID)
+
+	var r0 entity.TransferQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.TransferQuote, error)); ok {
+		return rf(ctx, quoteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.TransferQuote); ok {
+		r0 = rf(ctx, quoteID)
+	} else {
+		r0 = ret.Get(0).(entity.TransferQuote)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, quoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertQuote provides a mock function with given fields: ctx, quote, ttl
+func (_m *TransferQuoteRepository) InsertQuote(ctx context.Context, quote entity.TransferQuote, ttl time.Duration) error {
+	ret := _m.Called(ctx, quote, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TransferQuote, time.Duration) error); ok {
+		r0 = rf(ctx, quote, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTransferQuoteRepository creates a new instance of TransferQuoteRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransferQuoteRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransferQuoteRepository {
+	mock := &TransferQuoteRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
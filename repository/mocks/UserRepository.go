@@ -0,0 +1,505 @@
+// Code generated by mockery v2.35.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/IrvanWijayaSardam/SelfBank/money"
+)
+
+// UserRepository is an autogenerated mock type for the UserRepository type
+type UserRepository struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields: ctx, page, pageSize
+func (_m *UserRepository) All(ctx context.Context, page int, pageSize int) ([]entity.User, error) {
+	ret := _m.Called(ctx, page, pageSize)
+
+	var r0 []entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.User, error)); ok {
+		return rf(ctx, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.User); ok {
+		r0 = rf(ctx, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseAccount provides a mock function with given fields: ctx, user, payout
+func (_m *UserRepository) CloseAccount(ctx context.Context, user entity.User, payout *entity.Withdrawal) error {
+	ret := _m.Called(ctx, user, payout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, *entity.Withdrawal) error); ok {
+		r0 = rf(ctx, user, payout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DormancyCandidates provides a mock function with given fields: ctx, idleSince, limit
+func (_m *UserRepository) DormancyCandidates(ctx context.Context, idleSince int64, limit int) ([]entity.User, error) {
+	ret := _m.Called(ctx, idleSince, limit)
+
+	var r0 []entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]entity.User, error)); ok {
+		return rf(ctx, idleSince, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []entity.User); ok {
+		r0 = rf(ctx, idleSince, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, idleSince, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByEmail provides a mock function with given fields: ctx, email
+func (_m *UserRepository) FindByEmail(ctx context.Context, email string) entity.User {
+	ret := _m.Called(ctx, email)
+
+	var r0 entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	return r0
+}
+
+// FindByEmailAnyStatus provides a mock function with given fields: ctx, email
+func (_m *UserRepository) FindByEmailAnyStatus(ctx context.Context, email string) (entity.User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRole provides a mock function with given fields: ctx, id
+func (_m *UserRepository) FindRole(ctx context.Context, id uint64) entity.Role {
+	ret := _m.Called(ctx, id)
+
+	var r0 entity.Role
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) entity.Role); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.Role)
+	}
+
+	return r0
+}
+
+// InsertUser provides a mock function with given fields: ctx, user
+func (_m *UserRepository) InsertUser(ctx context.Context, user entity.User) (entity.User, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) (entity.User, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) entity.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsDuplicateEmail provides a mock function with given fields: ctx, email
+func (_m *UserRepository) IsDuplicateEmail(ctx context.Context, email string) *gorm.DB {
+	ret := _m.Called(ctx, email)
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gorm.DB); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
+// MarkDormant provides a mock function with given fields: ctx, id, idleSince
+func (_m *UserRepository) MarkDormant(ctx context.Context, id uint64, idleSince int64) (bool, error) {
+	ret := _m.Called(ctx, id, idleSince)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) (bool, error)); ok {
+		return rf(ctx, id, idleSince)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) bool); ok {
+		r0 = rf(ctx, id, idleSince)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int64) error); ok {
+		r1 = rf(ctx, id, idleSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileUser provides a mock function with given fields: ctx, userId
+func (_m *UserRepository) ProfileUser(ctx context.Context, userId uint64) (entity.User, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (entity.User, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) entity.User); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveUser provides a mock function with given fields: ctx, user
+func (_m *UserRepository) SaveUser(ctx context.Context, user entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: ctx, query, status, page, pageSize
+func (_m *UserRepository) SearchUsers(ctx context.Context, query string, status uint64, page int, pageSize int) ([]entity.User, error) {
+	ret := _m.Called(ctx, query, status, page, pageSize)
+
+	var r0 []entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, int, int) ([]entity.User, error)); ok {
+		return rf(ctx, query, status, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, int, int) []entity.User); ok {
+		r0 = rf(ctx, query, status, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64, int, int) error); ok {
+		r1 = rf(ctx, query, status, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalDepositByUserID provides a mock function with given fields: ctx, userId
+func (_m *UserRepository) TotalDepositByUserID(ctx context.Context, userId uint64) (money.Money, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (money.Money, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) money.Money); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalPendingDepositsByUserID provides a mock function with given fields: ctx, idUser
+func (_m *UserRepository) TotalPendingDepositsByUserID(ctx context.Context, idUser uint64) int64 {
+	ret := _m.Called(ctx, idUser)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) int64); ok {
+		r0 = rf(ctx, idUser)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TotalSearchUsers provides a mock function with given fields: ctx, query, status
+func (_m *UserRepository) TotalSearchUsers(ctx context.Context, query string, status uint64) int64 {
+	ret := _m.Called(ctx, query, status)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) int64); ok {
+		r0 = rf(ctx, query, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TotalTransactionFromByAccountNumber provides a mock function with given fields: ctx, accountNumber
+func (_m *UserRepository) TotalTransactionFromByAccountNumber(ctx context.Context, accountNumber string) (money.Money, error) {
+	ret := _m.Called(ctx, accountNumber)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (money.Money, error)); ok {
+		return rf(ctx, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) money.Money); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalTransactionInByAccountNumber provides a mock function with given fields: ctx, accountNumber
+func (_m *UserRepository) TotalTransactionInByAccountNumber(ctx context.Context, accountNumber string) (money.Money, error) {
+	ret := _m.Called(ctx, accountNumber)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (money.Money, error)); ok {
+		return rf(ctx, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) money.Money); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalWalletInByUserID provides a mock function with given fields: ctx, idUser, currency
+func (_m *UserRepository) TotalWalletInByUserID(ctx context.Context, idUser uint64, currency string) (money.Money, error) {
+	ret := _m.Called(ctx, idUser, currency)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (money.Money, error)); ok {
+		return rf(ctx, idUser, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) money.Money); ok {
+		r0 = rf(ctx, idUser, currency)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, idUser, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalWalletOutByUserID provides a mock function with given fields: ctx, idUser, currency
+func (_m *UserRepository) TotalWalletOutByUserID(ctx context.Context, idUser uint64, currency string) (money.Money, error) {
+	ret := _m.Called(ctx, idUser, currency)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (money.Money, error)); ok {
+		return rf(ctx, idUser, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) money.Money); ok {
+		r0 = rf(ctx, idUser, currency)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, idUser, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalWithdrawalByUserID provides a mock function with given fields: ctx, userid
+func (_m *UserRepository) TotalWithdrawalByUserID(ctx context.Context, userid uint64) (money.Money, error) {
+	ret := _m.Called(ctx, userid)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (money.Money, error)); ok {
+		return rf(ctx, userid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) money.Money); ok {
+		r0 = rf(ctx, userid)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *UserRepository) UpdateUser(ctx context.Context, user entity.User) entity.User {
+	ret := _m.Called(ctx, user)
+
+	var r0 entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) entity.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	return r0
+}
+
+// UpdateUserIf provides a mock function with given fields: ctx, id, expected, columns
+func (_m *UserRepository) UpdateUserIf(ctx context.Context, id uint64, expected map[string]interface{}, columns map[string]interface{}) (bool, error) {
+	ret := _m.Called(ctx, id, expected, columns)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, map[string]interface{}, map[string]interface{}) (bool, error)); ok {
+		return rf(ctx, id, expected, columns)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, map[string]interface{}, map[string]interface{}) bool); ok {
+		r0 = rf(ctx, id, expected, columns)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, map[string]interface{}, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, expected, columns)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyCredential provides a mock function with given fields: ctx, email, password
+func (_m *UserRepository) VerifyCredential(ctx context.Context, email string, password string) interface{} {
+	ret := _m.Called(ctx, email, password)
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) interface{}); ok {
+		r0 = rf(ctx, email, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
+// NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserRepository {
+	mock := &UserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,8 +1,9 @@
 package repository
 
 import (
-	"errors"
+	"context"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
 
@@ -10,33 +11,39 @@ import (
 )
 
 type TransactionRepository interface {
-	InsertTransaction(brg *entity.Transaction) entity.Transaction
-	All(page int, pageSize int) ([]entity.Transaction, error)
-	UpdateTransaction(plg entity.Transaction) entity.Transaction
-	FindTransactionByID(id uint64) entity.Transaction
-	FindTransactionByIDUser(id uint64, page int, pageSize int) ([]entity.Transaction, error)
-	TotalTransaction() int64
-	TotalTransactionByUserID(idUser uint64) int64
-	UpdateTransactionStatus(id uint64, newStatus uint64) error
-	ValidateAccNumber(accNumber uint64) bool
+	InsertTransaction(ctx context.Context, brg *entity.Transaction) entity.Transaction
+	All(ctx context.Context, page int, pageSize int) ([]entity.Transaction, error)
+	UpdateTransaction(ctx context.Context, plg entity.Transaction) entity.Transaction
+	FindTransactionByID(ctx context.Context, id uint64) entity.Transaction
+	FindTransactionByIDUser(ctx context.Context, id uint64, page int, pageSize int) ([]entity.Transaction, error)
+	RecentTransactions(ctx context.Context, idUser uint64, accNumber uint64, limit int) ([]entity.Transaction, error)
+	TotalTransaction(ctx context.Context) int64
+	TotalTransactionByUserID(ctx context.Context, idUser uint64) int64
+	UpdateTransactionStatus(ctx context.Context, id uint64, newStatus uint64) error
+	ValidateAccNumber(ctx context.Context, accNumber uint64) bool
+	FindUserByAccNumber(ctx context.Context, accNumber uint64) entity.User
 }
 
+// receivingStatuses are the account states that can be paid into. Dormant
+// accounts keep receiving money, frozen and closed ones do not.
+var receivingStatuses = []uint64{entity.UserStatusActive, entity.UserStatusDormant}
+
 type TransactionConnection struct {
 	connection *gorm.DB
 }
 
-func (db *TransactionConnection) TotalTransaction() int64 {
+func (db *TransactionConnection) TotalTransaction(ctx context.Context) int64 {
 	var count int64
-	result := db.connection.Model(&entity.Transaction{}).Where("status = ?", 1).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Transaction{}).Where("status = ?", 1).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
 	return count
 }
 
-func (db *TransactionConnection) TotalTransactionByUserID(idUser uint64) int64 {
+func (db *TransactionConnection) TotalTransactionByUserID(ctx context.Context, idUser uint64) int64 {
 	var count int64
-	result := db.connection.Model(&entity.Transaction{}).Where("id_user = ? && status = ?", idUser, 1).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Transaction{}).Where("id_user = ? && status = ?", idUser, 1).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
@@ -49,15 +56,15 @@ func NewTransactionRepository(db *gorm.DB) TransactionRepository {
 	}
 }
 
-func (db *TransactionConnection) InsertTransaction(Transaction *entity.Transaction) entity.Transaction {
+func (db *TransactionConnection) InsertTransaction(ctx context.Context, Transaction *entity.Transaction) entity.Transaction {
 	Transaction.Date = helper.GetCurrentTimeInLocation()
-	db.connection.Save(Transaction)
+	db.connection.WithContext(ctx).Save(Transaction)
 	return *Transaction
 }
 
-func (db *TransactionConnection) ValidateAccNumber(accNumber uint64) bool {
+func (db *TransactionConnection) ValidateAccNumber(ctx context.Context, accNumber uint64) bool {
 	var count int64
-	result := db.connection.Model(&entity.User{}).Where("account_number = ?", accNumber).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.User{}).Where("account_number = ? AND status IN ?", accNumber, receivingStatuses).Count(&count)
 	if result.Error != nil {
 		return false
 	}
@@ -68,15 +75,21 @@ func (db *TransactionConnection) ValidateAccNumber(accNumber uint64) bool {
 	}
 }
 
-func (db *TransactionConnection) All(page int, pageSize int) ([]entity.Transaction, error) {
+func (db *TransactionConnection) FindUserByAccNumber(ctx context.Context, accNumber uint64) entity.User {
+	var user entity.User
+	db.connection.WithContext(ctx).Where("account_number = ? AND status IN ?", accNumber, receivingStatuses).Take(&user)
+	return user
+}
+
+func (db *TransactionConnection) All(ctx context.Context, page int, pageSize int) ([]entity.Transaction, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
 	var transactions []entity.Transaction
 	offset := (page - 1) * pageSize
 
-	result := db.connection.Where("status = ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	result := db.connection.WithContext(ctx).Where("status = ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -85,15 +98,27 @@ func (db *TransactionConnection) All(page int, pageSize int) ([]entity.Transacti
 	return transactions, nil
 }
 
-func (db *TransactionConnection) FindTransactionByIDUser(idUser uint64, page int, pageSize int) ([]entity.Transaction, error) {
+func (db *TransactionConnection) FindTransactionByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.Transaction, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
 	var transactions []entity.Transaction
 	offset := (page - 1) * pageSize
 
-	result := db.connection.Where("id_user = ? && status = ?", idUser, 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	result := db.connection.WithContext(ctx).Where("id_user = ? && status = ?", idUser, 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return transactions, nil
+}
+
+// RecentTransactions returns the latest transfers sent or received by the
+// user, newest first.
+func (db *TransactionConnection) RecentTransactions(ctx context.Context, idUser uint64, accNumber uint64, limit int) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+	result := db.connection.WithContext(ctx).Where("(id_user = ? OR transaction_to = ?) AND status = ?", idUser, accNumber, 1).Order("date desc").Limit(limit).Find(&transactions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -101,14 +126,14 @@ func (db *TransactionConnection) FindTransactionByIDUser(idUser uint64, page int
 	return transactions, nil
 }
 
-func (db *TransactionConnection) UpdateTransaction(Transaction entity.Transaction) entity.Transaction {
-	db.connection.Save(&Transaction)
+func (db *TransactionConnection) UpdateTransaction(ctx context.Context, Transaction entity.Transaction) entity.Transaction {
+	db.connection.WithContext(ctx).Save(&Transaction)
 	return Transaction
 }
 
-func (db *TransactionConnection) FindTransactionByID(id uint64) entity.Transaction {
+func (db *TransactionConnection) FindTransactionByID(ctx context.Context, id uint64) entity.Transaction {
 	var Transaction entity.Transaction
-	result := db.connection.Where("id = ? ", id).Take(&Transaction)
+	result := db.connection.WithContext(ctx).Where("id = ? ", id).Take(&Transaction)
 	if result.Error != nil || result.RowsAffected == 0 {
 		return Transaction
 	}
@@ -116,16 +141,16 @@ func (db *TransactionConnection) FindTransactionByID(id uint64) entity.Transacti
 	return Transaction
 }
 
-func (db *TransactionConnection) UpdateTransactionStatus(id uint64, newStatus uint64) error {
+func (db *TransactionConnection) UpdateTransactionStatus(ctx context.Context, id uint64, newStatus uint64) error {
 	var trx entity.Transaction
-	result := db.connection.First(&trx, id)
+	result := db.connection.WithContext(ctx).First(&trx, id)
 	if result.Error != nil {
 		return result.Error
 	}
 
 	trx.Status = newStatus
 
-	result = db.connection.Save(&trx)
+	result = db.connection.WithContext(ctx).Save(&trx)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/go-redis/redis"
+)
+
+const transferQuotePrefix = "transfer-quote:"
+
+type TransferQuoteRepository interface {
+	InsertQuote(ctx context.Context, quote entity.TransferQuote, ttl time.Duration) error
+	FindQuote(ctx context.Context, quoteID string) (entity.TransferQuote, error)
+	ConsumeQuote(ctx context.Context, quoteID string) error
+}
+
+type transferQuoteConnection struct {
+	connection *redis.Client
+}
+
+func NewTransferQuoteRepository(db *redis.Client) TransferQuoteRepository {
+	return &transferQuoteConnection{connection: db}
+}
+
+func (db *transferQuoteConnection) InsertQuote(ctx context.Context, quote entity.TransferQuote, ttl time.Duration) error {
+	payload, err := json.Marshal(quote)
+	if err != nil {
+		return err
+	}
+
+	return cacheError(ctx, cacheClient(ctx, db.connection).Set(transferQuotePrefix+quote.ID, payload, ttl).Err())
+}
+
+func (db *transferQuoteConnection) FindQuote(ctx context.Context, quoteID string) (entity.TransferQuote, error) {
+	var quote entity.TransferQuote
+
+	payload, err := cacheClient(ctx, db.connection).Get(transferQuotePrefix + quoteID).Bytes()
+	err = cacheError(ctx, err)
+	if err == redis.Nil {
+		return quote, apperror.ErrQuoteExpired
+	}
+	if err != nil {
+		return quote, err
+	}
+
+	err = json.Unmarshal(payload, &quote)
+	return quote, err
+}
+
+// ConsumeQuote deletes the quote and fails with ErrQuoteExpired when it was
+// already gone. Redis runs DEL atomically, so of concurrent confirmations of
+// one quote only a single one gets past it.
+func (db *transferQuoteConnection) ConsumeQuote(ctx context.Context, quoteID string) error {
+	deleted, err := cacheClient(ctx, db.connection).Del(transferQuotePrefix + quoteID).Result()
+	if err := cacheError(ctx, err); err != nil {
+		return err
+	}
+	if deleted != 1 {
+		return apperror.ErrQuoteExpired
+	}
+	return nil
+}
@@ -1,48 +1,80 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"strings"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
 
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	All(page int, pageSize int) ([]entity.User, error)
-	InsertUser(user entity.User) entity.User
-	UpdateUser(user entity.User) entity.User
-	DeleteUser(idUser uint64) bool
-	VerifyCredential(email string, password string) interface{}
-	IsDuplicateEmail(email string) (tx *gorm.DB)
-	FindByEmail(email string) entity.User
-	ProfileUser(userId uint64) entity.User
-	TotalDepositByUserID(userId uint64) int64
-	TotalWithdrawalByUserID(userid uint64) int64
-	TotalTransactionInByAccountNumber(accountNumber string) int64
-	TotalTransactionFromByAccountNumber(accountNumber string) int64
+	All(ctx context.Context, page int, pageSize int) ([]entity.User, error)
+	SearchUsers(ctx context.Context, query string, status uint64, page int, pageSize int) ([]entity.User, error)
+	TotalSearchUsers(ctx context.Context, query string, status uint64) int64
+	SaveUser(ctx context.Context, user entity.User) error
+	UpdateUserIf(ctx context.Context, id uint64, expected map[string]interface{}, columns map[string]interface{}) (bool, error)
+	FindRole(ctx context.Context, id uint64) entity.Role
+	InsertUser(ctx context.Context, user entity.User) (entity.User, error)
+	UpdateUser(ctx context.Context, user entity.User) entity.User
+	CloseAccount(ctx context.Context, user entity.User, payout *entity.Withdrawal) error
+	DormancyCandidates(ctx context.Context, idleSince int64, limit int) ([]entity.User, error)
+	MarkDormant(ctx context.Context, id uint64, idleSince int64) (bool, error)
+	TotalPendingDepositsByUserID(ctx context.Context, idUser uint64) int64
+	VerifyCredential(ctx context.Context, email string, password string) interface{}
+	IsDuplicateEmail(ctx context.Context, email string) (tx *gorm.DB)
+	FindByEmail(ctx context.Context, email string) entity.User
+	FindByEmailAnyStatus(ctx context.Context, email string) (entity.User, error)
+	ProfileUser(ctx context.Context, userId uint64) (entity.User, error)
+	TotalDepositByUserID(ctx context.Context, userId uint64) (money.Money, error)
+	TotalWithdrawalByUserID(ctx context.Context, userid uint64) (money.Money, error)
+	TotalTransactionInByAccountNumber(ctx context.Context, accountNumber string) (money.Money, error)
+	TotalTransactionFromByAccountNumber(ctx context.Context, accountNumber string) (money.Money, error)
+	TotalWalletInByUserID(ctx context.Context, idUser uint64, currency string) (money.Money, error)
+	TotalWalletOutByUserID(ctx context.Context, idUser uint64, currency string) (money.Money, error)
 }
 
+// accountNumberAttempts bounds the account numbers tried for a new user
+// when the generated ones are taken.
+const accountNumberAttempts = 5
+
+// Unique indexes of the users table, told apart when an insert violates one.
+const (
+	accountNumberIndex = "idx_users_account_number"
+	emailIndex         = "idx_users_email"
+)
+
+// mysqlDuplicateEntry is the MySQL error number of a unique index violation.
+const mysqlDuplicateEntry = 1062
+
 type userConnection struct {
-	connection *gorm.DB
+	connection          *gorm.DB
+	accountNumberPrefix string
 }
 
-func NewUserRepository(db *gorm.DB) UserRepository {
+func NewUserRepository(db *gorm.DB, accountNumberPrefix string) UserRepository {
 	return &userConnection{
-		connection: db,
+		connection:          db,
+		accountNumberPrefix: accountNumberPrefix,
 	}
 }
 
-func (db *userConnection) All(page int, pageSize int) ([]entity.User, error) {
+func (db *userConnection) All(ctx context.Context, page int, pageSize int) ([]entity.User, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
 	var transactions []entity.User
 	offset := (page - 1) * pageSize
 
-	result := db.connection.Where("status = ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	result := db.connection.WithContext(ctx).Where("status = ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -50,88 +82,259 @@ func (db *userConnection) All(page int, pageSize int) ([]entity.User, error) {
 	return transactions, nil
 }
 
-func (db *userConnection) InsertUser(user entity.User) entity.User {
+// SearchUsers finds users by name, email or phone number, or by account
+// number when the query is one. A status of 0 matches every status.
+func (db *userConnection) SearchUsers(ctx context.Context, query string, status uint64, page int, pageSize int) ([]entity.User, error) {
+	if page <= 0 || pageSize <= 0 {
+		return nil, apperror.ErrInvalidPagination
+	}
+
+	var users []entity.User
+	offset := (page - 1) * pageSize
+
+	result := db.searchUsers(ctx, query, status).Order("id asc").Offset(offset).Limit(pageSize).Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return users, nil
+}
+
+func (db *userConnection) TotalSearchUsers(ctx context.Context, query string, status uint64) int64 {
+	var count int64
+	result := db.searchUsers(ctx, query, status).Model(&entity.User{}).Count(&count)
+	if result.Error != nil {
+		return 0
+	}
+	return count
+}
+
+func (db *userConnection) searchUsers(ctx context.Context, query string, status uint64) *gorm.DB {
+	tx := db.connection.WithContext(ctx)
+	if status != 0 {
+		tx = tx.Where("status = ?", status)
+	}
+	if query == "" {
+		return tx
+	}
+
+	pattern := "%" + query + "%"
+	return tx.Where("namadepan LIKE ? || namabelakang LIKE ? || CONCAT(namadepan, ' ', namabelakang) LIKE ? || email LIKE ? || telephone LIKE ? || account_number = ?",
+		pattern, pattern, pattern, pattern, pattern, query)
+}
+
+// SaveUser works like UpdateUser but reports failures, including an audit
+// log that could not be written.
+func (db *userConnection) SaveUser(ctx context.Context, user entity.User) error {
+	return db.connection.WithContext(ctx).Save(&user).Error
+}
+
+// UpdateUserIf updates only the given columns of the user, and only while the
+// row still holds the expected values, so that two changes made at once do
+// not overwrite each other. It reports whether the row was updated.
+func (db *userConnection) UpdateUserIf(ctx context.Context, id uint64, expected map[string]interface{}, columns map[string]interface{}) (bool, error) {
+	result := db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Where(expected).Updates(columns)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (db *userConnection) FindRole(ctx context.Context, id uint64) entity.Role {
+	var role entity.Role
+	db.connection.WithContext(ctx).Where("id = ?", id).Take(&role)
+	return role
+}
+
+// InsertUser registers an active customer, the role and status are never
+// taken from the caller. The user gets a new account number, generating
+// another when the unique index reports it taken. An email registered in the
+// meantime is reported as apperror.ErrEmailTaken.
+func (db *userConnection) InsertUser(ctx context.Context, user entity.User) (entity.User, error) {
 	user.Password = helper.HashAndSalt([]byte(user.Password))
-	user.AccountNumber = helper.GenerateRandomAccountNumber()
-	db.connection.Save(&user)
-	return user
+	user.IdRole = entity.RoleUser
+	user.Status = entity.UserStatusActive
+	user.KycTier = entity.KycTierUnverified
+	user.ActiveSince = helper.GetCurrentTimeInLocation()
+
+	for attempt := 1; ; attempt++ {
+		accountNumber, err := helper.GenerateAccountNumber(db.accountNumberPrefix)
+		if err != nil {
+			return entity.User{}, err
+		}
+		user.AccountNumber = accountNumber
+
+		err = db.connection.WithContext(ctx).Create(&user).Error
+		if err == nil {
+			return user, nil
+		}
+		switch duplicateKey(err) {
+		case emailIndex:
+			return entity.User{}, apperror.ErrEmailTaken
+		case accountNumberIndex:
+			if attempt < accountNumberAttempts {
+				logging.FromContext(ctx).Warn("Generated account number is taken, attempt ", attempt, " of ", accountNumberAttempts)
+				continue
+			}
+		}
+		return entity.User{}, err
+	}
+}
+
+// duplicateKey returns the unique index err reports violated, or "" for any
+// other error. MySQL 8 names the index as table.index, older versions leave
+// the table out.
+func duplicateKey(err error) string {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlDuplicateEntry {
+		return ""
+	}
+	_, key, found := strings.Cut(mysqlErr.Message, " for key ")
+	if !found {
+		return ""
+	}
+	key = strings.Trim(key, "'")
+	return key[strings.LastIndex(key, ".")+1:]
 }
 
-func (db *userConnection) UpdateUser(user entity.User) entity.User {
-	db.connection.Save(&user)
+func (db *userConnection) UpdateUser(ctx context.Context, user entity.User) entity.User {
+	db.connection.WithContext(ctx).Save(&user)
 	return user
 }
 
-func (db *userConnection) DeleteUser(idUser uint64) bool {
-	var user entity.User
-	db.connection.Where("id = ?", idUser).Take(&user)
-	user.Status = 2
-	result := db.connection.Save(&user)
+// CloseAccount saves the closed user together with the withdrawal paying
+// out the remaining balance, when there is one, in one database transaction.
+func (db *userConnection) CloseAccount(ctx context.Context, user entity.User, payout *entity.Withdrawal) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if payout != nil {
+			payout.Date = helper.GetCurrentTimeInLocation()
+			if err := tx.Create(payout).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(&user).Error
+	})
+}
 
-	if result.RowsAffected == 1 {
-		return true
+// DormancyCandidates finds active customers that were opened or reactivated
+// before idleSince and have not deposited, withdrawn or sent money since.
+// Money they received does not count as activity.
+func (db *userConnection) DormancyCandidates(ctx context.Context, idleSince int64, limit int) ([]entity.User, error) {
+	var users []entity.User
+	result := db.connection.WithContext(ctx).
+		Where("status = ? AND id_role = ? AND active_since < ?", entity.UserStatusActive, entity.RoleUser, idleSince).
+		Where("NOT EXISTS (SELECT 1 FROM transactions t WHERE t.id_user = users.id AND t.date >= ?)", idleSince).
+		Where("NOT EXISTS (SELECT 1 FROM withdrawals w WHERE w.id_user = users.id AND w.date >= ?)", idleSince).
+		Where("NOT EXISTS (SELECT 1 FROM deposits d WHERE d.id_user = users.id AND d.date >= ?)", idleSince).
+		Where("NOT EXISTS (SELECT 1 FROM wallet_transfers wt WHERE wt.id_user = users.id AND wt.date >= ?)", idleSince).
+		Order("id asc").Limit(limit).Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
 	}
-	return false
+	return users, nil
+}
+
+// MarkDormant marks the user dormant only while the account is still active
+// and was opened or reactivated before idleSince, so a freeze or reactivation
+// made after the user was picked is kept. It reports whether it was marked.
+func (db *userConnection) MarkDormant(ctx context.Context, id uint64, idleSince int64) (bool, error) {
+	result := db.connection.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND status = ? AND active_since < ?", id, entity.UserStatusActive, idleSince).
+		Update("status", entity.UserStatusDormant)
+	return result.RowsAffected > 0, result.Error
 }
 
-func (db *userConnection) VerifyCredential(email string, password string) interface{} {
+// TotalPendingDepositsByUserID counts the deposits still waiting for the
+// payment, created or pending at Midtrans.
+func (db *userConnection) TotalPendingDepositsByUserID(ctx context.Context, idUser uint64) int64 {
+	var count int64
+	db.connection.WithContext(ctx).Model(&entity.Deposit{}).Where("id_user = ? AND status IN ?", idUser, []uint64{1, 2}).Count(&count)
+	return count
+}
+
+func (db *userConnection) VerifyCredential(ctx context.Context, email string, password string) interface{} {
 	var user entity.User
-	res := db.connection.Where("email =?", email).Take(&user)
+	res := db.connection.WithContext(ctx).Where("email =?", email).Take(&user)
 	if res.Error == nil {
 		return user
 	}
 	return nil
 }
 
-func (db *userConnection) IsDuplicateEmail(email string) (tx *gorm.DB) {
+func (db *userConnection) IsDuplicateEmail(ctx context.Context, email string) (tx *gorm.DB) {
 	var user entity.User
-	return db.connection.Where("email = ?", email).Take(&user)
+	return db.connection.WithContext(ctx).Where("email = ?", email).Take(&user)
 }
 
-func (db *userConnection) FindByEmail(email string) entity.User {
+func (db *userConnection) FindByEmail(ctx context.Context, email string) entity.User {
 	var user entity.User
-	db.connection.Where("email LIKE ? AND status = ?", "%"+email+"%", 1).Take(&user)
+	db.connection.WithContext(ctx).Where("email LIKE ? AND status = ?", "%"+email+"%", 1).Take(&user)
 	return user
 }
 
-func (db *userConnection) ProfileUser(userID uint64) entity.User {
+// FindByEmailAnyStatus looks up the user with exactly this email whatever the
+// account status, for operators acting on frozen or closed accounts too.
+func (db *userConnection) FindByEmailAnyStatus(ctx context.Context, email string) (entity.User, error) {
 	var user entity.User
-	db.connection.Find(&user, userID)
-	return user
+	err := db.connection.WithContext(ctx).Where("email = ?", email).Take(&user).Error
+	return user, err
+}
+
+// ProfileUser looks the user up by ID, failing with gorm.ErrRecordNotFound
+// when there is none so callers can tell it apart from a failed query.
+func (db *userConnection) ProfileUser(ctx context.Context, userID uint64) (entity.User, error) {
+	var user entity.User
+	err := db.connection.WithContext(ctx).Take(&user, userID).Error
+	return user, err
 }
 
-func (db *userConnection) TotalDepositByUserID(idUser uint64) int64 {
+func (db *userConnection) TotalDepositByUserID(ctx context.Context, idUser uint64) (money.Money, error) {
 	var totalAmount int64
-	result := db.connection.Model(&entity.Deposit{}).Select("SUM(amount)").Where("id_user = ? && status = ?", idUser, 5).Scan(&totalAmount)
+	result := db.connection.WithContext(ctx).Model(&entity.Deposit{}).Select("COALESCE(SUM(amount), 0)").Where("id_user = ? && status = ?", idUser, 5).Scan(&totalAmount)
 	if result.Error != nil {
-		return 0
+		return money.Money{}, result.Error
 	}
-	return totalAmount
+	return money.Rupiah(totalAmount), nil
 }
 
-func (db *userConnection) TotalWithdrawalByUserID(idUser uint64) int64 {
+func (db *userConnection) TotalWithdrawalByUserID(ctx context.Context, idUser uint64) (money.Money, error) {
 	var totalAmount int64
-	result := db.connection.Model(&entity.Withdrawal{}).Select("SUM(amount)").Where("id_user = ? && status = ?", idUser, 1).Scan(&totalAmount)
+	result := db.connection.WithContext(ctx).Model(&entity.Withdrawal{}).Select("COALESCE(SUM(amount + fee), 0)").Where("id_user = ? && status = ?", idUser, 1).Scan(&totalAmount)
 	if result.Error != nil {
-		return 0
+		return money.Money{}, result.Error
 	}
-	return totalAmount
+	return money.Rupiah(totalAmount), nil
 }
 
-func (db *userConnection) TotalTransactionInByAccountNumber(accountNumber string) int64 {
+func (db *userConnection) TotalTransactionInByAccountNumber(ctx context.Context, accountNumber string) (money.Money, error) {
 	var totalAmount int64
-	result := db.connection.Model(&entity.Transaction{}).Select("SUM(amount)").Where("transaction_to = ? && status = ?", accountNumber, 1).Scan(&totalAmount)
+	result := db.connection.WithContext(ctx).Model(&entity.Transaction{}).Select("COALESCE(SUM(amount), 0)").Where("transaction_to = ? && status = ?", accountNumber, 1).Scan(&totalAmount)
 	if result.Error != nil {
-		return 0
+		return money.Money{}, result.Error
 	}
-	return totalAmount
+	return money.Rupiah(totalAmount), nil
 }
 
-func (db *userConnection) TotalTransactionFromByAccountNumber(accountNumber string) int64 {
+func (db *userConnection) TotalTransactionFromByAccountNumber(ctx context.Context, accountNumber string) (money.Money, error) {
 	var totalAmount int64
-	result := db.connection.Model(&entity.Transaction{}).Select("SUM(amount)").Where("transaction_from = ? && status = ?", accountNumber, 1).Scan(&totalAmount)
+	result := db.connection.WithContext(ctx).Model(&entity.Transaction{}).Select("COALESCE(SUM(amount + fee), 0)").Where("transaction_from = ? && status = ?", accountNumber, 1).Scan(&totalAmount)
 	if result.Error != nil {
-		return 0
+		return money.Money{}, result.Error
+	}
+	return money.Rupiah(totalAmount), nil
+}
+
+func (db *userConnection) TotalWalletInByUserID(ctx context.Context, idUser uint64, currency string) (money.Money, error) {
+	var totalAmount int64
+	result := db.connection.WithContext(ctx).Model(&entity.WalletTransfer{}).Select("COALESCE(SUM(credit_amount), 0)").Where("to_user = ? && to_currency = ? && status = ?", idUser, currency, 1).Scan(&totalAmount)
+	if result.Error != nil {
+		return money.Money{}, result.Error
+	}
+	return money.New(totalAmount, currency), nil
+}
+
+func (db *userConnection) TotalWalletOutByUserID(ctx context.Context, idUser uint64, currency string) (money.Money, error) {
+	var totalAmount int64
+	result := db.connection.WithContext(ctx).Model(&entity.WalletTransfer{}).Select("COALESCE(SUM(debit_amount), 0)").Where("id_user = ? && from_currency = ? && status = ?", idUser, currency, 1).Scan(&totalAmount)
+	if result.Error != nil {
+		return money.Money{}, result.Error
 	}
-	return totalAmount
+	return money.New(totalAmount, currency), nil
 }
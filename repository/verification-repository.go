@@ -1,17 +1,18 @@
 package repository
 
 import (
+	"context"
 	"time"
 
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
 	"github.com/go-redis/redis"
-	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type VerificationRepository interface {
-	InsertVerification(email string, verificationKey string) error
-	ValidateVerification(verificationKey string) bool
+	InsertVerification(ctx context.Context, email string, verificationKey string) error
+	ValidateVerification(ctx context.Context, verificationKey string) bool
 }
 
 type redisConnection struct {
@@ -23,42 +24,39 @@ func NewVerificationRepository(db *redis.Client, sqlDB *gorm.DB) VerificationRep
 	return &redisConnection{connection: db, connectionDB: sqlDB}
 }
 
-func (db *redisConnection) InsertVerification(email string, verificationKey string) error {
-	statusCMD := db.connection.Set(verificationKey, email, time.Minute*3)
-	if statusCMD.Err() != nil {
-		logrus.Error(statusCMD.Err())
-		return statusCMD.Err()
-	}
-
-	res, err := statusCMD.Result()
+func (db *redisConnection) InsertVerification(ctx context.Context, email string, verificationKey string) error {
+	err := cacheClient(ctx, db.connection).Set(verificationKey, email, time.Minute*3).Err()
 	if err != nil {
-		logrus.Error(err.Error())
+		return cacheError(ctx, err)
 	}
 
-	logrus.Info("OTP Inserted to Redis ", res)
-
+	logging.FromContext(ctx).Info("Verification code stored")
 	return nil
 }
 
-func (db *redisConnection) ValidateVerification(verificationKey string) bool {
-	email, statusCMD := db.connection.Get(verificationKey).Result()
-	if statusCMD != nil {
-		logrus.Error(statusCMD.Error())
+func (db *redisConnection) ValidateVerification(ctx context.Context, verificationKey string) bool {
+	email, err := cacheClient(ctx, db.connection).Get(verificationKey).Result()
+	if err == redis.Nil {
+		logging.FromContext(ctx).Info("Unknown or expired verification code")
+		return false
+	}
+	if err != nil {
+		cacheError(ctx, err)
 		return false
 	}
 
 	var user entity.User
-	if err := db.connectionDB.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := db.connectionDB.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		return true
 	}
 
 	user.IsVerified = true
 
-	if err := db.connectionDB.Save(&user).Error; err != nil {
+	if err := db.connectionDB.WithContext(ctx).Save(&user).Error; err != nil {
 		return true
 	}
 
-	_, err := db.connection.Del(verificationKey).Result()
+	_, err = cacheClient(ctx, db.connection).Del(verificationKey).Result()
 	if err != nil {
 		return true
 	}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+
+	"gorm.io/gorm"
+)
+
+type WalletRepository interface {
+	FindWalletsByIDUser(ctx context.Context, idUser uint64) ([]entity.Wallet, error)
+	FindWallet(ctx context.Context, idUser uint64, currency string) entity.Wallet
+	InsertWallet(ctx context.Context, wallet entity.Wallet) (entity.Wallet, error)
+	InsertTransfer(ctx context.Context, transfer *entity.WalletTransfer) error
+	FindTransfersByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.WalletTransfer, error)
+	TotalTransfersByIDUser(ctx context.Context, idUser uint64) int64
+}
+
+type walletConnection struct {
+	connection *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) WalletRepository {
+	return &walletConnection{
+		connection: db,
+	}
+}
+
+func (db *walletConnection) FindWalletsByIDUser(ctx context.Context, idUser uint64) ([]entity.Wallet, error) {
+	var wallets []entity.Wallet
+	result := db.connection.WithContext(ctx).Where("id_user = ?", idUser).Order("id").Find(&wallets)
+	return wallets, result.Error
+}
+
+func (db *walletConnection) FindWallet(ctx context.Context, idUser uint64, currency string) entity.Wallet {
+	var wallet entity.Wallet
+	db.connection.WithContext(ctx).Where("id_user = ? && currency = ?", idUser, currency).Take(&wallet)
+	return wallet
+}
+
+func (db *walletConnection) InsertWallet(ctx context.Context, wallet entity.Wallet) (entity.Wallet, error) {
+	wallet.CreatedAt = helper.GetCurrentTimeInLocation()
+	result := db.connection.WithContext(ctx).Create(&wallet)
+	return wallet, result.Error
+}
+
+func (db *walletConnection) InsertTransfer(ctx context.Context, transfer *entity.WalletTransfer) error {
+	transfer.Date = helper.GetCurrentTimeInLocation()
+	return db.connection.WithContext(ctx).Create(transfer).Error
+}
+
+// FindTransfersByIDUser returns the transfers a user sent or received, newest
+// first.
+func (db *walletConnection) FindTransfersByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.WalletTransfer, error) {
+	if page <= 0 || pageSize <= 0 {
+		return nil, apperror.ErrInvalidPagination
+	}
+
+	var transfers []entity.WalletTransfer
+	offset := (page - 1) * pageSize
+
+	result := db.connection.WithContext(ctx).Where("(id_user = ? || to_user = ?) && status = ?", idUser, idUser, 1).Order("id desc").Offset(offset).Limit(pageSize).Find(&transfers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return transfers, nil
+}
+
+func (db *walletConnection) TotalTransfersByIDUser(ctx context.Context, idUser uint64) int64 {
+	var count int64
+	result := db.connection.WithContext(ctx).Model(&entity.WalletTransfer{}).Where("(id_user = ? || to_user = ?) && status = ?", idUser, idUser, 1).Count(&count)
+	if result.Error != nil {
+		return 0
+	}
+	return count
+}
@@ -1,8 +1,9 @@
 package repository
 
 import (
-	"errors"
+	"context"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
 
@@ -10,32 +11,32 @@ import (
 )
 
 type WithdrawalRepository interface {
-	InsertWithdrawal(brg *entity.Withdrawal) entity.Withdrawal
-	All(page int, pageSize int) ([]entity.Withdrawal, error)
-	UpdateWithdrawal(plg entity.Withdrawal) entity.Withdrawal
-	FindWithdrawalByID(id uint64) *entity.Withdrawal
-	FindWithdrawalByIDUser(id uint64, page int, pageSize int) ([]entity.Withdrawal, error)
-	TotalWithdrawal() int64
-	TotalWithdrawalByUserID(idUser uint64) int64
-	UpdateWithdrawalStatus(id uint64, newStatus uint64) error
+	InsertWithdrawal(ctx context.Context, brg *entity.Withdrawal) entity.Withdrawal
+	All(ctx context.Context, page int, pageSize int) ([]entity.Withdrawal, error)
+	UpdateWithdrawal(ctx context.Context, plg entity.Withdrawal) entity.Withdrawal
+	FindWithdrawalByID(ctx context.Context, id uint64) *entity.Withdrawal
+	FindWithdrawalByIDUser(ctx context.Context, id uint64, page int, pageSize int) ([]entity.Withdrawal, error)
+	TotalWithdrawal(ctx context.Context) int64
+	TotalWithdrawalByUserID(ctx context.Context, idUser uint64) int64
+	UpdateWithdrawalStatus(ctx context.Context, id uint64, newStatus uint64) error
 }
 
 type WithdrawalConnection struct {
 	connection *gorm.DB
 }
 
-func (db *WithdrawalConnection) TotalWithdrawal() int64 {
+func (db *WithdrawalConnection) TotalWithdrawal(ctx context.Context) int64 {
 	var count int64
-	result := db.connection.Model(&entity.Withdrawal{}).Where("status = ?", 1).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Withdrawal{}).Where("status = ?", 1).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
 	return count
 }
 
-func (db *WithdrawalConnection) TotalWithdrawalByUserID(idUser uint64) int64 {
+func (db *WithdrawalConnection) TotalWithdrawalByUserID(ctx context.Context, idUser uint64) int64 {
 	var count int64
-	result := db.connection.Model(&entity.Withdrawal{}).Where("id_user = ? && status = ?", idUser, 1).Count(&count)
+	result := db.connection.WithContext(ctx).Model(&entity.Withdrawal{}).Where("id_user = ? && status = ?", idUser, 1).Count(&count)
 	if result.Error != nil {
 		return 0
 	}
@@ -48,21 +49,21 @@ func NewWithdrawalRepository(db *gorm.DB) WithdrawalRepository {
 	}
 }
 
-func (db *WithdrawalConnection) InsertWithdrawal(Withdrawal *entity.Withdrawal) entity.Withdrawal {
+func (db *WithdrawalConnection) InsertWithdrawal(ctx context.Context, Withdrawal *entity.Withdrawal) entity.Withdrawal {
 	Withdrawal.Date = helper.GetCurrentTimeInLocation()
-	db.connection.Save(Withdrawal)
+	db.connection.WithContext(ctx).Save(Withdrawal)
 	return *Withdrawal
 }
 
-func (db *WithdrawalConnection) All(page int, pageSize int) ([]entity.Withdrawal, error) {
+func (db *WithdrawalConnection) All(ctx context.Context, page int, pageSize int) ([]entity.Withdrawal, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
 	var transactions []entity.Withdrawal
 	offset := (page - 1) * pageSize
 
-	result := db.connection.Where("status = ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	result := db.connection.WithContext(ctx).Where("status = ?", 1).Offset(offset).Limit(pageSize).Find(&transactions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -70,15 +71,15 @@ func (db *WithdrawalConnection) All(page int, pageSize int) ([]entity.Withdrawal
 	return transactions, nil
 }
 
-func (db *WithdrawalConnection) FindWithdrawalByIDUser(idUser uint64, page int, pageSize int) ([]entity.Withdrawal, error) {
+func (db *WithdrawalConnection) FindWithdrawalByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.Withdrawal, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
 	var transactions []entity.Withdrawal
 	offset := (page - 1) * pageSize
 
-	result := db.connection.Where("id_user = ? && status = ?", idUser, 1).Offset(offset).Limit(pageSize).Find(&transactions)
+	result := db.connection.WithContext(ctx).Where("id_user = ? && status = ?", idUser, 1).Offset(offset).Limit(pageSize).Find(&transactions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -86,14 +87,14 @@ func (db *WithdrawalConnection) FindWithdrawalByIDUser(idUser uint64, page int,
 	return transactions, nil
 }
 
-func (db *WithdrawalConnection) UpdateWithdrawal(Withdrawal entity.Withdrawal) entity.Withdrawal {
-	db.connection.Save(&Withdrawal)
+func (db *WithdrawalConnection) UpdateWithdrawal(ctx context.Context, Withdrawal entity.Withdrawal) entity.Withdrawal {
+	db.connection.WithContext(ctx).Save(&Withdrawal)
 	return Withdrawal
 }
 
-func (db *WithdrawalConnection) FindWithdrawalByID(id uint64) *entity.Withdrawal {
+func (db *WithdrawalConnection) FindWithdrawalByID(ctx context.Context, id uint64) *entity.Withdrawal {
 	var Withdrawal entity.Withdrawal
-	result := db.connection.Where("id = ? ", id).Take(&Withdrawal)
+	result := db.connection.WithContext(ctx).Where("id = ? ", id).Take(&Withdrawal)
 	if result.Error != nil || result.RowsAffected == 0 {
 		return nil
 	}
@@ -101,16 +102,16 @@ func (db *WithdrawalConnection) FindWithdrawalByID(id uint64) *entity.Withdrawal
 	return &Withdrawal
 }
 
-func (db *WithdrawalConnection) UpdateWithdrawalStatus(id uint64, newStatus uint64) error {
+func (db *WithdrawalConnection) UpdateWithdrawalStatus(ctx context.Context, id uint64, newStatus uint64) error {
 	var trx entity.Withdrawal
-	result := db.connection.First(&trx, id)
+	result := db.connection.WithContext(ctx).First(&trx, id)
 	if result.Error != nil {
 		return result.Error
 	}
 
 	trx.Status = newStatus
 
-	result = db.connection.Save(&trx)
+	result = db.connection.WithContext(ctx).Save(&trx)
 	if result.Error != nil {
 		return result.Error
 	}
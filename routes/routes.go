@@ -1,10 +1,14 @@
 package routes
 
 import (
+	"crypto/subtle"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/controller"
 	"github.com/IrvanWijayaSardam/SelfBank/service"
 
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func RegisterRoutes(e *echo.Echo, jwtService service.JWTService, authController controller.AuthController) {
@@ -15,35 +19,37 @@ func RegisterRoutes(e *echo.Echo, jwtService service.JWTService, authController
 }
 
 func DepositRoutes(e *echo.Echo, depositService service.DepositService,
-	depositController controller.DepositController, jwtMiddleware echo.MiddlewareFunc) {
+	depositController controller.DepositController, jwtMiddleware echo.MiddlewareFunc, activeAccount echo.MiddlewareFunc) {
 	depositRoutes := e.Group("/api/deposit")
 
 	depositRoutes.Use(jwtMiddleware)
-	depositRoutes.POST("/", depositController.Insert)
+	depositRoutes.POST("/", depositController.Insert, activeAccount)
 	depositRoutes.GET("/", depositController.All)
 	depositRoutes.GET("/:id", depositController.FindDepositByID)
 
 }
 
 func WithdrawalRoutes(e *echo.Echo, withdrawalService service.WithdrawalService,
-	withdrawalController controller.WithdrawalController, jwtMiddleware echo.MiddlewareFunc) {
+	withdrawalController controller.WithdrawalController, jwtMiddleware echo.MiddlewareFunc, activeAccount echo.MiddlewareFunc) {
 	withdrawalRoutes := e.Group("/api/withdrawal")
 
 	withdrawalRoutes.Use(jwtMiddleware)
 
-	withdrawalRoutes.POST("/", withdrawalController.Insert)
+	withdrawalRoutes.POST("/", withdrawalController.Insert, activeAccount)
 	withdrawalRoutes.GET("/", withdrawalController.All)
 	withdrawalRoutes.GET("/:id", withdrawalController.FindWithdrawalByID)
 
 }
 
 func TransactionRoutes(e *echo.Echo, transactionService service.TransactionService,
-	transactionController controller.TransactionController, jwtMiddleware echo.MiddlewareFunc) {
+	transactionController controller.TransactionController, jwtMiddleware echo.MiddlewareFunc, activeAccount echo.MiddlewareFunc) {
 	trxRoutes := e.Group("/api/transaction")
 
 	trxRoutes.Use(jwtMiddleware)
 
-	trxRoutes.POST("/", transactionController.Insert)
+	trxRoutes.POST("/", transactionController.Insert, activeAccount)
+	trxRoutes.POST("/inquiry", transactionController.Inquiry, activeAccount)
+	trxRoutes.POST("/confirm", transactionController.Confirm, activeAccount)
 	trxRoutes.GET("/", transactionController.All)
 	trxRoutes.GET("/:id", transactionController.FindTransactionByID)
 }
@@ -52,9 +58,9 @@ func ProfileRoutes(e *echo.Echo, userService service.UserService,
 	userController controller.UserController, jwtMiddleware echo.MiddlewareFunc) {
 	profileRoutes := e.Group("/api/profile")
 
+	profileRoutes.Use(jwtMiddleware)
 	profileRoutes.GET("/", userController.MyProfile)
 	profileRoutes.PUT("/", userController.UpdateProfile)
-	profileRoutes.DELETE("/:id", userController.DeleteUser)
 
 }
 
@@ -62,12 +68,45 @@ func UserRoutes(e *echo.Echo, userService service.UserService,
 	userController controller.UserController, jwtMiddleware echo.MiddlewareFunc) {
 	profileRoutes := e.Group("/api/user")
 
+	profileRoutes.Use(jwtMiddleware)
 	profileRoutes.GET("/", userController.All)
 	profileRoutes.PUT("/", userController.UpdateProfile)
-	profileRoutes.DELETE("/:id", userController.DeleteUser)
 
 }
 
+func AccountRoutes(e *echo.Echo, accountController controller.AccountController, jwtMiddleware echo.MiddlewareFunc) {
+	accountRoutes := e.Group("/api/account")
+
+	accountRoutes.Use(jwtMiddleware)
+
+	accountRoutes.POST("/close", accountController.Close)
+	accountRoutes.POST("/reactivate", accountController.Reactivate)
+}
+
+func AdminRoutes(e *echo.Echo, adminController controller.AdminController, jwtMiddleware echo.MiddlewareFunc) {
+	adminRoutes := e.Group("/api/admin/users")
+
+	adminRoutes.Use(jwtMiddleware)
+
+	adminRoutes.GET("/", adminController.Users)
+	adminRoutes.GET("/:id", adminController.User)
+	adminRoutes.POST("/:id/freeze", adminController.Freeze)
+	adminRoutes.POST("/:id/unfreeze", adminController.Unfreeze)
+	adminRoutes.POST("/:id/reset-credentials", adminController.ResetCredentials)
+	adminRoutes.PUT("/:id/role", adminController.ChangeRole)
+	adminRoutes.DELETE("/:id", adminController.Close)
+	adminRoutes.POST("/:id/reactivate", adminController.Reactivate)
+}
+
+func AuditRoutes(e *echo.Echo, auditController controller.AuditController, jwtMiddleware echo.MiddlewareFunc) {
+	auditRoutes := e.Group("/api/admin/audit")
+
+	auditRoutes.Use(jwtMiddleware)
+
+	auditRoutes.GET("/", auditController.Logs)
+	auditRoutes.GET("/verify", auditController.Verify)
+}
+
 func ImageRoutes(e *echo.Echo, userController controller.UserController, jwtMiddleware echo.MiddlewareFunc) {
 	imageRoutes := e.Group("/api/cdn/images")
 
@@ -75,10 +114,54 @@ func ImageRoutes(e *echo.Echo, userController controller.UserController, jwtMidd
 
 }
 
+func HealthRoutes(e *echo.Echo, healthController controller.HealthController) {
+	e.GET("/healthz", healthController.Live)
+	e.GET("/readyz", healthController.Ready)
+}
+
+// MetricsRoutes exposes the Prometheus metrics. When token is set scrapers
+// must send it as a bearer token.
+func MetricsRoutes(e *echo.Echo, token string) {
+	handler := echo.WrapHandler(promhttp.Handler())
+	e.GET("/metrics", func(c echo.Context) error {
+		if token != "" {
+			expected := []byte("Bearer " + token)
+			if subtle.ConstantTimeCompare([]byte(c.Request().Header.Get("Authorization")), expected) != 1 {
+				return apperror.ErrInvalidToken
+			}
+		}
+		return handler(c)
+	})
+}
+
+func FileRoutes(e *echo.Echo, fileController controller.FileController) {
+	e.GET("/cdn/*", fileController.ServePublic)
+	e.GET("/api/files/*", fileController.ServeSigned)
+}
+
 func ChatbotRoutes(e *echo.Echo, chatbotController controller.ChatbotController, jwtMiddleware echo.MiddlewareFunc) {
 	chatbotRoutes := e.Group("/api/chatbot")
 
+	chatbotRoutes.Use(jwtMiddleware)
 	chatbotRoutes.POST("/", chatbotController.Request)
+	chatbotRoutes.POST("/stream", chatbotController.Stream)
+	chatbotRoutes.GET("/conversations", chatbotController.Conversations)
+	chatbotRoutes.GET("/conversations/:id", chatbotController.Conversation)
+	chatbotRoutes.DELETE("/conversations/:id", chatbotController.DeleteConversation)
+	chatbotRoutes.GET("/usage", chatbotController.Usage)
+}
+
+func FaqRoutes(e *echo.Echo, faqController controller.FaqController, jwtMiddleware echo.MiddlewareFunc) {
+	faqRoutes := e.Group("/api/faq")
+
+	faqRoutes.Use(jwtMiddleware)
+
+	faqRoutes.GET("/", faqController.All)
+	faqRoutes.POST("/", faqController.Insert)
+	faqRoutes.GET("/search", faqController.Search)
+	faqRoutes.GET("/:id", faqController.FindByID)
+	faqRoutes.PUT("/:id", faqController.Update)
+	faqRoutes.DELETE("/:id", faqController.Delete)
 }
 
 func MidtransRoutes(e *echo.Echo, transactionService service.DepositService,
@@ -89,6 +172,41 @@ func MidtransRoutes(e *echo.Echo, transactionService service.DepositService,
 
 }
 
+func FeeLimitRoutes(e *echo.Echo, feeLimitController controller.FeeLimitController, jwtMiddleware echo.MiddlewareFunc) {
+	ruleRoutes := e.Group("/api/rules")
+
+	ruleRoutes.Use(jwtMiddleware)
+
+	ruleRoutes.GET("/", feeLimitController.AllRules)
+	ruleRoutes.POST("/limits", feeLimitController.SaveLimitRule)
+	ruleRoutes.POST("/fees", feeLimitController.SaveFeeRule)
+}
+
+func KycRoutes(e *echo.Echo, kycController controller.KycController, jwtMiddleware echo.MiddlewareFunc) {
+	kycRoutes := e.Group("/api/kyc")
+
+	kycRoutes.Use(jwtMiddleware)
+
+	kycRoutes.POST("/", kycController.Submit)
+	kycRoutes.GET("/", kycController.MySubmission)
+	kycRoutes.GET("/queue", kycController.Queue)
+	kycRoutes.POST("/:id/approve", kycController.Approve)
+	kycRoutes.POST("/:id/reject", kycController.Reject)
+	kycRoutes.GET("/:id/documents/:document", kycController.Document)
+}
+
+func WalletRoutes(e *echo.Echo, walletController controller.WalletController, jwtMiddleware echo.MiddlewareFunc, activeAccount echo.MiddlewareFunc) {
+	walletRoutes := e.Group("/api/wallets")
+
+	walletRoutes.Use(jwtMiddleware)
+
+	walletRoutes.GET("/", walletController.Wallets)
+	walletRoutes.POST("/", walletController.Open, activeAccount)
+	walletRoutes.POST("/transfer", walletController.Transfer, activeAccount)
+	walletRoutes.GET("/transfers", walletController.Transfers)
+	walletRoutes.GET("/rates", walletController.Rate)
+}
+
 func VerificationRoutes(e *echo.Echo, verificatioNService service.VerificationService,
 	verificationController controller.VerificationController, jwtMiddleware echo.MiddlewareFunc) {
 	authRoutes := e.Group("/api/verification")
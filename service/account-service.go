@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AuditActionCloseAccount      = "account.close"
+	AuditActionReactivateAccount = "account.reactivate"
+	AuditActionDormant           = "account.dormant"
+)
+
+const dormancyBatchSize = 100
+
+// AccountService keeps users to the state of their account. Owners close
+// their account or reactivate it once dormant, and accounts without activity
+// are marked dormant.
+type AccountService interface {
+	EnsureActive(ctx context.Context, idUser uint64) error
+	ClosingBalance(ctx context.Context, idUser uint64) (money.Money, error)
+	Close(ctx context.Context, idUser uint64, closure dto.AccountClosureDTO) (*entity.Withdrawal, error)
+	Reactivate(ctx context.Context, idUser uint64) error
+	MarkDormant(ctx context.Context, idleFor time.Duration) (int, error)
+}
+
+type accountService struct {
+	userRepository   repository.UserRepository
+	walletRepository repository.WalletRepository
+	userService      UserService
+}
+
+func NewAccountService(userRep repository.UserRepository, walletRep repository.WalletRepository, userService UserService) AccountService {
+	return &accountService{
+		userRepository:   userRep,
+		walletRepository: walletRep,
+		userService:      userService,
+	}
+}
+
+// StatusError tells why an account in status cannot move money, it is nil
+// for active accounts.
+func StatusError(status uint64) error {
+	switch status {
+	case entity.UserStatusFrozen:
+		return apperror.ErrAccountFrozen
+	case entity.UserStatusDormant:
+		return apperror.ErrAccountDormant
+	case entity.UserStatusClosed:
+		return apperror.ErrAccountClosed
+	}
+	return nil
+}
+
+// EnsureActive looks the state up on every call, a token issued before the
+// account was frozen or closed must not move money.
+func (service *accountService) EnsureActive(ctx context.Context, idUser uint64) error {
+	user, err := service.currentUser(ctx, idUser)
+	if err != nil {
+		return err
+	}
+	return StatusError(user.Status)
+}
+
+// currentUser looks up the signed in user, a token of a user that no longer
+// exists is invalid.
+func (service *accountService) currentUser(ctx context.Context, idUser uint64) (entity.User, error) {
+	user, err := service.userRepository.ProfileUser(ctx, idUser)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.User{}, apperror.ErrInvalidToken
+	}
+	return user, err
+}
+
+// ClosingBalance returns the IDR balance left to pay out when the account is
+// closed. Accounts with pending deposits or money in another currency cannot
+// be closed yet.
+func (service *accountService) ClosingBalance(ctx context.Context, idUser uint64) (money.Money, error) {
+	if service.userRepository.TotalPendingDepositsByUserID(ctx, idUser) > 0 {
+		return money.Money{}, apperror.ErrPendingDeposits
+	}
+
+	wallets, err := service.walletRepository.FindWalletsByIDUser(ctx, idUser)
+	if err != nil {
+		return money.Money{}, err
+	}
+	for _, wallet := range wallets {
+		if wallet.Currency == money.IDR {
+			continue
+		}
+		balance, err := service.userService.GetBalance(ctx, idUser, wallet.Currency)
+		if err != nil {
+			return money.Money{}, err
+		}
+		if !balance.IsZero() {
+			return money.Money{}, apperror.ErrWalletNotEmpty.With(apperror.Params{"currency": wallet.Currency})
+		}
+	}
+	return service.userService.GetSaldo(ctx, idUser)
+}
+
+// Close closes the caller's account once they confirmed it with their
+// password. A remaining balance is paid out to closure.PayoutTo by a
+// withdrawal saved with the closure, free of fees and limits.
+func (service *accountService) Close(ctx context.Context, idUser uint64, closure dto.AccountClosureDTO) (*entity.Withdrawal, error) {
+	user, err := service.userRepository.ProfileUser(ctx, idUser)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !comparePassword(user.Password, []byte(closure.Password)) {
+		return nil, apperror.ErrInvalidCredentials
+	}
+	switch user.Status {
+	case entity.UserStatusClosed, entity.UserStatusFrozen:
+		return nil, StatusError(user.Status)
+	}
+
+	balance, err := service.ClosingBalance(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+
+	var payout *entity.Withdrawal
+	if !balance.IsZero() {
+		payoutTo := strings.TrimSpace(closure.PayoutTo)
+		if !balance.IsPositive() || payoutTo == "" {
+			return nil, apperror.ErrBalanceNotZero.With(apperror.Params{"balance": balance.Format()})
+		}
+		payout = &entity.Withdrawal{
+			ID_User: user.ID,
+			Amount:  balance,
+			Fee:     money.Rupiah(0),
+			To:      payoutTo,
+			Status:  1,
+		}
+	}
+
+	user.Status = entity.UserStatusClosed
+	user.ClosedAt = helper.GetCurrentTimeInLocation()
+	if err := service.userRepository.CloseAccount(audit.WithAction(ctx, AuditActionCloseAccount, closure.Reason), user, payout); err != nil {
+		return nil, err
+	}
+	if payout != nil {
+		recordTransfer(metrics.KindWithdrawal, payout.Amount)
+	}
+	return payout, nil
+}
+
+// Reactivate lets the owner of a dormant account use it again. Frozen and
+// closed accounts can only be reopened by an admin.
+func (service *accountService) Reactivate(ctx context.Context, idUser uint64) error {
+	user, err := service.currentUser(ctx, idUser)
+	if err != nil {
+		return err
+	}
+	if user.Status != entity.UserStatusDormant {
+		return apperror.ErrAccountNotDormant
+	}
+
+	reactivated, err := service.userRepository.UpdateUserIf(audit.WithAction(ctx, AuditActionReactivateAccount, ""), user.ID,
+		map[string]interface{}{"status": entity.UserStatusDormant},
+		map[string]interface{}{"status": entity.UserStatusActive, "active_since": helper.GetCurrentTimeInLocation()})
+	if err != nil {
+		return err
+	}
+	if !reactivated {
+		return apperror.ErrAccountNotDormant
+	}
+	return nil
+}
+
+// MarkDormant marks the customers without deposits, withdrawals or transfers
+// for idleFor as dormant and returns how many were. Customers frozen or
+// reactivated after they were picked are left as they are.
+func (service *accountService) MarkDormant(ctx context.Context, idleFor time.Duration) (int, error) {
+	idleSince := time.Now().Add(-idleFor)
+	ctx = audit.WithAction(ctx, AuditActionDormant, "No activity since "+idleSince.Format("2006-01-02"))
+
+	marked := 0
+	for {
+		users, err := service.userRepository.DormancyCandidates(ctx, idleSince.Unix(), dormancyBatchSize)
+		if err != nil {
+			return marked, err
+		}
+		for _, user := range users {
+			dormant, err := service.userRepository.MarkDormant(ctx, user.ID, idleSince.Unix())
+			if err != nil {
+				return marked, err
+			}
+			if dormant {
+				marked++
+			}
+		}
+		if len(users) < dormancyBatchSize {
+			return marked, nil
+		}
+	}
+}
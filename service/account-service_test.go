@@ -0,0 +1,29 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	repomocks "github.com/IrvanWijayaSardam/SelfBank/repository/mocks"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+func TestAccountService_MarkDormant(t *testing.T) {
+	userRepository := repomocks.NewUserRepository(t)
+	userRepository.On("DormancyCandidates", mock.Anything, mock.Anything, mock.Anything).
+		Return([]entity.User{{ID: 7, Status: entity.UserStatusActive}, {ID: 8, Status: entity.UserStatusActive}}, nil)
+	userRepository.On("MarkDormant", mock.Anything, uint64(7), mock.Anything).Return(true, nil)
+	// User 8 was frozen after it was picked, the update matches no row.
+	userRepository.On("MarkDormant", mock.Anything, uint64(8), mock.Anything).Return(false, nil)
+
+	marked, err := service.NewAccountService(userRepository, nil, nil).MarkDormant(context.Background(), 180*24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, marked)
+	userRepository.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything)
+}
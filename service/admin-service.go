@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AuditActionFreeze           = "user.freeze"
+	AuditActionUnfreeze         = "user.unfreeze"
+	AuditActionResetCredentials = "user.reset_credentials"
+	AuditActionChangeRole       = "user.change_role"
+	AuditActionClose            = "user.close"
+	AuditActionReactivate       = "user.reactivate"
+)
+
+// AdminService is the back office for user accounts. Every change is
+// audited under its own action with the reason the admin gave.
+type AdminService interface {
+	SearchUsers(ctx context.Context, query string, status uint64, page int, pageSize int) ([]entity.User, error)
+	TotalSearchUsers(ctx context.Context, query string, status uint64) int64
+	FindUser(ctx context.Context, id uint64) (entity.User, error)
+	Freeze(ctx context.Context, id uint64, reason string) error
+	Unfreeze(ctx context.Context, id uint64, reason string) error
+	ResetCredentials(ctx context.Context, id uint64, reason string) (string, error)
+	ChangeRole(ctx context.Context, id uint64, idRole uint64, reason string) error
+	Close(ctx context.Context, id uint64, reason string) error
+	Reactivate(ctx context.Context, id uint64, reason string) error
+}
+
+type adminService struct {
+	userRepository repository.UserRepository
+	accountService AccountService
+}
+
+func NewAdminService(userRep repository.UserRepository, accountService AccountService) AdminService {
+	return &adminService{
+		userRepository: userRep,
+		accountService: accountService,
+	}
+}
+
+func (service *adminService) SearchUsers(ctx context.Context, query string, status uint64, page int, pageSize int) ([]entity.User, error) {
+	return service.userRepository.SearchUsers(ctx, strings.TrimSpace(query), status, page, pageSize)
+}
+
+func (service *adminService) TotalSearchUsers(ctx context.Context, query string, status uint64) int64 {
+	return service.userRepository.TotalSearchUsers(ctx, strings.TrimSpace(query), status)
+}
+
+func (service *adminService) FindUser(ctx context.Context, id uint64) (entity.User, error) {
+	user, err := service.userRepository.ProfileUser(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.User{}, apperror.ErrUserNotFound
+	}
+	if err != nil {
+		return entity.User{}, apperror.Internal(err)
+	}
+	return user, nil
+}
+
+func (service *adminService) Freeze(ctx context.Context, id uint64, reason string) error {
+	user, err := service.otherUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	switch user.Status {
+	case entity.UserStatusFrozen:
+		return apperror.ErrUserAlreadyFrozen
+	case entity.UserStatusClosed:
+		return apperror.ErrUserClosed
+	}
+	return service.changeStatus(ctx, user, entity.UserStatusFrozen, AuditActionFreeze, reason)
+}
+
+func (service *adminService) Unfreeze(ctx context.Context, id uint64, reason string) error {
+	user, err := service.FindUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.Status != entity.UserStatusFrozen {
+		return apperror.ErrUserNotFrozen
+	}
+	return service.changeStatus(ctx, user, entity.UserStatusActive, AuditActionUnfreeze, reason)
+}
+
+// ResetCredentials replaces the user's password with a temporary one, which
+// is returned so the admin can hand it over. It is never stored in clear.
+func (service *adminService) ResetCredentials(ctx context.Context, id uint64, reason string) (string, error) {
+	user, err := service.FindUser(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if user.Status == entity.UserStatusClosed {
+		return "", apperror.ErrUserClosed
+	}
+
+	password, err := helper.GenerateRandomPassword()
+	if err != nil {
+		return "", err
+	}
+	err = service.updateUser(audit.WithAction(ctx, AuditActionResetCredentials, reason), user.ID,
+		map[string]interface{}{"status": user.Status},
+		map[string]interface{}{"password": helper.HashAndSalt([]byte(password))})
+	if err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+func (service *adminService) ChangeRole(ctx context.Context, id uint64, idRole uint64, reason string) error {
+	user, err := service.otherUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if service.userRepository.FindRole(ctx, idRole).ID == 0 {
+		return apperror.ErrRoleNotFound
+	}
+	if user.IdRole == idRole {
+		return nil
+	}
+
+	return service.updateUser(audit.WithAction(ctx, AuditActionChangeRole, reason), user.ID,
+		map[string]interface{}{"id_role": user.IdRole},
+		map[string]interface{}{"id_role": idRole})
+}
+
+// Close closes an account whose balance was already paid out, the owner
+// closes it themselves when money is left.
+func (service *adminService) Close(ctx context.Context, id uint64, reason string) error {
+	user, err := service.otherUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.Status == entity.UserStatusClosed {
+		return apperror.ErrUserClosed
+	}
+	balance, err := service.accountService.ClosingBalance(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !balance.IsZero() {
+		return apperror.ErrBalanceNotZero.With(apperror.Params{"balance": balance.Format()})
+	}
+	return service.changeStatus(ctx, user, entity.UserStatusClosed, AuditActionClose, reason)
+}
+
+func (service *adminService) Reactivate(ctx context.Context, id uint64, reason string) error {
+	user, err := service.FindUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.Status != entity.UserStatusClosed && user.Status != entity.UserStatusDormant {
+		return apperror.ErrUserNotClosed
+	}
+	return service.changeStatus(ctx, user, entity.UserStatusActive, AuditActionReactivate, reason)
+}
+
+// otherUser finds a user other than the acting admin, admins cannot lock
+// themselves out or take their own rights away.
+func (service *adminService) otherUser(ctx context.Context, id uint64) (entity.User, error) {
+	if audit.ActorFromContext(ctx).ID == id {
+		return entity.User{}, apperror.ErrOwnAccount
+	}
+	return service.FindUser(ctx, id)
+}
+
+// changeStatus starts the idle time of reopened accounts over, so the
+// dormancy job does not mark them dormant again right away.
+func (service *adminService) changeStatus(ctx context.Context, user entity.User, status uint64, action string, reason string) error {
+	columns := map[string]interface{}{"status": status}
+	switch status {
+	case entity.UserStatusActive:
+		columns["active_since"] = helper.GetCurrentTimeInLocation()
+		columns["closed_at"] = 0
+	case entity.UserStatusClosed:
+		columns["closed_at"] = helper.GetCurrentTimeInLocation()
+	}
+	return service.updateUser(audit.WithAction(ctx, action, reason), user.ID, map[string]interface{}{"status": user.Status}, columns)
+}
+
+// updateUser writes columns only while the user still holds the expected
+// values the admin's decision was based on. A user changed in the meantime,
+// by another admin for instance, is reported as a conflict.
+func (service *adminService) updateUser(ctx context.Context, id uint64, expected map[string]interface{}, columns map[string]interface{}) error {
+	updated, err := service.userRepository.UpdateUserIf(ctx, id, expected, columns)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return apperror.ErrUserChanged
+	}
+	return nil
+}
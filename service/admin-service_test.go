@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	repomocks "github.com/IrvanWijayaSardam/SelfBank/repository/mocks"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+func TestAdminService_Freeze(t *testing.T) {
+	tests := []struct {
+		name    string
+		updated bool
+		wantErr error
+	}{
+		{
+			name:    "Frozen",
+			updated: true,
+		},
+		{
+			name:    "Changed Meanwhile",
+			updated: false,
+			wantErr: apperror.ErrUserChanged,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userRepository := repomocks.NewUserRepository(t)
+			userRepository.On("ProfileUser", mock.Anything, uint64(7)).Return(entity.User{ID: 7, Status: entity.UserStatusActive}, nil)
+			userRepository.On("UpdateUserIf", mock.Anything, uint64(7),
+				map[string]interface{}{"status": entity.UserStatusActive},
+				map[string]interface{}{"status": entity.UserStatusFrozen},
+			).Return(test.updated, nil)
+
+			err := service.NewAdminService(userRepository, nil).Freeze(context.Background(), 7, "suspicious activity")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAdminService_ChangeRole(t *testing.T) {
+	userRepository := repomocks.NewUserRepository(t)
+	userRepository.On("ProfileUser", mock.Anything, uint64(7)).Return(entity.User{ID: 7, IdRole: entity.RoleUser}, nil)
+	userRepository.On("FindRole", mock.Anything, entity.RoleAdmin).Return(entity.Role{ID: entity.RoleAdmin})
+	userRepository.On("UpdateUserIf", mock.Anything, uint64(7),
+		map[string]interface{}{"id_role": entity.RoleUser},
+		map[string]interface{}{"id_role": entity.RoleAdmin},
+	).Return(false, nil)
+
+	err := service.NewAdminService(userRepository, nil).ChangeRole(context.Background(), 7, entity.RoleAdmin, "promoted")
+
+	assert.ErrorIs(t, err, apperror.ErrUserChanged)
+}
+
+func TestAdminService_FindUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		queryErr error
+		wantErr  error
+	}{
+		{
+			name:     "Missing User",
+			queryErr: gorm.ErrRecordNotFound,
+			wantErr:  apperror.ErrUserNotFound,
+		},
+		{
+			name:     "Failed Query",
+			queryErr: errors.New("connection reset"),
+			wantErr:  apperror.ErrInternal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userRepository := repomocks.NewUserRepository(t)
+			userRepository.On("ProfileUser", mock.Anything, uint64(7)).Return(entity.User{}, test.queryErr)
+
+			_, err := service.NewAdminService(userRepository, nil).FindUser(context.Background(), 7)
+
+			assert.ErrorIs(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+)
+
+// auditVerifyBatch is how many logs are read at a time while verifying.
+const auditVerifyBatch = 500
+
+type AuditService interface {
+	Logs(ctx context.Context, filter entity.AuditFilter, page int, pageSize int) ([]entity.AuditLog, error)
+	TotalLogs(ctx context.Context, filter entity.AuditFilter) int64
+	Verify(ctx context.Context) (dto.AuditVerification, error)
+}
+
+type auditService struct {
+	auditRepository repository.AuditRepository
+}
+
+func NewAuditService(auditRep repository.AuditRepository) AuditService {
+	return &auditService{
+		auditRepository: auditRep,
+	}
+}
+
+func (service *auditService) Logs(ctx context.Context, filter entity.AuditFilter, page int, pageSize int) ([]entity.AuditLog, error) {
+	return service.auditRepository.FindLogs(ctx, filter, page, pageSize)
+}
+
+func (service *auditService) TotalLogs(ctx context.Context, filter entity.AuditFilter) int64 {
+	return service.auditRepository.TotalLogs(ctx, filter)
+}
+
+// Verify walks the chain from the first log. Each log must link to the one
+// before it and match its hash, and the head must be found along the way,
+// otherwise the latest logs were removed. Logs appended while verifying are
+// checked too.
+func (service *auditService) Verify(ctx context.Context) (dto.AuditVerification, error) {
+	head, err := service.auditRepository.Head(ctx)
+	if err != nil {
+		return dto.AuditVerification{}, err
+	}
+
+	verification := dto.AuditVerification{Valid: true}
+	previous := ""
+	headFound := head.Hash == ""
+	var lastID uint64
+	for {
+		logs, err := service.auditRepository.LogsAfter(ctx, lastID, auditVerifyBatch)
+		if err != nil {
+			return dto.AuditVerification{}, err
+		}
+
+		for _, log := range logs {
+			switch {
+			case log.PrevHash != previous:
+				return brokenChain(verification, log.ID, "The log does not follow the one before it, a log was removed or reordered"), nil
+			case audit.Hash(log) != log.Hash:
+				return brokenChain(verification, log.ID, "The log was altered"), nil
+			}
+			verification.Checked++
+			previous = log.Hash
+			lastID = log.ID
+			if log.Hash == head.Hash {
+				headFound = true
+			}
+		}
+
+		if len(logs) < auditVerifyBatch {
+			break
+		}
+	}
+
+	if !headFound {
+		return brokenChain(verification, lastID, fmt.Sprintf("The latest logs were removed, the chain ends before the head %s", head.Hash)), nil
+	}
+	return verification, nil
+}
+
+func brokenChain(verification dto.AuditVerification, id uint64, problem string) dto.AuditVerification {
+	verification.Valid = false
+	verification.BrokenAt = id
+	verification.Problem = problem
+	return verification
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/IrvanWijayaSardam/SelfBank/audit"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	repomocks "github.com/IrvanWijayaSardam/SelfBank/repository/mocks"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+// auditChain builds count chained logs the way audit.Append stores them.
+func auditChain(count int) []entity.AuditLog {
+	logs := make([]entity.AuditLog, count)
+	previous := ""
+	for i := range logs {
+		logs[i] = entity.AuditLog{
+			ID:        uint64(i + 1),
+			ActorID:   1,
+			Action:    audit.ActionUpdate,
+			Entity:    "users",
+			EntityID:  fmt.Sprint(i + 1),
+			After:     `{"status":1}`,
+			CreatedAt: int64(1700000000 + i),
+			PrevHash:  previous,
+		}
+		logs[i].Hash = audit.Hash(logs[i])
+		previous = logs[i].Hash
+	}
+	return logs
+}
+
+func TestAuditService_Verify(t *testing.T) {
+	tests := []struct {
+		name string
+		// tamper changes the stored logs, it returns them with the head hash.
+		tamper       func(logs []entity.AuditLog) ([]entity.AuditLog, string)
+		wantValid    bool
+		wantChecked  int
+		wantBrokenAt uint64
+	}{
+		{
+			name: "Intact Chain",
+			tamper: func(logs []entity.AuditLog) ([]entity.AuditLog, string) {
+				return logs, logs[len(logs)-1].Hash
+			},
+			wantValid:   true,
+			wantChecked: 5,
+		},
+		{
+			name: "Empty Log",
+			tamper: func(logs []entity.AuditLog) ([]entity.AuditLog, string) {
+				return nil, ""
+			},
+			wantValid: true,
+		},
+		{
+			name: "Altered Log",
+			tamper: func(logs []entity.AuditLog) ([]entity.AuditLog, string) {
+				logs[2].After = `{"status":3}`
+				return logs, logs[len(logs)-1].Hash
+			},
+			wantChecked:  2,
+			wantBrokenAt: 3,
+		},
+		{
+			name: "Altered Log With Its Hash Recomputed",
+			tamper: func(logs []entity.AuditLog) ([]entity.AuditLog, string) {
+				logs[2].After = `{"status":3}`
+				logs[2].Hash = audit.Hash(logs[2])
+				return logs, logs[len(logs)-1].Hash
+			},
+			wantChecked:  3,
+			wantBrokenAt: 4,
+		},
+		{
+			name: "Removed Middle Log",
+			tamper: func(logs []entity.AuditLog) ([]entity.AuditLog, string) {
+				head := logs[len(logs)-1].Hash
+				return append(logs[:2], logs[3:]...), head
+			},
+			wantChecked:  2,
+			wantBrokenAt: 4,
+		},
+		{
+			name: "Removed Tail",
+			tamper: func(logs []entity.AuditLog) ([]entity.AuditLog, string) {
+				return logs[:3], logs[len(logs)-1].Hash
+			},
+			wantChecked:  3,
+			wantBrokenAt: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditRepository := repomocks.NewAuditRepository(t)
+			auditService := service.NewAuditService(auditRepository)
+
+			logs, head := test.tamper(auditChain(5))
+			auditRepository.On("Head", mock.Anything).Return(entity.AuditHead{ID: audit.HeadID, Hash: head}, nil)
+			auditRepository.On("LogsAfter", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, id uint64, limit int) ([]entity.AuditLog, error) {
+				var after []entity.AuditLog
+				for _, log := range logs {
+					if log.ID > id && len(after) < limit {
+						after = append(after, log)
+					}
+				}
+				return after, nil
+			})
+
+			verification, err := auditService.Verify(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantValid, verification.Valid)
+			assert.Equal(t, test.wantChecked, verification.Checked)
+			assert.Equal(t, test.wantBrokenAt, verification.BrokenAt)
+			if !test.wantValid {
+				assert.NotEmpty(t, verification.Problem)
+			}
+		})
+	}
+}
@@ -1,21 +1,21 @@
 package service
 
 import (
-	"log"
-
+	"context"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/repository"
 
 	"github.com/mashingan/smapping"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService interface {
-	VerifyCredential(email string, password string) interface{}
-	CreateUser(user dto.RegisterDTO) entity.User
-	FindByEmail(email string) entity.User
-	IsDuplicateEmail(email string) bool
+	VerifyCredential(ctx context.Context, email string, password string) interface{}
+	CreateUser(ctx context.Context, user dto.RegisterDTO) (entity.User, error)
+	FindByEmail(ctx context.Context, email string) entity.User
+	IsDuplicateEmail(ctx context.Context, email string) bool
 }
 
 type authService struct {
@@ -28,8 +28,8 @@ func NewAuthService(userRep repository.UserRepository) AuthService {
 	}
 }
 
-func (service *authService) VerifyCredential(email string, password string) interface{} {
-	res := service.userRepository.VerifyCredential(email, password)
+func (service *authService) VerifyCredential(ctx context.Context, email string, password string) interface{} {
+	res := service.userRepository.VerifyCredential(ctx, email, password)
 	if v, ok := res.(entity.User); ok {
 		comparedPassword := comparePassword(v.Password, []byte(password))
 		if v.Email == email && comparedPassword {
@@ -40,22 +40,21 @@ func (service *authService) VerifyCredential(email string, password string) inte
 	return res
 }
 
-func (service *authService) CreateUser(user dto.RegisterDTO) entity.User {
+func (service *authService) CreateUser(ctx context.Context, user dto.RegisterDTO) (entity.User, error) {
 	userToCreate := entity.User{}
 	err := smapping.FillStruct(&userToCreate, smapping.MapFields(&user))
 	if err != nil {
-		log.Fatalf("Failed map %v", err)
+		logrus.Fatalf("Failed map %v", err)
 	}
-	res := service.userRepository.InsertUser(userToCreate)
-	return res
+	return service.userRepository.InsertUser(ctx, userToCreate)
 }
 
-func (service *authService) FindByEmail(email string) entity.User {
-	return service.userRepository.FindByEmail(email)
+func (service *authService) FindByEmail(ctx context.Context, email string) entity.User {
+	return service.userRepository.FindByEmail(ctx, email)
 }
 
-func (service *authService) IsDuplicateEmail(email string) bool {
-	res := service.userRepository.IsDuplicateEmail(email)
+func (service *authService) IsDuplicateEmail(ctx context.Context, email string) bool {
+	res := service.userRepository.IsDuplicateEmail(ctx, email)
 	return !(res.Error == nil)
 }
 
@@ -63,7 +62,7 @@ func comparePassword(hashedPwd string, plainPassword []byte) bool {
 	byteHash := []byte(hashedPwd)
 	err := bcrypt.CompareHashAndPassword(byteHash, plainPassword)
 	if err != nil {
-		log.Println(err)
+		logrus.Debug("Password mismatch: ", err)
 		return false
 	}
 	return true
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/jsonschema"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/llm"
+)
+
+const (
+	chatbotDefaultLimit = 5
+	chatbotMaxLimit     = 10
+)
+
+// chatbotTool is a function the model may call. run always receives the
+// signed in user, the model only picks the arguments. Results go to the
+// provider, so account numbers are masked and free text is redacted.
+type chatbotTool struct {
+	definition openai.FunctionDefinition
+	run        func(ctx context.Context, user entity.User, arguments json.RawMessage) (interface{}, error)
+}
+
+type chatbotLimitArguments struct {
+	Limit int `json:"limit"`
+}
+
+type chatbotDepositArguments struct {
+	Method string `json:"method"`
+}
+
+var chatbotLimitParameters = jsonschema.Definition{
+	Type: jsonschema.Object,
+	Properties: map[string]jsonschema.Definition{
+		"limit": {Type: jsonschema.Integer, Description: "How many to return, 1 to 10. Defaults to 5."},
+	},
+}
+
+// depositMethods describes how to pay for a deposit with each payment type
+// the deposit endpoint accepts.
+var depositMethods = map[string]string{
+	"bca":   "Choose BCA virtual account (payment type 6) when creating the deposit, then pay the virtual account number shown through BCA mobile, KlikBCA or an ATM.",
+	"bri":   "Choose BRI virtual account (payment type 7) when creating the deposit, then pay the virtual account number shown through BRImo or an ATM.",
+	"bni":   "Choose BNI virtual account (payment type 8) when creating the deposit, then pay the virtual account number shown through BNI Mobile Banking or an ATM.",
+	"gopay": "Choose GoPay (payment type 10) when creating the deposit, the app opens GoPay to confirm the payment.",
+}
+
+// readOnlyTools lists everything the chatbot can do. None of them writes
+// anything, tools that move money must never be added here.
+func (service *chatbotService) readOnlyTools() map[string]chatbotTool {
+	tools := []chatbotTool{
+		{
+			definition: openai.FunctionDefinition{
+				Name:        "get_balance",
+				Description: "Get the user's current IDR balance and account number, masked to its last four digits.",
+				Parameters:  jsonschema.Definition{Type: jsonschema.Object},
+			},
+			run: service.balanceTool,
+		},
+		{
+			definition: openai.FunctionDefinition{
+				Name:        "list_transfers",
+				Description: "List the user's latest transfers, sent and received, newest first.",
+				Parameters:  chatbotLimitParameters,
+			},
+			run: service.transfersTool,
+		},
+		{
+			definition: openai.FunctionDefinition{
+				Name:        "list_deposits",
+				Description: "List the user's latest deposits with their status, newest first.",
+				Parameters:  chatbotLimitParameters,
+			},
+			run: service.depositsTool,
+		},
+		{
+			definition: openai.FunctionDefinition{
+				Name:        "deposit_instructions",
+				Description: "Explain how to top up the account with a payment method.",
+				Parameters: jsonschema.Definition{
+					Type: jsonschema.Object,
+					Properties: map[string]jsonschema.Definition{
+						"method": {Type: jsonschema.String, Enum: []string{"bca", "bri", "bni", "gopay"}},
+					},
+					Required: []string{"method"},
+				},
+			},
+			run: depositInstructionsTool,
+		},
+	}
+
+	byName := make(map[string]chatbotTool, len(tools))
+	for _, tool := range tools {
+		byName[tool.definition.Name] = tool
+	}
+	return byName
+}
+
+func (service *chatbotService) balanceTool(ctx context.Context, user entity.User, arguments json.RawMessage) (interface{}, error) {
+	balance, err := service.UserService.GetSaldo(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"account_number": helper.MaskAccountNumber(user.AccountNumber),
+		"balance":        balance,
+	}, nil
+}
+
+func (service *chatbotService) transfersTool(ctx context.Context, user entity.User, arguments json.RawMessage) (interface{}, error) {
+	transactions, err := service.TransactionService.RecentTransactions(ctx, user.ID, user.AccountNumber, chatbotLimit(arguments))
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]map[string]interface{}, 0, len(transactions))
+	for _, transaction := range transactions {
+		direction := "sent"
+		counterpart := transaction.TransactionTo
+		if transaction.TransactionTo == user.AccountNumber {
+			direction = "received"
+			counterpart = transaction.TransactionFrom
+		}
+		transfers = append(transfers, map[string]interface{}{
+			"direction":      direction,
+			"account_number": helper.MaskAccountNumber(counterpart),
+			"amount":         transaction.Amount,
+			"note":           llm.Redact(transaction.Note),
+			"category":       transaction.Category,
+			"date":           helper.ConvertUnixtime(transaction.Date).Format("2006-01-02 15:04"),
+		})
+	}
+	return transfers, nil
+}
+
+func (service *chatbotService) depositsTool(ctx context.Context, user entity.User, arguments json.RawMessage) (interface{}, error) {
+	deposits, err := service.DepositService.RecentDeposits(ctx, user.ID, chatbotLimit(arguments))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]interface{}, 0, len(deposits))
+	for _, deposit := range deposits {
+		results = append(results, map[string]interface{}{
+			"id":     deposit.ID,
+			"amount": deposit.Amount,
+			"fee":    deposit.Fee,
+			"status": depositStatusName(deposit.Status),
+			"date":   helper.ConvertUnixtime(deposit.Date).Format("2006-01-02 15:04"),
+		})
+	}
+	return results, nil
+}
+
+func depositInstructionsTool(ctx context.Context, user entity.User, arguments json.RawMessage) (interface{}, error) {
+	var args chatbotDepositArguments
+	json.Unmarshal(arguments, &args)
+
+	instructions, ok := depositMethods[strings.ToLower(args.Method)]
+	if !ok {
+		return map[string]string{"error": "Supported methods are BCA, BRI and BNI virtual accounts and GoPay"}, nil
+	}
+	return map[string]string{
+		"instructions": instructions,
+		"note":         "A deposit fee may apply, it is shown before the payment is made.",
+	}, nil
+}
+
+// chatbotLimit reads the limit argument, keeping it within what a chat
+// answer can reasonably show.
+func chatbotLimit(arguments json.RawMessage) int {
+	var args chatbotLimitArguments
+	json.Unmarshal(arguments, &args)
+	if args.Limit <= 0 {
+		return chatbotDefaultLimit
+	}
+	if args.Limit > chatbotMaxLimit {
+		return chatbotMaxLimit
+	}
+	return args.Limit
+}
+
+func depositStatusName(status uint64) string {
+	switch status {
+	case 2:
+		return "Pending"
+	case 3:
+		return "Cancelled"
+	case 4:
+		return "Denied"
+	case 5:
+		return "Paid"
+	}
+	return "Created"
+}
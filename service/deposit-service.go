@@ -2,69 +2,75 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"log"
 	"mime/multipart"
-	"os"
-	"path/filepath"
 	"strconv"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
 	"github.com/IrvanWijayaSardam/SelfBank/repository"
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
 	"github.com/jung-kurt/gofpdf"
 	"github.com/midtrans/midtrans-go"
 
-	"github.com/google/uuid"
 	"github.com/mashingan/smapping"
+	"github.com/sirupsen/logrus"
 )
 
 type DepositService interface {
-	InsertDeposit(Deposit dto.DepositDTO) entity.Deposit
-	All(page int, pageSize int) ([]entity.Deposit, error)
-	FindDepositByIDUser(idUser uint64, int, pageSize int) ([]entity.Deposit, error)
-	FindDepositByID(id string) entity.Deposit
-	SaveFile(file *multipart.FileHeader) (string, error)
-	TotalDeposit() int64
-	TotalDepositByUserID(idUser uint64) int64
-	InsertPaymentToken(transactionID string, paymentToken string, virtualAcc string, callbackUrl string) error
-	UpdateDepositStatus(orderID string, newStatus uint64) error
-	FindPaymentInfoById(depositId string) *entity.PaymentToken
+	InsertDeposit(ctx context.Context, Deposit dto.DepositDTO) entity.Deposit
+	All(ctx context.Context, page int, pageSize int) ([]entity.Deposit, error)
+	FindDepositByIDUser(ctx context.Context, idUser uint64, int, pageSize int) ([]entity.Deposit, error)
+	RecentDeposits(ctx context.Context, idUser uint64, limit int) ([]entity.Deposit, error)
+	FindDepositByID(ctx context.Context, id string) entity.Deposit
+	SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error)
+	TotalDeposit(ctx context.Context) int64
+	TotalDepositByUserID(ctx context.Context, idUser uint64) int64
+	InsertPaymentToken(ctx context.Context, transactionID string, paymentToken string, virtualAcc string, callbackUrl string) error
+	UpdateDepositStatus(ctx context.Context, orderID string, newStatus uint64) error
+	FindPaymentInfoById(ctx context.Context, depositId string) *entity.PaymentToken
 	GenerateDepositPDF(deposits []dto.DepositResponse) (*bytes.Buffer, error)
-	SearchByDateAll(dateStart int64, dateEnd int64) ([]entity.Deposit, error)
-	SearchByDateIDUser(idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error)
-	TotalDepositByDate(dateStart int64, dateEnd int64) int64
-	TotalDepositByDateIdUser(idUser uint64, dateStart int64, dateEnd int64) int64
+	SearchByDateAll(ctx context.Context, dateStart int64, dateEnd int64) ([]entity.Deposit, error)
+	SearchByDateIDUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error)
+	TotalDepositByDate(ctx context.Context, dateStart int64, dateEnd int64) int64
+	TotalDepositByDateIdUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) int64
 }
 
 type depositService struct {
 	DepositRepository repository.DepositRepository
+	FeeLimitService   FeeLimitService
+	BlobStore         storage.BlobStore
 }
 
-func NewDepositService(fundRep repository.DepositRepository) DepositService {
+func NewDepositService(fundRep repository.DepositRepository, feeLimitService FeeLimitService, blobStore storage.BlobStore) DepositService {
 	return &depositService{
 		DepositRepository: fundRep,
+		FeeLimitService:   feeLimitService,
+		BlobStore:         blobStore,
 	}
 }
 
-func (service *depositService) InsertDeposit(b dto.DepositDTO) entity.Deposit {
+func (service *depositService) InsertDeposit(ctx context.Context, b dto.DepositDTO) entity.Deposit {
 	Deposit := entity.Deposit{}
 	err := smapping.FillStruct(&Deposit, smapping.MapFields(&b))
 	if err != nil {
-		log.Fatalf("Failed map %v", err)
+		logrus.Fatalf("Failed map %v", err)
 	}
 	numInt := int(helper.GenerateTrxId())
 	Deposit.ID = strconv.Itoa(numInt)
 	Deposit.Date = helper.GetCurrentTimeInLocation()
-	res := service.DepositRepository.InsertDeposit(&Deposit)
+	res := service.DepositRepository.InsertDeposit(ctx, &Deposit)
+	metrics.DepositsCreated.WithLabelValues(b.PaymentType).Inc()
 	return res
 }
 
-func (service *depositService) InsertPaymentToken(transactionID string, paymentToken string, virtualAcc string, callbackUrl string) error {
-	err := service.DepositRepository.StorePaymentToken(transactionID, paymentToken, virtualAcc, callbackUrl)
+func (service *depositService) InsertPaymentToken(ctx context.Context, transactionID string, paymentToken string, virtualAcc string, callbackUrl string) error {
+	err := service.DepositRepository.StorePaymentToken(ctx, transactionID, paymentToken, virtualAcc, callbackUrl)
 	if err != nil {
 		if midErr, ok := err.(*midtrans.Error); ok {
 			return errors.New(midErr.Message)
@@ -74,101 +80,84 @@ func (service *depositService) InsertPaymentToken(transactionID string, paymentT
 	return nil
 }
 
-func (service *depositService) TotalDeposit() int64 {
-	return service.DepositRepository.TotalDeposit()
+func (service *depositService) TotalDeposit(ctx context.Context) int64 {
+	return service.DepositRepository.TotalDeposit(ctx)
 }
 
-func (service *depositService) TotalDepositByDate(dateStart int64, dateEnd int64) int64 {
-	return service.DepositRepository.TotalDepositByDate(dateStart, dateEnd)
+func (service *depositService) TotalDepositByDate(ctx context.Context, dateStart int64, dateEnd int64) int64 {
+	return service.DepositRepository.TotalDepositByDate(ctx, dateStart, dateEnd)
 }
 
-func (service *depositService) TotalDepositByDateIdUser(idUser uint64, dateStart int64, dateEnd int64) int64 {
-	return service.DepositRepository.TotalDepositByDateIdUser(idUser, dateStart, dateEnd)
+func (service *depositService) TotalDepositByDateIdUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) int64 {
+	return service.DepositRepository.TotalDepositByDateIdUser(ctx, idUser, dateStart, dateEnd)
 }
 
-func (service *depositService) TotalDepositByUserID(idUser uint64) int64 {
-	return service.DepositRepository.TotalDepositByUserID(idUser)
+func (service *depositService) TotalDepositByUserID(ctx context.Context, idUser uint64) int64 {
+	return service.DepositRepository.TotalDepositByUserID(ctx, idUser)
 }
 
-func (service *depositService) All(page int, pageSize int) ([]entity.Deposit, error) {
+func (service *depositService) All(ctx context.Context, page int, pageSize int) ([]entity.Deposit, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
-	return service.DepositRepository.All(page, pageSize)
+	return service.DepositRepository.All(ctx, page, pageSize)
 }
 
-func (service *depositService) SearchByDateAll(dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
-	return service.DepositRepository.SearchByDateAll(dateStart, dateEnd)
+func (service *depositService) SearchByDateAll(ctx context.Context, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
+	return service.DepositRepository.SearchByDateAll(ctx, dateStart, dateEnd)
 }
 
-func (service *depositService) SearchByDateIDUser(idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
-	return service.DepositRepository.SearchByDateIDUser(idUser, dateStart, dateEnd)
+func (service *depositService) SearchByDateIDUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
+	return service.DepositRepository.SearchByDateIDUser(ctx, idUser, dateStart, dateEnd)
 }
 
-func (service *depositService) FindDepositByIDUser(idUser uint64, page int, pageSize int) ([]entity.Deposit, error) {
+func (service *depositService) FindDepositByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.Deposit, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
-	return service.DepositRepository.FindDepositByIDUser(idUser, page, pageSize)
+	return service.DepositRepository.FindDepositByIDUser(ctx, idUser, page, pageSize)
 }
 
-func (service *depositService) FindDepositByID(id string) entity.Deposit {
-	return service.DepositRepository.FindDepositByID(id)
+func (service *depositService) RecentDeposits(ctx context.Context, idUser uint64, limit int) ([]entity.Deposit, error) {
+	return service.DepositRepository.RecentDeposits(ctx, idUser, limit)
 }
 
-func (service *depositService) FindPaymentInfoById(id string) *entity.PaymentToken {
-	return service.DepositRepository.FindPaymentInfoById(id)
+func (service *depositService) FindDepositByID(ctx context.Context, id string) entity.Deposit {
+	return service.DepositRepository.FindDepositByID(ctx, id)
 }
 
-func (service *depositService) SaveFile(file *multipart.FileHeader) (string, error) {
-	src, err := file.Open()
-	if err != nil {
-		return "", err
-	}
-	defer src.Close()
-
-	cdnDir := "cdn" // Specify the desired directory name
-
-	// Create the cdn directory if it doesn't exist in the current working directory
-	err = os.MkdirAll(cdnDir, 0755)
-	if err != nil {
-		return "", err
-	}
-
-	// Generate a random file name using UUID and append the original file extension
-	fileExt := filepath.Ext(file.Filename)
-	fileName := uuid.New().String() + fileExt
-	filePath := filepath.Join(cdnDir, fileName)
-
-	dst, err := os.Create(filePath)
-	if err != nil {
-		return "", err
-	}
-	defer dst.Close()
-
-	_, err = io.Copy(dst, src)
-	if err != nil {
-		return "", err
-	}
+func (service *depositService) FindPaymentInfoById(ctx context.Context, id string) *entity.PaymentToken {
+	return service.DepositRepository.FindPaymentInfoById(ctx, id)
+}
 
-	return fileName, nil
+func (service *depositService) SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	return saveUpload(ctx, service.BlobStore, file)
 }
 
-func (service *depositService) UpdateDepositStatus(orderID string, newStatus uint64) error {
+func (service *depositService) UpdateDepositStatus(ctx context.Context, orderID string, newStatus uint64) error {
 	// Fetch the MasterJual entity by order ID
-	masterJual := service.DepositRepository.FindDepositByID(orderID)
+	masterJual := service.DepositRepository.FindDepositByID(ctx, orderID)
 	if masterJual.ID == "0" {
-		return fmt.Errorf("MasterJual not found for order ID %s", orderID)
+		return apperror.ErrNotFound.WithDetail(fmt.Sprintf("MasterJual not found for order ID %s", orderID))
 	}
 
+	previousStatus := masterJual.Status
 	masterJual.Status = newStatus
 
-	err := service.DepositRepository.UpdateDepositStatus(masterJual.ID, newStatus)
+	err := service.DepositRepository.UpdateDepositStatus(ctx, masterJual.ID, newStatus)
 	if err != nil {
 		return err
 	}
+	if newStatus != previousStatus {
+		metrics.DepositStatusTransitions.WithLabelValues(metrics.DepositStatusName(previousStatus), metrics.DepositStatusName(newStatus)).Inc()
+	}
+
+	// The deposit fee is only earned once Midtrans reports the payment as settled
+	if newStatus == 5 && previousStatus != 5 {
+		service.FeeLimitService.RecordFee(ctx, masterJual.ID_User, entity.TrxTypeDeposit, masterJual.ID, masterJual.Fee)
+	}
 
 	return nil
 }
@@ -195,7 +184,7 @@ func (service *depositService) GenerateDepositPDF(Deposits []dto.DepositResponse
 		pdf.CellFormat(40, 10, deposit.Id_deposit, "1", 0, "C", false, 0, "")
 		pdf.CellFormat(40, 10, helper.Uint64ToString(deposit.Id_user), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(40, 10, deposit.Date, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(60, 10, helper.Uint64ToString(deposit.Amount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(60, 10, deposit.Amount.Format(), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(60, 10, deposit.Status, "1", 0, "C", false, 0, "")
 		pdf.CellFormat(50, 10, deposit.Virtual_account, "1", 0, "C", false, 0, "")
 		pdf.CellFormat(220, 10, deposit.Url_callback, "1", 1, "C", false, 0, "")
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/config"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/llm"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+)
+
+// faqChunkLength is the size in characters articles are cut into before
+// they are embedded, so a match points at the right passage.
+const faqChunkLength = 1000
+
+type FaqService interface {
+	InsertArticle(ctx context.Context, idAdmin uint64, article dto.FaqArticleDTO) (entity.FaqArticle, error)
+	UpdateArticle(ctx context.Context, id uint64, article dto.FaqArticleDTO) (entity.FaqArticle, error)
+	DeleteArticle(ctx context.Context, id uint64) error
+	Article(ctx context.Context, id uint64) (entity.FaqArticle, error)
+	Articles(ctx context.Context, page int, pageSize int) ([]entity.FaqArticle, error)
+	TotalArticles(ctx context.Context) int64
+	Search(ctx context.Context, query string, limit int) ([]dto.FaqMatch, error)
+	Reindex(ctx context.Context) (int, error)
+}
+
+type faqService struct {
+	FaqRepository repository.FaqRepository
+	LLMProvider   llm.Provider
+	// embeddingModel tells the vectors of the current model apart from
+	// those left by another one until the articles are reindexed.
+	embeddingModel string
+	minScore       float64
+}
+
+func NewFaqService(faqRepository repository.FaqRepository, llmProvider llm.Provider, cfg config.ChatbotConfig) FaqService {
+	embeddingModel := cfg.EmbeddingModel
+	if cfg.Provider == config.ChatbotFake {
+		embeddingModel = config.ChatbotFake
+	}
+
+	return &faqService{
+		FaqRepository:  faqRepository,
+		LLMProvider:    llmProvider,
+		embeddingModel: embeddingModel,
+		minScore:       cfg.FaqMinScore,
+	}
+}
+
+// InsertArticle stores an article once its passages are embedded, so every
+// stored article can be found by the chatbot.
+func (service *faqService) InsertArticle(ctx context.Context, idAdmin uint64, articleDTO dto.FaqArticleDTO) (entity.FaqArticle, error) {
+	article := entity.FaqArticle{
+		Title:     strings.TrimSpace(articleDTO.Title),
+		Body:      strings.TrimSpace(articleDTO.Body),
+		Published: articleDTO.Published == nil || *articleDTO.Published,
+		CreatedBy: idAdmin,
+	}
+
+	chunks, err := service.index(ctx, article)
+	if err != nil {
+		return entity.FaqArticle{}, err
+	}
+	if err := service.FaqRepository.InsertArticle(ctx, &article, chunks); err != nil {
+		return entity.FaqArticle{}, err
+	}
+	return article, nil
+}
+
+func (service *faqService) UpdateArticle(ctx context.Context, id uint64, articleDTO dto.FaqArticleDTO) (entity.FaqArticle, error) {
+	article := service.FaqRepository.FindArticle(ctx, id)
+	if article.ID == 0 {
+		return entity.FaqArticle{}, apperror.ErrFaqArticleNotFound
+	}
+
+	article.Title = strings.TrimSpace(articleDTO.Title)
+	article.Body = strings.TrimSpace(articleDTO.Body)
+	if articleDTO.Published != nil {
+		article.Published = *articleDTO.Published
+	}
+
+	chunks, err := service.index(ctx, article)
+	if err != nil {
+		return entity.FaqArticle{}, err
+	}
+	if err := service.FaqRepository.UpdateArticle(ctx, &article, chunks); err != nil {
+		return entity.FaqArticle{}, err
+	}
+	return article, nil
+}
+
+func (service *faqService) DeleteArticle(ctx context.Context, id uint64) error {
+	if service.FaqRepository.FindArticle(ctx, id).ID == 0 {
+		return apperror.ErrFaqArticleNotFound
+	}
+	return service.FaqRepository.DeleteArticle(ctx, id)
+}
+
+func (service *faqService) Article(ctx context.Context, id uint64) (entity.FaqArticle, error) {
+	article := service.FaqRepository.FindArticle(ctx, id)
+	if article.ID == 0 {
+		return entity.FaqArticle{}, apperror.ErrFaqArticleNotFound
+	}
+	return article, nil
+}
+
+func (service *faqService) Articles(ctx context.Context, page int, pageSize int) ([]entity.FaqArticle, error) {
+	return service.FaqRepository.FindArticles(ctx, page, pageSize)
+}
+
+func (service *faqService) TotalArticles(ctx context.Context) int64 {
+	return service.FaqRepository.TotalArticles(ctx)
+}
+
+// Search returns the published articles closest to query, best first, with
+// the passage that matched. Each article is returned once.
+func (service *faqService) Search(ctx context.Context, query string, limit int) ([]dto.FaqMatch, error) {
+	chunks, err := service.FaqRepository.PublishedChunks(ctx, service.embeddingModel)
+	if err != nil || len(chunks) == 0 {
+		return nil, err
+	}
+
+	vectors, err := service.LLMProvider.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+
+	best := map[uint64]dto.FaqMatch{}
+	for _, chunk := range chunks {
+		score := llm.Cosine(vectors[0], chunk.Embedding)
+		if score < service.minScore || score <= best[chunk.ArticleID].Score {
+			continue
+		}
+		best[chunk.ArticleID] = dto.FaqMatch{ArticleID: chunk.ArticleID, Content: chunk.Content, Score: score}
+	}
+
+	matches := make([]dto.FaqMatch, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	ids := make([]uint64, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ArticleID)
+	}
+	articles, err := service.FaqRepository.FindArticlesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	titles := map[uint64]string{}
+	for _, article := range articles {
+		titles[article.ID] = article.Title
+	}
+	for i := range matches {
+		matches[i].Title = titles[matches[i].ArticleID]
+	}
+	return matches, nil
+}
+
+// Reindex embeds every article again, after the embedding model changed.
+func (service *faqService) Reindex(ctx context.Context) (int, error) {
+	articles, err := service.FaqRepository.AllArticles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, article := range articles {
+		chunks, err := service.index(ctx, article)
+		if err != nil {
+			return i, err
+		}
+		if err := service.FaqRepository.ReplaceChunks(ctx, article.ID, chunks); err != nil {
+			return i, err
+		}
+	}
+	return len(articles), nil
+}
+
+// index cuts an article into passages and embeds them. The title is embedded
+// with every passage since it often carries the question.
+func (service *faqService) index(ctx context.Context, article entity.FaqArticle) ([]entity.FaqChunk, error) {
+	passages := chunkText(article.Body, faqChunkLength)
+	texts := make([]string, len(passages))
+	for i, passage := range passages {
+		texts[i] = article.Title + "\n\n" + passage
+	}
+
+	vectors, err := service.LLMProvider.Embed(ctx, texts)
+	if err != nil {
+		return nil, apperror.ErrFaqIndexFailed.Wrap(err)
+	}
+
+	chunks := make([]entity.FaqChunk, len(passages))
+	for i, passage := range passages {
+		chunks[i] = entity.FaqChunk{Position: i, Content: passage, Embedding: vectors[i], Model: service.embeddingModel}
+	}
+	return chunks, nil
+}
+
+// chunkText groups the paragraphs of text into passages of up to length
+// characters. Longer paragraphs are cut between words.
+func chunkText(text string, length int) []string {
+	var passages []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			passages = append(passages, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+utf8.RuneCountInString(paragraph)+2 > length {
+			flush()
+		}
+
+		for _, word := range strings.Fields(paragraph) {
+			if current.Len() > 0 && utf8.RuneCountInString(current.String())+utf8.RuneCountInString(word)+1 > length {
+				flush()
+			}
+			if current.Len() > 0 {
+				current.WriteString(" ")
+			}
+			current.WriteString(word)
+		}
+		current.WriteString("\n\n")
+	}
+	flush()
+
+	for i := range passages {
+		passages[i] = strings.TrimSpace(passages[i])
+	}
+	return passages
+}
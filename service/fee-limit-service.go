@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/logging"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+)
+
+// Built-in rules used for a transaction type until an admin stores its own.
+// Users who have not completed KYC get the tighter tier specific limits.
+var (
+	defaultLimitRules = []entity.LimitRule{
+		{TransactionType: entity.TrxTypeTransfer, KycTier: entity.KycTierUnverified, MinAmount: money.Rupiah(10000), MaxAmount: money.Rupiah(2000000), DailyLimit: money.Rupiah(5000000), MonthlyLimit: money.Rupiah(10000000)},
+		{TransactionType: entity.TrxTypeWithdrawal, KycTier: entity.KycTierUnverified, MinAmount: money.Rupiah(50000), MaxAmount: money.Rupiah(1000000), DailyLimit: money.Rupiah(2000000), MonthlyLimit: money.Rupiah(5000000)},
+		{TransactionType: entity.TrxTypeDeposit, KycTier: entity.KycTierUnverified, MinAmount: money.Rupiah(10000), MaxAmount: money.Rupiah(2000000), DailyLimit: money.Rupiah(10000000), MonthlyLimit: money.Rupiah(20000000)},
+		{TransactionType: entity.TrxTypeTransfer, MinAmount: money.Rupiah(10000), MaxAmount: money.Rupiah(25000000), DailyLimit: money.Rupiah(50000000), MonthlyLimit: money.Rupiah(500000000)},
+		{TransactionType: entity.TrxTypeWithdrawal, MinAmount: money.Rupiah(50000), MaxAmount: money.Rupiah(10000000), DailyLimit: money.Rupiah(20000000), MonthlyLimit: money.Rupiah(200000000)},
+		{TransactionType: entity.TrxTypeDeposit, MinAmount: money.Rupiah(10000), MaxAmount: money.Rupiah(100000000), DailyLimit: money.Rupiah(100000000), MonthlyLimit: money.Rupiah(1000000000)},
+	}
+	defaultFeeRules = []entity.FeeRule{
+		{TransactionType: entity.TrxTypeTransfer},
+		{TransactionType: entity.TrxTypeWithdrawal, FlatFee: money.Rupiah(2500)},
+		{TransactionType: entity.TrxTypeDeposit, FlatFee: money.Rupiah(4000)},
+		{TransactionType: entity.TrxTypeDeposit, PaymentMethod: "10", PercentageBps: 200, MinFee: money.Rupiah(1000)},
+	}
+)
+
+type FeeLimitService interface {
+	Evaluate(ctx context.Context, idUser uint64, trxType string, paymentMethod string, amount money.Money) (money.Money, error)
+	RecordFee(ctx context.Context, idUser uint64, trxType string, referenceID string, fee money.Money)
+	AllLimitRules(ctx context.Context) ([]entity.LimitRule, error)
+	AllFeeRules(ctx context.Context) ([]entity.FeeRule, error)
+	SaveLimitRule(ctx context.Context, rule entity.LimitRule) (entity.LimitRule, error)
+	SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error)
+}
+
+type feeLimitService struct {
+	feeLimitRepository repository.FeeLimitRepository
+	userRepository     repository.UserRepository
+}
+
+func NewFeeLimitService(feeLimitRep repository.FeeLimitRepository, userRep repository.UserRepository) FeeLimitService {
+	return &feeLimitService{
+		feeLimitRepository: feeLimitRep,
+		userRepository:     userRep,
+	}
+}
+
+// Evaluate checks amount against the limits that apply to the user and
+// returns the fee to charge on top of it. Rules are defined in Rupiah, so
+// amount must be too.
+func (service *feeLimitService) Evaluate(ctx context.Context, idUser uint64, trxType string, paymentMethod string, amount money.Money) (money.Money, error) {
+	noFee := money.Rupiah(0)
+	if amount.Currency != money.IDR {
+		return noFee, apperror.ErrCurrencyNotSupported.With(apperror.Params{"currency": amount.Currency})
+	}
+	if !amount.IsPositive() {
+		return noFee, apperror.ErrInvalidAmount
+	}
+
+	user, err := service.userRepository.ProfileUser(ctx, idUser)
+	if err != nil {
+		return noFee, err
+	}
+
+	limitRules, err := service.feeLimitRepository.LimitRules(ctx, trxType)
+	if err != nil {
+		return noFee, err
+	}
+	if len(limitRules) == 0 {
+		limitRules = defaultLimitRulesFor(trxType)
+	}
+
+	if rule, ok := matchLimitRule(limitRules, user.IdRole, user.KycTier); ok {
+		if err := service.checkLimit(ctx, rule, idUser, trxType, amount); err != nil {
+			return noFee, err
+		}
+	}
+
+	feeRules, err := service.feeLimitRepository.FeeRules(ctx, trxType)
+	if err != nil {
+		return noFee, err
+	}
+	if len(feeRules) == 0 {
+		feeRules = defaultFeeRulesFor(trxType)
+	}
+
+	if rule, ok := matchFeeRule(feeRules, paymentMethod); ok {
+		return calculateFee(rule, amount)
+	}
+	return noFee, nil
+}
+
+func (service *feeLimitService) RecordFee(ctx context.Context, idUser uint64, trxType string, referenceID string, fee money.Money) {
+	if fee.IsZero() {
+		return
+	}
+
+	posting := entity.FeePosting{
+		ID_User:         idUser,
+		TransactionType: trxType,
+		ReferenceID:     referenceID,
+		Amount:          fee,
+	}
+	if err := service.feeLimitRepository.InsertFeePosting(ctx, &posting); err != nil {
+		logging.FromContext(ctx).Error("Failed to record fee posting for ", trxType, " ", referenceID, ": ", err)
+	}
+}
+
+func (service *feeLimitService) AllLimitRules(ctx context.Context) ([]entity.LimitRule, error) {
+	return service.feeLimitRepository.AllLimitRules(ctx)
+}
+
+func (service *feeLimitService) AllFeeRules(ctx context.Context) ([]entity.FeeRule, error) {
+	return service.feeLimitRepository.AllFeeRules(ctx)
+}
+
+func (service *feeLimitService) SaveLimitRule(ctx context.Context, rule entity.LimitRule) (entity.LimitRule, error) {
+	if !isKnownTrxType(rule.TransactionType) {
+		return rule, apperror.ErrInvalidRequest.WithDetail(fmt.Sprintf("Unknown transaction type %q", rule.TransactionType))
+	}
+	if err := normalizeRuleAmounts(&rule.MinAmount, &rule.MaxAmount, &rule.DailyLimit, &rule.MonthlyLimit); err != nil {
+		return rule, err
+	}
+	if !rule.MaxAmount.IsZero() && rule.MinAmount.GreaterThan(rule.MaxAmount) {
+		return rule, apperror.ErrInvalidRequest.WithDetail("min_amount cannot be greater than max_amount")
+	}
+	return service.feeLimitRepository.SaveLimitRule(ctx, rule)
+}
+
+func (service *feeLimitService) SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error) {
+	if !isKnownTrxType(rule.TransactionType) {
+		return rule, apperror.ErrInvalidRequest.WithDetail(fmt.Sprintf("Unknown transaction type %q", rule.TransactionType))
+	}
+	if rule.PercentageBps > 10000 {
+		return rule, apperror.ErrInvalidRequest.WithDetail("percentage_bps cannot exceed 10000")
+	}
+	if err := normalizeRuleAmounts(&rule.FlatFee, &rule.MinFee, &rule.MaxFee); err != nil {
+		return rule, err
+	}
+	return service.feeLimitRepository.SaveFeeRule(ctx, rule)
+}
+
+func (service *feeLimitService) checkLimit(ctx context.Context, rule entity.LimitRule, idUser uint64, trxType string, amount money.Money) error {
+	if !rule.MinAmount.IsZero() && amount.LessThan(rule.MinAmount) {
+		return apperror.ErrAmountBelowMinimum.With(apperror.Params{"type": trxType, "limit": rule.MinAmount.Format()})
+	}
+	if !rule.MaxAmount.IsZero() && amount.GreaterThan(rule.MaxAmount) {
+		return apperror.ErrAmountAboveMaximum.With(apperror.Params{"type": trxType, "limit": rule.MaxAmount.Format()})
+	}
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+
+	if !rule.DailyLimit.IsZero() {
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc).Unix()
+		used, err := service.feeLimitRepository.SumAmountSince(ctx, idUser, trxType, startOfDay)
+		if err != nil {
+			return err
+		}
+		total, err := used.Add(amount)
+		if err != nil {
+			return err
+		}
+		if total.GreaterThan(rule.DailyLimit) {
+			return apperror.ErrDailyLimitExceeded.With(apperror.Params{"type": trxType, "limit": rule.DailyLimit.Format(), "remaining": remaining(rule.DailyLimit, used).Format()})
+		}
+	}
+
+	if !rule.MonthlyLimit.IsZero() {
+		startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc).Unix()
+		used, err := service.feeLimitRepository.SumAmountSince(ctx, idUser, trxType, startOfMonth)
+		if err != nil {
+			return err
+		}
+		total, err := used.Add(amount)
+		if err != nil {
+			return err
+		}
+		if total.GreaterThan(rule.MonthlyLimit) {
+			return apperror.ErrMonthlyLimitExceeded.With(apperror.Params{"type": trxType, "limit": rule.MonthlyLimit.Format(), "remaining": remaining(rule.MonthlyLimit, used).Format()})
+		}
+	}
+
+	return nil
+}
+
+// matchLimitRule picks the most specific rule for the role and KYC tier.
+// A rule for an exact role beats one for an exact tier, which beats a
+// catch-all rule.
+func matchLimitRule(rules []entity.LimitRule, idRole uint64, kycTier uint64) (entity.LimitRule, bool) {
+	best := -1
+	var matched entity.LimitRule
+	for _, rule := range rules {
+		if rule.IdRole != 0 && rule.IdRole != idRole {
+			continue
+		}
+		if rule.KycTier != 0 && rule.KycTier != kycTier {
+			continue
+		}
+
+		score := 0
+		if rule.IdRole != 0 {
+			score += 2
+		}
+		if rule.KycTier != 0 {
+			score++
+		}
+		if score > best {
+			best = score
+			matched = rule
+		}
+	}
+	return matched, best >= 0
+}
+
+func matchFeeRule(rules []entity.FeeRule, paymentMethod string) (entity.FeeRule, bool) {
+	var fallback *entity.FeeRule
+	for i, rule := range rules {
+		if rule.PaymentMethod == paymentMethod {
+			return rule, true
+		}
+		if rule.PaymentMethod == "" && fallback == nil {
+			fallback = &rules[i]
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return entity.FeeRule{}, false
+}
+
+func calculateFee(rule entity.FeeRule, amount money.Money) (money.Money, error) {
+	percentage, err := amount.Bps(int64(rule.PercentageBps))
+	if err != nil {
+		return money.Rupiah(0), err
+	}
+	fee, err := percentage.Add(rule.FlatFee)
+	if err != nil {
+		return money.Rupiah(0), err
+	}
+
+	fee = fee.Max(rule.MinFee)
+	if !rule.MaxFee.IsZero() {
+		fee = fee.Min(rule.MaxFee)
+	}
+	return fee, nil
+}
+
+// normalizeRuleAmounts makes sure rule amounts sent by an admin are positive
+// Rupiah amounts, amounts left out become Rp0.
+func normalizeRuleAmounts(amounts ...*money.Money) error {
+	for _, amount := range amounts {
+		if amount.Currency == "" {
+			amount.Currency = money.IDR
+		}
+		if amount.Currency != money.IDR {
+			return apperror.ErrInvalidRequest.WithDetail("Rule amounts must be in IDR")
+		}
+		if amount.IsNegative() {
+			return apperror.ErrInvalidRequest.WithDetail("Rule amounts cannot be negative")
+		}
+	}
+	return nil
+}
+
+func defaultLimitRulesFor(trxType string) []entity.LimitRule {
+	var matched []entity.LimitRule
+	for _, rule := range defaultLimitRules {
+		if rule.TransactionType == trxType {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+func defaultFeeRulesFor(trxType string) []entity.FeeRule {
+	var matched []entity.FeeRule
+	for _, rule := range defaultFeeRules {
+		if rule.TransactionType == trxType {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+func remaining(limit money.Money, used money.Money) money.Money {
+	left, err := limit.Sub(used)
+	if err != nil || left.IsNegative() {
+		return money.Rupiah(0)
+	}
+	return left
+}
+
+func isKnownTrxType(trxType string) bool {
+	switch trxType {
+	case entity.TrxTypeTransfer, entity.TrxTypeWithdrawal, entity.TrxTypeDeposit:
+		return true
+	}
+	return false
+}
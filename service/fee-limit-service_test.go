@@ -0,0 +1,218 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+	repomocks "github.com/IrvanWijayaSardam/SelfBank/repository/mocks"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+func TestFeeLimitService_Evaluate(t *testing.T) {
+	unverified := entity.User{ID: 1, IdRole: entity.RoleUser, KycTier: entity.KycTierUnverified}
+	verified := entity.User{ID: 1, IdRole: entity.RoleUser, KycTier: entity.KycTierVerified}
+	errDatabase := errors.New("database is unavailable")
+
+	tests := []struct {
+		name          string
+		user          entity.User
+		trxType       string
+		paymentMethod string
+		amount        money.Money
+		limitRules    []entity.LimitRule
+		feeRules      []entity.FeeRule
+		// used is what SumAmountSince returns for the daily and then the
+		// monthly check, sumErr fails the first sum.
+		used    []money.Money
+		sumErr  error
+		wantFee money.Money
+		wantErr error
+	}{
+		{
+			name:    "Default Transfer Without Fee",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(50000),
+			used:    []money.Money{money.Rupiah(0), money.Rupiah(0)},
+			wantFee: money.Rupiah(0),
+		},
+		{
+			name:    "Below Minimum",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(5000),
+			wantErr: apperror.ErrAmountBelowMinimum,
+		},
+		{
+			name:    "Above Unverified Maximum",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(3000000),
+			wantErr: apperror.ErrAmountAboveMaximum,
+		},
+		{
+			name:    "Verified Tier Gets Catch All Maximum",
+			user:    verified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(3000000),
+			used:    []money.Money{money.Rupiah(0), money.Rupiah(0)},
+			wantFee: money.Rupiah(0),
+		},
+		{
+			name:    "Daily Limit Exceeded",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(1000000),
+			used:    []money.Money{money.Rupiah(4500000)},
+			wantErr: apperror.ErrDailyLimitExceeded,
+		},
+		{
+			name:    "Daily Limit Reached Exactly",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(1000000),
+			used:    []money.Money{money.Rupiah(4000000), money.Rupiah(4000000)},
+			wantFee: money.Rupiah(0),
+		},
+		{
+			name:    "Monthly Limit Exceeded",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(1000000),
+			used:    []money.Money{money.Rupiah(0), money.Rupiah(9500000)},
+			wantErr: apperror.ErrMonthlyLimitExceeded,
+		},
+		{
+			name:    "Sum Error Rejects",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(50000),
+			sumErr:  errDatabase,
+			wantErr: errDatabase,
+		},
+		{
+			name:    "Role Rule Beats Tier Rule",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(600000),
+			limitRules: []entity.LimitRule{
+				{TransactionType: entity.TrxTypeTransfer, MaxAmount: money.Rupiah(100000)},
+				{TransactionType: entity.TrxTypeTransfer, KycTier: entity.KycTierUnverified, MaxAmount: money.Rupiah(200000)},
+				{TransactionType: entity.TrxTypeTransfer, IdRole: entity.RoleUser, MaxAmount: money.Rupiah(1000000)},
+			},
+			wantFee: money.Rupiah(0),
+		},
+		{
+			name:    "Tier Rule Beats Catch All",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(150000),
+			limitRules: []entity.LimitRule{
+				{TransactionType: entity.TrxTypeTransfer, MaxAmount: money.Rupiah(1000000)},
+				{TransactionType: entity.TrxTypeTransfer, KycTier: entity.KycTierUnverified, MaxAmount: money.Rupiah(100000)},
+			},
+			wantErr: apperror.ErrAmountAboveMaximum,
+		},
+		{
+			name:    "Rule For Other Role Is Skipped",
+			user:    unverified,
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(150000),
+			limitRules: []entity.LimitRule{
+				{TransactionType: entity.TrxTypeTransfer, IdRole: entity.RoleAdmin, MaxAmount: money.Rupiah(100000)},
+			},
+			wantFee: money.Rupiah(0),
+		},
+		{
+			name:    "Default Withdrawal Flat Fee",
+			user:    verified,
+			trxType: entity.TrxTypeWithdrawal,
+			amount:  money.Rupiah(100000),
+			used:    []money.Money{money.Rupiah(0), money.Rupiah(0)},
+			wantFee: money.Rupiah(2500),
+		},
+		{
+			name:          "Payment Method Percentage Fee",
+			user:          verified,
+			trxType:       entity.TrxTypeDeposit,
+			paymentMethod: "10",
+			amount:        money.Rupiah(100000),
+			used:          []money.Money{money.Rupiah(0), money.Rupiah(0)},
+			wantFee:       money.Rupiah(2000),
+		},
+		{
+			name:          "Percentage Fee Raised To Minimum",
+			user:          verified,
+			trxType:       entity.TrxTypeDeposit,
+			paymentMethod: "10",
+			amount:        money.Rupiah(20000),
+			used:          []money.Money{money.Rupiah(0), money.Rupiah(0)},
+			wantFee:       money.Rupiah(1000),
+		},
+		{
+			name:          "Unknown Payment Method Falls Back",
+			user:          verified,
+			trxType:       entity.TrxTypeDeposit,
+			paymentMethod: "99",
+			amount:        money.Rupiah(100000),
+			used:          []money.Money{money.Rupiah(0), money.Rupiah(0)},
+			wantFee:       money.Rupiah(4000),
+		},
+		{
+			name:       "Fee Capped At Maximum",
+			user:       verified,
+			trxType:    entity.TrxTypeTransfer,
+			amount:     money.Rupiah(10000000),
+			limitRules: []entity.LimitRule{{TransactionType: entity.TrxTypeTransfer}},
+			feeRules: []entity.FeeRule{
+				{TransactionType: entity.TrxTypeTransfer, FlatFee: money.Rupiah(1000), PercentageBps: 50, MaxFee: money.Rupiah(25000)},
+			},
+			wantFee: money.Rupiah(25000),
+		},
+		{
+			name:    "Non Rupiah Amount",
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.New(100, "USD"),
+			wantErr: apperror.ErrCurrencyNotSupported,
+		},
+		{
+			name:    "Non Positive Amount",
+			trxType: entity.TrxTypeTransfer,
+			amount:  money.Rupiah(0),
+			wantErr: apperror.ErrInvalidAmount,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			feeLimitRepository := repomocks.NewFeeLimitRepository(t)
+			userRepository := repomocks.NewUserRepository(t)
+			feeLimitService := service.NewFeeLimitService(feeLimitRepository, userRepository)
+
+			userRepository.On("ProfileUser", mock.Anything, mock.Anything).Return(test.user, nil).Maybe()
+			feeLimitRepository.On("LimitRules", mock.Anything, test.trxType).Return(test.limitRules, nil).Maybe()
+			feeLimitRepository.On("FeeRules", mock.Anything, test.trxType).Return(test.feeRules, nil).Maybe()
+			if test.sumErr != nil {
+				feeLimitRepository.On("SumAmountSince", mock.Anything, test.user.ID, test.trxType, mock.Anything).Return(money.Rupiah(0), test.sumErr).Once()
+			}
+			for _, used := range test.used {
+				feeLimitRepository.On("SumAmountSince", mock.Anything, test.user.ID, test.trxType, mock.Anything).Return(used, nil).Once()
+			}
+
+			fee, err := feeLimitService.Evaluate(context.Background(), test.user.ID, test.trxType, test.paymentMethod, test.amount)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantFee, fee)
+		})
+	}
+}
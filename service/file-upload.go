@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"mime/multipart"
+
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
+
+	"github.com/google/uuid"
+)
+
+// saveUpload validates an uploaded document and stores it publicly under a
+// random name, returning its URL.
+func saveUpload(ctx context.Context, store storage.BlobStore, file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	document, err := storage.DocumentPolicy.Validate(src)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mediaUploadTimeout)
+	defer cancel()
+
+	key := storage.PublicPrefix + "cdn/" + uuid.New().String() + document.Extension
+	err = store.Put(ctx, key, document.Reader(), document.ContentType)
+	if err != nil {
+		return "", err
+	}
+
+	return store.URL(key), nil
+}
@@ -1,48 +1,83 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"time"
+
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
-	"github.com/IrvanWijayaSardam/SelfBank/helper"
-	"github.com/go-playground/validator/v10"
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
+	"github.com/IrvanWijayaSardam/SelfBank/validation"
+	"github.com/google/uuid"
+)
+
+const (
+	profileImageSize     = 512
+	profileThumbnailSize = 128
+	mediaUploadTimeout   = 10 * time.Second
 )
 
 var (
-	validate = validator.New()
+	validate = validation.New()
 )
 
-type mediaUpload interface {
-	FileUpload(file dto.File) (string, error)
-	RemoteUpload(url dto.Url) (string, error)
+type MediaUpload interface {
+	FileUpload(ctx context.Context, file dto.File) (dto.UploadedImage, error)
 }
 
-type media struct{}
+type media struct {
+	store storage.BlobStore
+}
 
-func NewMediaUpload() mediaUpload {
-	return &media{}
+func NewMediaUpload(store storage.BlobStore) MediaUpload {
+	return &media{store: store}
 }
 
-func (*media) FileUpload(file dto.File) (string, error) {
-	err := validate.Struct(file)
+func (m *media) FileUpload(ctx context.Context, file dto.File) (dto.UploadedImage, error) {
+	err := validate.Validate(file)
 	if err != nil {
-		return "", err
+		return dto.UploadedImage{}, err
 	}
 
-	uploadUrl, err := helper.ImageUploadHelper(file.File)
+	image, err := storage.ImagePolicy.Validate(file.File)
 	if err != nil {
-		return "", err
+		return dto.UploadedImage{}, err
 	}
-	return uploadUrl, nil
+	return m.storeProfileImage(ctx, image)
 }
 
-func (*media) RemoteUpload(url dto.Url) (string, error) {
-	err := validate.Struct(url)
+// storeProfileImage saves a resized profile picture and its thumbnail.
+func (m *media) storeProfileImage(ctx context.Context, image storage.ValidatedFile) (dto.UploadedImage, error) {
+	profile, err := storage.ResizeImage(image.Content, profileImageSize, profileImageSize)
+	if err != nil {
+		return dto.UploadedImage{}, err
+	}
+
+	thumbnail, err := storage.ResizeImage(image.Content, profileThumbnailSize, profileThumbnailSize)
 	if err != nil {
-		return "", err
+		return dto.UploadedImage{}, err
 	}
 
-	uploadUrl, errUrl := helper.ImageUploadHelper(url.Url)
-	if errUrl != nil {
-		return "", err
+	ctx, cancel := context.WithTimeout(ctx, mediaUploadTimeout)
+	defer cancel()
+
+	name := uuid.New().String()
+	profileKey := storage.PublicPrefix + "profiles/" + name + ".jpg"
+	thumbnailKey := storage.PublicPrefix + "profiles/" + name + "-thumb.jpg"
+
+	err = m.store.Put(ctx, profileKey, bytes.NewReader(profile), "image/jpeg")
+	if err != nil {
+		return dto.UploadedImage{}, err
 	}
-	return uploadUrl, nil
+
+	err = m.store.Put(ctx, thumbnailKey, bytes.NewReader(thumbnail), "image/jpeg")
+	if err != nil {
+		m.store.Delete(ctx, profileKey)
+		return dto.UploadedImage{}, err
+	}
+
+	return dto.UploadedImage{
+		Url:          m.store.URL(profileKey),
+		ThumbnailUrl: m.store.URL(thumbnailKey),
+	}, nil
 }
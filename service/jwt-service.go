@@ -2,9 +2,9 @@ package service
 
 import (
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/IrvanWijayaSardam/SelfBank/config"
 	"github.com/golang-jwt/jwt/v4"
 )
 
@@ -19,18 +19,13 @@ type jwtService struct {
 }
 
 // NewJWTService creates a new instance of JWTService
-func NewJWTService() JWTService {
+func NewJWTService(jwtConfig config.JWTConfig) JWTService {
 	return &jwtService{
-		issuer:    "aminivan",
-		secretKey: getSecretKey(),
+		issuer:    jwtConfig.Issuer,
+		secretKey: jwtConfig.Secret,
 	}
 }
 
-func getSecretKey() string {
-	secretKey := os.Getenv("JWT_SECRET")
-	return secretKey
-}
-
 func (j *jwtService) GenerateToken(UserID string, Email string, Jk string, Telephone string, Name string, IdRole uint64, accountNumber string) (string, error) {
 	claims := jwt.MapClaims{
 		"userid":        UserID,
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KycDocumentKtp    = "ktp"
+	KycDocumentSelfie = "selfie"
+
+	minKycAge              = 17
+	kycDocumentURLLifetime = 5 * time.Minute
+)
+
+var nikPattern = regexp.MustCompile(`^[0-9]{16}$`)
+
+type KycService interface {
+	Submit(ctx context.Context, idUser uint64, submission dto.KycSubmissionDTO, ktp *multipart.FileHeader, selfie *multipart.FileHeader) (entity.KycSubmission, error)
+	MySubmission(ctx context.Context, idUser uint64) entity.KycSubmission
+	FindSubmissionByID(ctx context.Context, id uint64) entity.KycSubmission
+	PendingSubmissions(ctx context.Context, page int, pageSize int) ([]entity.KycSubmission, error)
+	TotalPendingSubmissions(ctx context.Context) int64
+	Approve(ctx context.Context, id uint64, reviewerID uint64) error
+	Reject(ctx context.Context, id uint64, reviewerID uint64, reason string) error
+	DocumentURL(ctx context.Context, id uint64, document string) (string, error)
+}
+
+type kycService struct {
+	kycRepository repository.KycRepository
+	blobStore     storage.BlobStore
+}
+
+func NewKycService(kycRep repository.KycRepository, blobStore storage.BlobStore) KycService {
+	return &kycService{
+		kycRepository: kycRep,
+		blobStore:     blobStore,
+	}
+}
+
+func (service *kycService) Submit(ctx context.Context, idUser uint64, submission dto.KycSubmissionDTO, ktp *multipart.FileHeader, selfie *multipart.FileHeader) (entity.KycSubmission, error) {
+	if err := validateKycSubmission(submission); err != nil {
+		return entity.KycSubmission{}, err
+	}
+
+	latest := service.kycRepository.LatestSubmissionByIDUser(ctx, idUser)
+	switch latest.Status {
+	case entity.KycStatusPending:
+		return entity.KycSubmission{}, apperror.ErrKycPending
+	case entity.KycStatusApproved:
+		return entity.KycSubmission{}, apperror.ErrKycAlreadyVerified
+	}
+
+	if service.kycRepository.IsNIKTaken(ctx, submission.NIK, idUser) {
+		return entity.KycSubmission{}, apperror.ErrNIKTaken
+	}
+
+	ktpPath, err := service.storeDocument(ctx, idUser, KycDocumentKtp, ktp)
+	if err != nil {
+		return entity.KycSubmission{}, err
+	}
+
+	selfiePath, err := service.storeDocument(ctx, idUser, KycDocumentSelfie, selfie)
+	if err != nil {
+		service.deleteDocuments(ktpPath)
+		return entity.KycSubmission{}, err
+	}
+
+	record := entity.KycSubmission{
+		ID_User:     idUser,
+		NIK:         submission.NIK,
+		DateOfBirth: submission.DateOfBirth,
+		Address:     strings.TrimSpace(submission.Address),
+		KtpPath:     ktpPath,
+		SelfiePath:  selfiePath,
+		Status:      entity.KycStatusPending,
+	}
+
+	err = service.kycRepository.InsertSubmission(ctx, &record)
+	if err != nil {
+		service.deleteDocuments(ktpPath, selfiePath)
+		return entity.KycSubmission{}, err
+	}
+
+	return record, nil
+}
+
+func (service *kycService) MySubmission(ctx context.Context, idUser uint64) entity.KycSubmission {
+	return service.kycRepository.LatestSubmissionByIDUser(ctx, idUser)
+}
+
+func (service *kycService) FindSubmissionByID(ctx context.Context, id uint64) entity.KycSubmission {
+	return service.kycRepository.FindSubmissionByID(ctx, id)
+}
+
+func (service *kycService) PendingSubmissions(ctx context.Context, page int, pageSize int) ([]entity.KycSubmission, error) {
+	return service.kycRepository.PendingSubmissions(ctx, page, pageSize)
+}
+
+func (service *kycService) TotalPendingSubmissions(ctx context.Context) int64 {
+	return service.kycRepository.TotalPendingSubmissions(ctx)
+}
+
+func (service *kycService) Approve(ctx context.Context, id uint64, reviewerID uint64) error {
+	submission, err := service.pendingSubmission(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	submission.ReviewedBy = reviewerID
+	return service.kycRepository.ApproveSubmission(ctx, submission)
+}
+
+func (service *kycService) Reject(ctx context.Context, id uint64, reviewerID uint64, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return apperror.ErrKycReasonRequired
+	}
+
+	submission, err := service.pendingSubmission(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	submission.ReviewedBy = reviewerID
+	submission.RejectReason = reason
+	return service.kycRepository.RejectSubmission(ctx, submission)
+}
+
+// DocumentURL returns a short lived signed link to one of the submission's
+// documents, they are never publicly reachable.
+func (service *kycService) DocumentURL(ctx context.Context, id uint64, document string) (string, error) {
+	submission := service.kycRepository.FindSubmissionByID(ctx, id)
+	if submission.ID == 0 {
+		return "", apperror.ErrKycNotSubmitted
+	}
+
+	switch document {
+	case KycDocumentKtp:
+		return service.blobStore.SignedURL(submission.KtpPath, kycDocumentURLLifetime)
+	case KycDocumentSelfie:
+		return service.blobStore.SignedURL(submission.SelfiePath, kycDocumentURLLifetime)
+	}
+	return "", apperror.ErrNotFound.WithDetail(fmt.Sprintf("Unknown document %q", document))
+}
+
+func (service *kycService) pendingSubmission(ctx context.Context, id uint64) (entity.KycSubmission, error) {
+	submission := service.kycRepository.FindSubmissionByID(ctx, id)
+	if submission.ID == 0 {
+		return submission, apperror.ErrKycNotSubmitted
+	}
+	if submission.Status != entity.KycStatusPending {
+		return submission, apperror.ErrKycAlreadyReviewed
+	}
+	return submission, nil
+}
+
+// storeDocument checks that the upload is a reasonably sized JPEG or PNG and
+// saves it privately under a random name in the user's KYC folder.
+func (service *kycService) storeDocument(ctx context.Context, idUser uint64, document string, file *multipart.FileHeader) (string, error) {
+	if file == nil {
+		return "", apperror.ErrKycDocumentRequired.With(apperror.Params{"document": document})
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	photo, err := storage.ImagePolicy.Validate(src)
+	if err != nil {
+		return "", apperror.ErrKycDocumentInvalid.With(apperror.Params{"document": document}).WithDetail(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mediaUploadTimeout)
+	defer cancel()
+
+	key := fmt.Sprintf("%skyc/%d/%s-%s%s", storage.PrivatePrefix, idUser, document, uuid.New().String(), photo.Extension)
+	err = service.blobStore.Put(ctx, key, photo.Reader(), photo.ContentType)
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// deleteDocuments cleans up after a failed submission. It does not use the
+// request context, the files must go even when the client has given up.
+func (service *kycService) deleteDocuments(keys ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mediaUploadTimeout)
+	defer cancel()
+
+	for _, key := range keys {
+		service.blobStore.Delete(ctx, key)
+	}
+}
+
+func validateKycSubmission(submission dto.KycSubmissionDTO) error {
+	if !nikPattern.MatchString(submission.NIK) {
+		return apperror.ErrInvalidNIK
+	}
+
+	dateOfBirth, err := time.Parse("2006-01-02", submission.DateOfBirth)
+	if err != nil {
+		return apperror.ErrInvalidDateOfBirth
+	}
+	if dateOfBirth.AddDate(minKycAge, 0, 0).After(time.Now()) {
+		return apperror.ErrUnderage.With(apperror.Params{"min_age": strconv.Itoa(minKycAge)})
+	}
+
+	if strings.TrimSpace(submission.Address) == "" {
+		return apperror.ErrAddressRequired
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+)
+
+const (
+	LedgerCheckNegativeBalance = "NEGATIVE_BALANCE"
+	LedgerCheckFeePostings     = "FEE_POSTINGS"
+)
+
+type LedgerService interface {
+	Reconcile(ctx context.Context, idUser uint64) ([]dto.LedgerIssue, error)
+}
+
+type ledgerService struct {
+	ledgerRepository repository.LedgerRepository
+	walletRepository repository.WalletRepository
+	userService      UserService
+}
+
+func NewLedgerService(ledgerRep repository.LedgerRepository, walletRep repository.WalletRepository, userService UserService) LedgerService {
+	return &ledgerService{
+		ledgerRepository: ledgerRep,
+		walletRepository: walletRep,
+		userService:      userService,
+	}
+}
+
+// Reconcile checks that no wallet is overdrawn and that every fee charged has
+// been posted. It checks every user when idUser is 0.
+func (service *ledgerService) Reconcile(ctx context.Context, idUser uint64) ([]dto.LedgerIssue, error) {
+	ids := []uint64{idUser}
+	if idUser == 0 {
+		var err error
+		ids, err = service.ledgerRepository.UserIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	issues := []dto.LedgerIssue{}
+	for _, id := range ids {
+		userIssues, err := service.reconcileUser(ctx, id)
+		if err != nil {
+			return issues, err
+		}
+		issues = append(issues, userIssues...)
+	}
+	return issues, nil
+}
+
+func (service *ledgerService) reconcileUser(ctx context.Context, idUser uint64) ([]dto.LedgerIssue, error) {
+	var issues []dto.LedgerIssue
+
+	wallets, err := service.walletRepository.FindWalletsByIDUser(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	currencies := []string{money.IDR}
+	for _, wallet := range wallets {
+		if wallet.Currency != money.IDR {
+			currencies = append(currencies, wallet.Currency)
+		}
+	}
+
+	for _, currency := range currencies {
+		balance, err := service.userService.GetBalance(ctx, idUser, currency)
+		if err != nil {
+			return nil, err
+		}
+		if balance.IsNegative() {
+			issues = append(issues, dto.LedgerIssue{
+				IDUser:  idUser,
+				Check:   LedgerCheckNegativeBalance,
+				Message: fmt.Sprintf("%s balance is %s", currency, balance.Format()),
+			})
+		}
+	}
+
+	for _, trxType := range []string{entity.TrxTypeTransfer, entity.TrxTypeWithdrawal, entity.TrxTypeDeposit} {
+		charged := service.ledgerRepository.FeesCharged(ctx, idUser, trxType)
+		posted := service.ledgerRepository.FeesPosted(ctx, idUser, trxType)
+		if charged.Cmp(posted) != 0 {
+			issues = append(issues, dto.LedgerIssue{
+				IDUser:  idUser,
+				Check:   LedgerCheckFeePostings,
+				Message: fmt.Sprintf("%s fees charged %s but posted %s", trxType, charged.Format(), posted.Format()),
+			})
+		}
+	}
+	return issues, nil
+}
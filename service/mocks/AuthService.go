@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	dto "github.com/IrvanWijayaSardam/SelfBank/dto"
 	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
 
@@ -14,27 +16,37 @@ type AuthService struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: user
-func (_m *AuthService) CreateUser(user dto.RegisterDTO) entity.User {
-	ret := _m.Called(user)
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *AuthService) CreateUser(ctx context.Context, user dto.RegisterDTO) (entity.User, error) {
+	ret := _m.Called(ctx, user)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(dto.RegisterDTO) entity.User); ok {
-		r0 = rf(user)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.RegisterDTO) (entity.User, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.RegisterDTO) entity.User); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, dto.RegisterDTO) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// FindByEmail provides a mock function with given fields: email
-func (_m *AuthService) FindByEmail(email string) entity.User {
-	ret := _m.Called(email)
+// FindByEmail provides a mock function with given fields: ctx, email
+func (_m *AuthService) FindByEmail(ctx context.Context, email string) entity.User {
+	ret := _m.Called(ctx, email)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(string) entity.User); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.User); ok {
+		r0 = rf(ctx, email)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
@@ -42,13 +54,13 @@ func (_m *AuthService) FindByEmail(email string) entity.User {
 	return r0
 }
 
-// IsDuplicateEmail provides a mock function with given fields: email
-func (_m *AuthService) IsDuplicateEmail(email string) bool {
-	ret := _m.Called(email)
+// IsDuplicateEmail provides a mock function with given fields: ctx, email
+func (_m *AuthService) IsDuplicateEmail(ctx context.Context, email string) bool {
+	ret := _m.Called(ctx, email)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -56,13 +68,13 @@ func (_m *AuthService) IsDuplicateEmail(email string) bool {
 	return r0
 }
 
-// VerifyCredential provides a mock function with given fields: email, password
-func (_m *AuthService) VerifyCredential(email string, password string) interface{} {
-	ret := _m.Called(email, password)
+// VerifyCredential provides a mock function with given fields: ctx, email, password
+func (_m *AuthService) VerifyCredential(ctx context.Context, email string, password string) interface{} {
+	ret := _m.Called(ctx, email, password)
 
 	var r0 interface{}
-	if rf, ok := ret.Get(0).(func(string, string) interface{}); ok {
-		r0 = rf(email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) interface{}); ok {
+		r0 = rf(ctx, email, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	bytes "bytes"
+	context "context"
+
 	dto "github.com/IrvanWijayaSardam/SelfBank/dto"
+
 	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
 
 	mock "github.com/stretchr/testify/mock"
@@ -11,30 +15,30 @@ import (
 	multipart "mime/multipart"
 )
 
-// MockDepositService is an autogenerated mock type for the MockDepositService type
+// MockDepositService is an autogenerated mock type for the DepositService type
 type MockDepositService struct {
 	mock.Mock
 }
 
-// All provides a mock function with given fields: page, pageSize
-func (_m *MockDepositService) All(page int, pageSize int) ([]entity.Deposit, error) {
-	ret := _m.Called(page, pageSize)
+// All provides a mock function with given fields: ctx, page, pageSize
+func (_m *MockDepositService) All(ctx context.Context, page int, pageSize int) ([]entity.Deposit, error) {
+	ret := _m.Called(ctx, page, pageSize)
 
 	var r0 []entity.Deposit
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]entity.Deposit, error)); ok {
-		return rf(page, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Deposit, error)); ok {
+		return rf(ctx, page, pageSize)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []entity.Deposit); ok {
-		r0 = rf(page, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Deposit); ok {
+		r0 = rf(ctx, page, pageSize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Deposit)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(page, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,41 +46,81 @@ func (_m *MockDepositService) All(page int, pageSize int) ([]entity.Deposit, err
 	return r0, r1
 }
 
-// FindDepositByID provides a mock function with given fields: id
-func (_m *MockDepositService) FindDepositByID(id string) *entity.Deposit {
-	ret := _m.Called(id)
+// FindDepositByID provides a mock function with given fields: ctx, id
+func (_m *MockDepositService) FindDepositByID(ctx context.Context, id string) entity.Deposit {
+	ret := _m.Called(ctx, id)
 
-	var r0 *entity.Deposit
-	if rf, ok := ret.Get(0).(func(string) *entity.Deposit); ok {
-		r0 = rf(id)
+	var r0 entity.Deposit
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Deposit); ok {
+		r0 = rf(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Deposit)
-		}
+		r0 = ret.Get(0).(entity.Deposit)
 	}
 
 	return r0
 }
 
-// FindDepositByIDUser provides a mock function with given fields: idUser, int1, pageSize
-func (_m *MockDepositService) FindDepositByIDUser(idUser uint64, int1 int, pageSize int) ([]entity.Deposit, error) {
-	ret := _m.Called(idUser, int1, pageSize)
+// FindDepositByIDUser provides a mock function with given fields: ctx, idUser, int2, pageSize
+func (_m *MockDepositService) FindDepositByIDUser(ctx context.Context, idUser uint64, int2 int, pageSize int) ([]entity.Deposit, error) {
+	ret := _m.Called(ctx, idUser, int2, pageSize)
 
 	var r0 []entity.Deposit
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, int, int) ([]entity.Deposit, error)); ok {
-		return rf(idUser, int1, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) ([]entity.Deposit, error)); ok {
+		return rf(ctx, idUser, int2, pageSize)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, int, int) []entity.Deposit); ok {
-		r0 = rf(idUser, int1, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) []entity.Deposit); ok {
+		r0 = rf(ctx, idUser, int2, pageSize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Deposit)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, int, int) error); ok {
-		r1 = rf(idUser, int1, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int, int) error); ok {
+		r1 = rf(ctx, idUser, int2, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPaymentInfoById provides a mock function with given fields: ctx, depositId
+func (_m *MockDepositService) FindPaymentInfoById(ctx context.Context, depositId string) *entity.PaymentToken {
+	ret := _m.Called(ctx, depositId)
+
+	var r0 *entity.PaymentToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PaymentToken); ok {
+		r0 = rf(ctx, depositId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentToken)
+		}
+	}
+
+	return r0
+}
+
+// GenerateDepositPDF provides a mock function with given fields: deposits
+func (_m *MockDepositService) GenerateDepositPDF(deposits []dto.DepositResponse) (*bytes.Buffer, error) {
+	ret := _m.Called(deposits)
+
+	var r0 *bytes.Buffer
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]dto.DepositResponse) (*bytes.Buffer, error)); ok {
+		return rf(deposits)
+	}
+	if rf, ok := ret.Get(0).(func([]dto.DepositResponse) *bytes.Buffer); ok {
+		r0 = rf(deposits)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bytes.Buffer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]dto.DepositResponse) error); ok {
+		r1 = rf(deposits)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,13 +128,13 @@ func (_m *MockDepositService) FindDepositByIDUser(idUser uint64, int1 int, pageS
 	return r0, r1
 }
 
-// InsertDeposit provides a mock function with given fields: Deposit
-func (_m *MockDepositService) InsertDeposit(Deposit dto.DepositDTO) entity.Deposit {
-	ret := _m.Called(Deposit)
+// InsertDeposit provides a mock function with given fields: ctx, Deposit
+func (_m *MockDepositService) InsertDeposit(ctx context.Context, Deposit dto.DepositDTO) entity.Deposit {
+	ret := _m.Called(ctx, Deposit)
 
 	var r0 entity.Deposit
-	if rf, ok := ret.Get(0).(func(dto.DepositDTO) entity.Deposit); ok {
-		r0 = rf(Deposit)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.DepositDTO) entity.Deposit); ok {
+		r0 = rf(ctx, Deposit)
 	} else {
 		r0 = ret.Get(0).(entity.Deposit)
 	}
@@ -98,13 +142,13 @@ func (_m *MockDepositService) InsertDeposit(Deposit dto.DepositDTO) entity.Depos
 	return r0
 }
 
-// InsertPaymentToken provides a mock function with given fields: transactionID, paymentToken, virtualAcc, callbackUrl
-func (_m *MockDepositService) InsertPaymentToken(transactionID string, paymentToken string, virtualAcc string, callbackUrl string) error {
-	ret := _m.Called(transactionID, paymentToken, virtualAcc, callbackUrl)
+// InsertPaymentToken provides a mock function with given fields: ctx, transactionID, paymentToken, virtualAcc, callbackUrl
+func (_m *MockDepositService) InsertPaymentToken(ctx context.Context, transactionID string, paymentToken string, virtualAcc string, callbackUrl string) error {
+	ret := _m.Called(ctx, transactionID, paymentToken, virtualAcc, callbackUrl)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
-		r0 = rf(transactionID, paymentToken, virtualAcc, callbackUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, transactionID, paymentToken, virtualAcc, callbackUrl)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -112,23 +156,49 @@ func (_m *MockDepositService) InsertPaymentToken(transactionID string, paymentTo
 	return r0
 }
 
-// SaveFile provides a mock function with given fields: file
-func (_m *MockDepositService) SaveFile(file *multipart.FileHeader) (string, error) {
-	ret := _m.Called(file)
+// RecentDeposits provides a mock function with given fields: ctx, idUser, limit
+func (_m *MockDepositService) RecentDeposits(ctx context.Context, idUser uint64, limit int) ([]entity.Deposit, error) {
+	ret := _m.Called(ctx, idUser, limit)
+
+	var r0 []entity.Deposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) ([]entity.Deposit, error)); ok {
+		return rf(ctx, idUser, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int) []entity.Deposit); ok {
+		r0 = rf(ctx, idUser, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Deposit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int) error); ok {
+		r1 = rf(ctx, idUser, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveFile provides a mock function with given fields: ctx, file
+func (_m *MockDepositService) SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	ret := _m.Called(ctx, file)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*multipart.FileHeader) (string, error)); ok {
-		return rf(file)
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader) (string, error)); ok {
+		return rf(ctx, file)
 	}
-	if rf, ok := ret.Get(0).(func(*multipart.FileHeader) string); ok {
-		r0 = rf(file)
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader) string); ok {
+		r0 = rf(ctx, file)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*multipart.FileHeader) error); ok {
-		r1 = rf(file)
+	if rf, ok := ret.Get(1).(func(context.Context, *multipart.FileHeader) error); ok {
+		r1 = rf(ctx, file)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -136,13 +206,93 @@ func (_m *MockDepositService) SaveFile(file *multipart.FileHeader) (string, erro
 	return r0, r1
 }
 
-// TotalDeposit provides a mock function with given fields:
-func (_m *MockDepositService) TotalDeposit() int64 {
-	ret := _m.Called()
+// SearchByDateAll provides a mock function with given fields: ctx, dateStart, dateEnd
+func (_m *MockDepositService) SearchByDateAll(ctx context.Context, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
+	ret := _m.Called(ctx, dateStart, dateEnd)
+
+	var r0 []entity.Deposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]entity.Deposit, error)); ok {
+		return rf(ctx, dateStart, dateEnd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []entity.Deposit); ok {
+		r0 = rf(ctx, dateStart, dateEnd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Deposit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, dateStart, dateEnd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchByDateIDUser provides a mock function with given fields: ctx, idUser, dateStart, dateEnd
+func (_m *MockDepositService) SearchByDateIDUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) ([]entity.Deposit, error) {
+	ret := _m.Called(ctx, idUser, dateStart, dateEnd)
+
+	var r0 []entity.Deposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64, int64) ([]entity.Deposit, error)); ok {
+		return rf(ctx, idUser, dateStart, dateEnd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64, int64) []entity.Deposit); ok {
+		r0 = rf(ctx, idUser, dateStart, dateEnd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Deposit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int64, int64) error); ok {
+		r1 = rf(ctx, idUser, dateStart, dateEnd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalDeposit provides a mock function with given fields: ctx
+func (_m *MockDepositService) TotalDeposit(ctx context.Context) int64 {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TotalDepositByDate provides a mock function with given fields: ctx, dateStart, dateEnd
+func (_m *MockDepositService) TotalDepositByDate(ctx context.Context, dateStart int64, dateEnd int64) int64 {
+	ret := _m.Called(ctx, dateStart, dateEnd)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) int64); ok {
+		r0 = rf(ctx, dateStart, dateEnd)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TotalDepositByDateIdUser provides a mock function with given fields: ctx, idUser, dateStart, dateEnd
+func (_m *MockDepositService) TotalDepositByDateIdUser(ctx context.Context, idUser uint64, dateStart int64, dateEnd int64) int64 {
+	ret := _m.Called(ctx, idUser, dateStart, dateEnd)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64, int64) int64); ok {
+		r0 = rf(ctx, idUser, dateStart, dateEnd)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -150,13 +300,13 @@ func (_m *MockDepositService) TotalDeposit() int64 {
 	return r0
 }
 
-// TotalDepositByUserID provides a mock function with given fields: idUser
-func (_m *MockDepositService) TotalDepositByUserID(idUser uint64) int64 {
-	ret := _m.Called(idUser)
+// TotalDepositByUserID provides a mock function with given fields: ctx, idUser
+func (_m *MockDepositService) TotalDepositByUserID(ctx context.Context, idUser uint64) int64 {
+	ret := _m.Called(ctx, idUser)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
-		r0 = rf(idUser)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) int64); ok {
+		r0 = rf(ctx, idUser)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -164,13 +314,13 @@ func (_m *MockDepositService) TotalDepositByUserID(idUser uint64) int64 {
 	return r0
 }
 
-// UpdateDepositStatus provides a mock function with given fields: orderID, newStatus
-func (_m *MockDepositService) UpdateDepositStatus(orderID string, newStatus uint64) error {
-	ret := _m.Called(orderID, newStatus)
+// UpdateDepositStatus provides a mock function with given fields: ctx, orderID, newStatus
+func (_m *MockDepositService) UpdateDepositStatus(ctx context.Context, orderID string, newStatus uint64) error {
+	ret := _m.Called(ctx, orderID, newStatus)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, uint64) error); ok {
-		r0 = rf(orderID, newStatus)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, orderID, newStatus)
 	} else {
 		r0 = ret.Error(0)
 	}
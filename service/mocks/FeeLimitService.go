@@ -0,0 +1,160 @@
+// Code generated by mockery v2.35.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/IrvanWijayaSardam/SelfBank/money"
+)
+
+// FeeLimitService is an autogenerated mock type for the FeeLimitService type
+type FeeLimitService struct {
+	mock.Mock
+}
+
+// AllFeeRules provides a mock function with given fields: ctx
+func (_m *FeeLimitService) AllFeeRules(ctx context.Context) ([]entity.FeeRule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.FeeRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.FeeRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.FeeRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.FeeRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AllLimitRules provides a mock function with given fields: ctx
+func (_m *FeeLimitService) AllLimitRules(ctx context.Context) ([]entity.LimitRule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.LimitRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.LimitRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.LimitRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LimitRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Evaluate provides a mock function with given fields: ctx, idUser, trxType, paymentMethod, amount
+func (_m *FeeLimitService) Evaluate(ctx context.Context, idUser uint64, trxType string, paymentMethod string, amount money.Money) (money.Money, error) {
+	ret := _m.Called(ctx, idUser, trxType, paymentMethod, amount)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, string, money.Money) (money.Money, error)); ok {
+		return rf(ctx, idUser, trxType, paymentMethod, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, string, money.Money) money.Money); ok {
+		r0 = rf(ctx, idUser, trxType, paymentMethod, amount)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string, string, money.Money) error); ok {
+		r1 = rf(ctx, idUser, trxType, paymentMethod, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordFee provides a mock function with given fields: ctx, idUser, trxType, referenceID, fee
+func (_m *FeeLimitService) RecordFee(ctx context.Context, idUser uint64, trxType string, referenceID string, fee money.Money) {
+	_m.Called(ctx, idUser, trxType, referenceID, fee)
+}
+
+// SaveFeeRule provides a mock function with given fields: ctx, rule
+func (_m *FeeLimitService) SaveFeeRule(ctx context.Context, rule entity.FeeRule) (entity.FeeRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	var r0 entity.FeeRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FeeRule) (entity.FeeRule, error)); ok {
+		return rf(ctx, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FeeRule) entity.FeeRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Get(0).(entity.FeeRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.FeeRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveLimitRule provides a mock function with given fields: ctx, rule
+func (_m *FeeLimitService) SaveLimitRule(ctx context.Context, rule entity.LimitRule) (entity.LimitRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	var r0 entity.LimitRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LimitRule) (entity.LimitRule, error)); ok {
+		return rf(ctx, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LimitRule) entity.LimitRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Get(0).(entity.LimitRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.LimitRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFeeLimitService creates a new instance of FeeLimitService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFeeLimitService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FeeLimitService {
+	mock := &FeeLimitService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
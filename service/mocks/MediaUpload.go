@@ -0,0 +1,53 @@
+// Code generated by mockery v2.35.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/IrvanWijayaSardam/SelfBank/dto"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MediaUpload is an autogenerated mock type for the MediaUpload type
+type MediaUpload struct {
+	mock.Mock
+}
+
+// FileUpload provides a mock function with given fields: ctx, file
+func (_m *MediaUpload) FileUpload(ctx context.Context, file dto.File) (dto.UploadedImage, error) {
+	ret := _m.Called(ctx, file)
+
+	var r0 dto.UploadedImage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.File) (dto.UploadedImage, error)); ok {
+		return rf(ctx, file)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.File) dto.UploadedImage); ok {
+		r0 = rf(ctx, file)
+	} else {
+		r0 = ret.Get(0).(dto.UploadedImage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.File) error); ok {
+		r1 = rf(ctx, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMediaUpload creates a new instance of MediaUpload. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMediaUpload(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MediaUpload {
+	mock := &MediaUpload{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,8 +4,10 @@ package mocks
 
 import (
 	bytes "bytes"
+	context "context"
 
 	dto "github.com/IrvanWijayaSardam/SelfBank/dto"
+
 	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
 
 	mock "github.com/stretchr/testify/mock"
@@ -16,25 +18,25 @@ type TransactionService struct {
 	mock.Mock
 }
 
-// All provides a mock function with given fields: page, pageSize
-func (_m *TransactionService) All(page int, pageSize int) ([]entity.Transaction, error) {
-	ret := _m.Called(page, pageSize)
+// All provides a mock function with given fields: ctx, page, pageSize
+func (_m *TransactionService) All(ctx context.Context, page int, pageSize int) ([]entity.Transaction, error) {
+	ret := _m.Called(ctx, page, pageSize)
 
 	var r0 []entity.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]entity.Transaction, error)); ok {
-		return rf(page, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Transaction, error)); ok {
+		return rf(ctx, page, pageSize)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []entity.Transaction); ok {
-		r0 = rf(page, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Transaction); ok {
+		r0 = rf(ctx, page, pageSize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(page, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,41 +44,87 @@ func (_m *TransactionService) All(page int, pageSize int) ([]entity.Transaction,
 	return r0, r1
 }
 
-// FindTransactionByID provides a mock function with given fields: id
-func (_m *TransactionService) FindTransactionByID(id uint64) *entity.Transaction {
-	ret := _m.Called(id)
+// ConfirmTransfer provides a mock function with given fields: ctx, quote
+func (_m *TransactionService) ConfirmTransfer(ctx context.Context, quote entity.TransferQuote) (entity.Transaction, error) {
+	ret := _m.Called(ctx, quote)
 
-	var r0 *entity.Transaction
-	if rf, ok := ret.Get(0).(func(uint64) *entity.Transaction); ok {
-		r0 = rf(id)
+	var r0 entity.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TransferQuote) (entity.Transaction, error)); ok {
+		return rf(ctx, quote)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TransferQuote) entity.Transaction); ok {
+		r0 = rf(ctx, quote)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Transaction)
-		}
+		r0 = ret.Get(0).(entity.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.TransferQuote) error); ok {
+		r1 = rf(ctx, quote)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindQuote provides a mock function with given fields: ctx, quoteID
+func (_m *TransactionService) FindQuote(ctx context.Context, quoteID string) (entity.TransferQuote, error) {
+	ret := _m.Called(ctx, quoteID)
+
+	var r0 entity.TransferQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.TransferQuote, error)); ok {
+		return rf(ctx, quoteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.TransferQuote); ok {
+		r0 = rf(ctx, quoteID)
+	} else {
+		r0 = ret.Get(0).(entity.TransferQuote)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, quoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTransactionByID provides a mock function with given fields: ctx, id
+func (_m *TransactionService) FindTransactionByID(ctx context.Context, id uint64) entity.Transaction {
+	ret := _m.Called(ctx, id)
+
+	var r0 entity.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) entity.Transaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.Transaction)
 	}
 
 	return r0
 }
 
-// FindTransactionByIDUser provides a mock function with given fields: idUiser, int1, pageSize
-func (_m *TransactionService) FindTransactionByIDUser(idUiser uint64, int1 int, pageSize int) ([]entity.Transaction, error) {
-	ret := _m.Called(idUiser, int1, pageSize)
+// FindTransactionByIDUser provides a mock function with given fields: ctx, idUiser, int2, pageSize
+func (_m *TransactionService) FindTransactionByIDUser(ctx context.Context, idUiser uint64, int2 int, pageSize int) ([]entity.Transaction, error) {
+	ret := _m.Called(ctx, idUiser, int2, pageSize)
 
 	var r0 []entity.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, int, int) ([]entity.Transaction, error)); ok {
-		return rf(idUiser, int1, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) ([]entity.Transaction, error)); ok {
+		return rf(ctx, idUiser, int2, pageSize)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, int, int) []entity.Transaction); ok {
-		r0 = rf(idUiser, int1, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) []entity.Transaction); ok {
+		r0 = rf(ctx, idUiser, int2, pageSize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, int, int) error); ok {
-		r1 = rf(idUiser, int1, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int, int) error); ok {
+		r1 = rf(ctx, idUiser, int2, pageSize)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,13 +158,37 @@ func (_m *TransactionService) GenerateTransactionPDF(Transactions []entity.Trans
 	return r0, r1
 }
 
-// InsertTransaction provides a mock function with given fields: Transaction
-func (_m *TransactionService) InsertTransaction(Transaction dto.TransactionDTO) entity.Transaction {
-	ret := _m.Called(Transaction)
+// Inquiry provides a mock function with given fields: ctx, idUser, accNumberFrom, inquiry
+func (_m *TransactionService) Inquiry(ctx context.Context, idUser uint64, accNumberFrom uint64, inquiry dto.TransferInquiryDTO) (dto.TransferInquiryResponse, error) {
+	ret := _m.Called(ctx, idUser, accNumberFrom, inquiry)
+
+	var r0 dto.TransferInquiryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, dto.TransferInquiryDTO) (dto.TransferInquiryResponse, error)); ok {
+		return rf(ctx, idUser, accNumberFrom, inquiry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, dto.TransferInquiryDTO) dto.TransferInquiryResponse); ok {
+		r0 = rf(ctx, idUser, accNumberFrom, inquiry)
+	} else {
+		r0 = ret.Get(0).(dto.TransferInquiryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, dto.TransferInquiryDTO) error); ok {
+		r1 = rf(ctx, idUser, accNumberFrom, inquiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertTransaction provides a mock function with given fields: ctx, Transaction
+func (_m *TransactionService) InsertTransaction(ctx context.Context, Transaction dto.TransactionDTO) entity.Transaction {
+	ret := _m.Called(ctx, Transaction)
 
 	var r0 entity.Transaction
-	if rf, ok := ret.Get(0).(func(dto.TransactionDTO) entity.Transaction); ok {
-		r0 = rf(Transaction)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TransactionDTO) entity.Transaction); ok {
+		r0 = rf(ctx, Transaction)
 	} else {
 		r0 = ret.Get(0).(entity.Transaction)
 	}
@@ -124,13 +196,39 @@ func (_m *TransactionService) InsertTransaction(Transaction dto.TransactionDTO)
 	return r0
 }
 
-// TotalTransaction provides a mock function with given fields:
-func (_m *TransactionService) TotalTransaction() int64 {
-	ret := _m.Called()
+// RecentTransactions provides a mock function with given fields: ctx, idUser, accNumber, limit
+func (_m *TransactionService) RecentTransactions(ctx context.Context, idUser uint64, accNumber uint64, limit int) ([]entity.Transaction, error) {
+	ret := _m.Called(ctx, idUser, accNumber, limit)
+
+	var r0 []entity.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, int) ([]entity.Transaction, error)); ok {
+		return rf(ctx, idUser, accNumber, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, int) []entity.Transaction); ok {
+		r0 = rf(ctx, idUser, accNumber, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, int) error); ok {
+		r1 = rf(ctx, idUser, accNumber, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalTransaction provides a mock function with given fields: ctx
+func (_m *TransactionService) TotalTransaction(ctx context.Context) int64 {
+	ret := _m.Called(ctx)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -138,13 +236,13 @@ func (_m *TransactionService) TotalTransaction() int64 {
 	return r0
 }
 
-// TotalTransactionByUserID provides a mock function with given fields: idUser
-func (_m *TransactionService) TotalTransactionByUserID(idUser uint64) int64 {
-	ret := _m.Called(idUser)
+// TotalTransactionByUserID provides a mock function with given fields: ctx, idUser
+func (_m *TransactionService) TotalTransactionByUserID(ctx context.Context, idUser uint64) int64 {
+	ret := _m.Called(ctx, idUser)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
-		r0 = rf(idUser)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) int64); ok {
+		r0 = rf(ctx, idUser)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -152,13 +250,13 @@ func (_m *TransactionService) TotalTransactionByUserID(idUser uint64) int64 {
 	return r0
 }
 
-// UpdateTransactionStatus provides a mock function with given fields: orderID, newStatus
-func (_m *TransactionService) UpdateTransactionStatus(orderID uint64, newStatus uint64) error {
-	ret := _m.Called(orderID, newStatus)
+// UpdateTransactionStatus provides a mock function with given fields: ctx, orderID, newStatus
+func (_m *TransactionService) UpdateTransactionStatus(ctx context.Context, orderID uint64, newStatus uint64) error {
+	ret := _m.Called(ctx, orderID, newStatus)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
-		r0 = rf(orderID, newStatus)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) error); ok {
+		r0 = rf(ctx, orderID, newStatus)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -166,13 +264,13 @@ func (_m *TransactionService) UpdateTransactionStatus(orderID uint64, newStatus
 	return r0
 }
 
-// ValidateAccNumber provides a mock function with given fields: accNumber
-func (_m *TransactionService) ValidateAccNumber(accNumber uint64) bool {
-	ret := _m.Called(accNumber)
+// ValidateAccNumber provides a mock function with given fields: ctx, accNumber
+func (_m *TransactionService) ValidateAccNumber(ctx context.Context, accNumber uint64) bool {
+	ret := _m.Called(ctx, accNumber)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(uint64) bool); ok {
-		r0 = rf(accNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) bool); ok {
+		r0 = rf(ctx, accNumber)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
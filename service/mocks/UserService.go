@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/IrvanWijayaSardam/SelfBank/money"
 )
 
 // UserService is an autogenerated mock type for the UserService type
@@ -12,41 +16,111 @@ type UserService struct {
 	mock.Mock
 }
 
-// FindUser provides a mock function with given fields: id
-func (_m *UserService) FindUser(id uint64) entity.User {
-	ret := _m.Called(id)
+// All provides a mock function with given fields: ctx, page, pageSize
+func (_m *UserService) All(ctx context.Context, page int, pageSize int) ([]entity.User, error) {
+	ret := _m.Called(ctx, page, pageSize)
+
+	var r0 []entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.User, error)); ok {
+		return rf(ctx, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.User); ok {
+		r0 = rf(ctx, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUser provides a mock function with given fields: ctx, id
+func (_m *UserService) FindUser(ctx context.Context, id uint64) (entity.User, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(uint64) entity.User); ok {
-		r0 = rf(id)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (entity.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) entity.User); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetSaldo provides a mock function with given fields: idUser
-func (_m *UserService) GetSaldo(idUser uint64) int64 {
-	ret := _m.Called(idUser)
+// GetBalance provides a mock function with given fields: ctx, idUser, currency
+func (_m *UserService) GetBalance(ctx context.Context, idUser uint64, currency string) (money.Money, error) {
+	ret := _m.Called(ctx, idUser, currency)
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
-		r0 = rf(idUser)
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (money.Money, error)); ok {
+		return rf(ctx, idUser, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) money.Money); ok {
+		r0 = rf(ctx, idUser, currency)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(money.Money)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, idUser, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSaldo provides a mock function with given fields: ctx, idUser
+func (_m *UserService) GetSaldo(ctx context.Context, idUser uint64) (money.Money, error) {
+	ret := _m.Called(ctx, idUser)
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (money.Money, error)); ok {
+		return rf(ctx, idUser)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) money.Money); ok {
+		r0 = rf(ctx, idUser)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, idUser)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: user
-func (_m *UserService) UpdateUser(user entity.User) entity.User {
-	ret := _m.Called(user)
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *UserService) UpdateUser(ctx context.Context, user entity.User) entity.User {
+	ret := _m.Called(ctx, user)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(entity.User) entity.User); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) entity.User); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	bytes "bytes"
+	context "context"
+
 	dto "github.com/IrvanWijayaSardam/SelfBank/dto"
+
 	entity "github.com/IrvanWijayaSardam/SelfBank/entity"
 
 	mock "github.com/stretchr/testify/mock"
@@ -16,25 +20,25 @@ type WithdrawalService struct {
 	mock.Mock
 }
 
-// All provides a mock function with given fields: page, pageSize
-func (_m *WithdrawalService) All(page int, pageSize int) ([]entity.Withdrawal, error) {
-	ret := _m.Called(page, pageSize)
+// All provides a mock function with given fields: ctx, page, pageSize
+func (_m *WithdrawalService) All(ctx context.Context, page int, pageSize int) ([]entity.Withdrawal, error) {
+	ret := _m.Called(ctx, page, pageSize)
 
 	var r0 []entity.Withdrawal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]entity.Withdrawal, error)); ok {
-		return rf(page, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Withdrawal, error)); ok {
+		return rf(ctx, page, pageSize)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []entity.Withdrawal); ok {
-		r0 = rf(page, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Withdrawal); ok {
+		r0 = rf(ctx, page, pageSize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Withdrawal)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(page, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,13 +46,13 @@ func (_m *WithdrawalService) All(page int, pageSize int) ([]entity.Withdrawal, e
 	return r0, r1
 }
 
-// FindWithdrawalByID provides a mock function with given fields: id
-func (_m *WithdrawalService) FindWithdrawalByID(id uint64) *entity.Withdrawal {
-	ret := _m.Called(id)
+// FindWithdrawalByID provides a mock function with given fields: ctx, id
+func (_m *WithdrawalService) FindWithdrawalByID(ctx context.Context, id uint64) *entity.Withdrawal {
+	ret := _m.Called(ctx, id)
 
 	var r0 *entity.Withdrawal
-	if rf, ok := ret.Get(0).(func(uint64) *entity.Withdrawal); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *entity.Withdrawal); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Withdrawal)
@@ -58,25 +62,51 @@ func (_m *WithdrawalService) FindWithdrawalByID(id uint64) *entity.Withdrawal {
 	return r0
 }
 
-// FindWithdrawalByIDUser provides a mock function with given fields: idUiser, int1, pageSize
-func (_m *WithdrawalService) FindWithdrawalByIDUser(idUiser uint64, int1 int, pageSize int) ([]entity.Withdrawal, error) {
-	ret := _m.Called(idUiser, int1, pageSize)
+// FindWithdrawalByIDUser provides a mock function with given fields: ctx, idUiser, int2, pageSize
+func (_m *WithdrawalService) FindWithdrawalByIDUser(ctx context.Context, idUiser uint64, int2 int, pageSize int) ([]entity.Withdrawal, error) {
+	ret := _m.Called(ctx, idUiser, int2, pageSize)
 
 	var r0 []entity.Withdrawal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, int, int) ([]entity.Withdrawal, error)); ok {
-		return rf(idUiser, int1, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) ([]entity.Withdrawal, error)); ok {
+		return rf(ctx, idUiser, int2, pageSize)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, int, int) []entity.Withdrawal); ok {
-		r0 = rf(idUiser, int1, pageSize)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int, int) []entity.Withdrawal); ok {
+		r0 = rf(ctx, idUiser, int2, pageSize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Withdrawal)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, int, int) error); ok {
-		r1 = rf(idUiser, int1, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int, int) error); ok {
+		r1 = rf(ctx, idUiser, int2, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateWithdrawalPDF provides a mock function with given fields: Transactions
+func (_m *WithdrawalService) GenerateWithdrawalPDF(Transactions []entity.Withdrawal) (*bytes.Buffer, error) {
+	ret := _m.Called(Transactions)
+
+	var r0 *bytes.Buffer
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]entity.Withdrawal) (*bytes.Buffer, error)); ok {
+		return rf(Transactions)
+	}
+	if rf, ok := ret.Get(0).(func([]entity.Withdrawal) *bytes.Buffer); ok {
+		r0 = rf(Transactions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bytes.Buffer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]entity.Withdrawal) error); ok {
+		r1 = rf(Transactions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,13 +114,13 @@ func (_m *WithdrawalService) FindWithdrawalByIDUser(idUiser uint64, int1 int, pa
 	return r0, r1
 }
 
-// InsertWithdrawal provides a mock function with given fields: Withdrawal
-func (_m *WithdrawalService) InsertWithdrawal(Withdrawal dto.WithdrawalDTO) entity.Withdrawal {
-	ret := _m.Called(Withdrawal)
+// InsertWithdrawal provides a mock function with given fields: ctx, Withdrawal
+func (_m *WithdrawalService) InsertWithdrawal(ctx context.Context, Withdrawal dto.WithdrawalDTO) entity.Withdrawal {
+	ret := _m.Called(ctx, Withdrawal)
 
 	var r0 entity.Withdrawal
-	if rf, ok := ret.Get(0).(func(dto.WithdrawalDTO) entity.Withdrawal); ok {
-		r0 = rf(Withdrawal)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.WithdrawalDTO) entity.Withdrawal); ok {
+		r0 = rf(ctx, Withdrawal)
 	} else {
 		r0 = ret.Get(0).(entity.Withdrawal)
 	}
@@ -98,23 +128,23 @@ func (_m *WithdrawalService) InsertWithdrawal(Withdrawal dto.WithdrawalDTO) enti
 	return r0
 }
 
-// SaveFile provides a mock function with given fields: file
-func (_m *WithdrawalService) SaveFile(file *multipart.FileHeader) (string, error) {
-	ret := _m.Called(file)
+// SaveFile provides a mock function with given fields: ctx, file
+func (_m *WithdrawalService) SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	ret := _m.Called(ctx, file)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*multipart.FileHeader) (string, error)); ok {
-		return rf(file)
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader) (string, error)); ok {
+		return rf(ctx, file)
 	}
-	if rf, ok := ret.Get(0).(func(*multipart.FileHeader) string); ok {
-		r0 = rf(file)
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader) string); ok {
+		r0 = rf(ctx, file)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*multipart.FileHeader) error); ok {
-		r1 = rf(file)
+	if rf, ok := ret.Get(1).(func(context.Context, *multipart.FileHeader) error); ok {
+		r1 = rf(ctx, file)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,13 +152,13 @@ func (_m *WithdrawalService) SaveFile(file *multipart.FileHeader) (string, error
 	return r0, r1
 }
 
-// TotalWithdrawal provides a mock function with given fields:
-func (_m *WithdrawalService) TotalWithdrawal() int64 {
-	ret := _m.Called()
+// TotalWithdrawal provides a mock function with given fields: ctx
+func (_m *WithdrawalService) TotalWithdrawal(ctx context.Context) int64 {
+	ret := _m.Called(ctx)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -136,13 +166,13 @@ func (_m *WithdrawalService) TotalWithdrawal() int64 {
 	return r0
 }
 
-// TotalWithdrawalByUserID provides a mock function with given fields: idUser
-func (_m *WithdrawalService) TotalWithdrawalByUserID(idUser uint64) int64 {
-	ret := _m.Called(idUser)
+// TotalWithdrawalByUserID provides a mock function with given fields: ctx, idUser
+func (_m *WithdrawalService) TotalWithdrawalByUserID(ctx context.Context, idUser uint64) int64 {
+	ret := _m.Called(ctx, idUser)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
-		r0 = rf(idUser)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) int64); ok {
+		r0 = rf(ctx, idUser)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
@@ -150,13 +180,13 @@ func (_m *WithdrawalService) TotalWithdrawalByUserID(idUser uint64) int64 {
 	return r0
 }
 
-// UpdateWithdrawalStatus provides a mock function with given fields: orderID, newStatus
-func (_m *WithdrawalService) UpdateWithdrawalStatus(orderID uint64, newStatus uint64) error {
-	ret := _m.Called(orderID, newStatus)
+// UpdateWithdrawalStatus provides a mock function with given fields: ctx, orderID, newStatus
+func (_m *WithdrawalService) UpdateWithdrawalStatus(ctx context.Context, orderID uint64, newStatus uint64) error {
+	ret := _m.Called(ctx, orderID, newStatus)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
-		r0 = rf(orderID, newStatus)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) error); ok {
+		r0 = rf(ctx, orderID, newStatus)
 	} else {
 		r0 = ret.Error(0)
 	}
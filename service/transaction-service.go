@@ -2,93 +2,238 @@ package service
 
 import (
 	"bytes"
-	"errors"
+	"context"
 	"fmt"
-	"log"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
 	"github.com/IrvanWijayaSardam/SelfBank/repository"
 	"github.com/jung-kurt/gofpdf"
 
+	"github.com/google/uuid"
 	"github.com/mashingan/smapping"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	transferQuoteTTL          = 5 * time.Minute
+	defaultTransferCategory   = "General"
+	maxTransferNoteLength     = 255
+	maxTransferCategoryLength = 50
 )
 
 type TransactionService interface {
-	InsertTransaction(Transaction dto.TransactionDTO) entity.Transaction
-	All(page int, pageSize int) ([]entity.Transaction, error)
-	FindTransactionByIDUser(idUiser uint64, int, pageSize int) ([]entity.Transaction, error)
-	FindTransactionByID(id uint64) entity.Transaction
-	TotalTransaction() int64
-	TotalTransactionByUserID(idUser uint64) int64
-	UpdateTransactionStatus(orderID uint64, newStatus uint64) error
-	ValidateAccNumber(accNumber uint64) bool
+	InsertTransaction(ctx context.Context, Transaction dto.TransactionDTO) entity.Transaction
+	All(ctx context.Context, page int, pageSize int) ([]entity.Transaction, error)
+	FindTransactionByIDUser(ctx context.Context, idUiser uint64, int, pageSize int) ([]entity.Transaction, error)
+	RecentTransactions(ctx context.Context, idUser uint64, accNumber uint64, limit int) ([]entity.Transaction, error)
+	FindTransactionByID(ctx context.Context, id uint64) entity.Transaction
+	TotalTransaction(ctx context.Context) int64
+	TotalTransactionByUserID(ctx context.Context, idUser uint64) int64
+	UpdateTransactionStatus(ctx context.Context, orderID uint64, newStatus uint64) error
+	ValidateAccNumber(ctx context.Context, accNumber uint64) bool
 	GenerateTransactionPDF(Transactions []entity.Transaction) (*bytes.Buffer, error)
+	Inquiry(ctx context.Context, idUser uint64, accNumberFrom uint64, inquiry dto.TransferInquiryDTO) (dto.TransferInquiryResponse, error)
+	FindQuote(ctx context.Context, quoteID string) (entity.TransferQuote, error)
+	ConfirmTransfer(ctx context.Context, quote entity.TransferQuote) (entity.Transaction, error)
 }
 
 type transactionService struct {
-	TransactionRepository repository.TransactionRepository
+	TransactionRepository   repository.TransactionRepository
+	TransferQuoteRepository repository.TransferQuoteRepository
+	FeeLimitService         FeeLimitService
 }
 
-func NewTransactionService(fundRep repository.TransactionRepository) TransactionService {
+func NewTransactionService(fundRep repository.TransactionRepository, quoteRep repository.TransferQuoteRepository, feeLimitService FeeLimitService) TransactionService {
 	return &transactionService{
-		TransactionRepository: fundRep,
+		TransactionRepository:   fundRep,
+		TransferQuoteRepository: quoteRep,
+		FeeLimitService:         feeLimitService,
 	}
 }
 
-func (service *transactionService) InsertTransaction(b dto.TransactionDTO) entity.Transaction {
+func (service *transactionService) InsertTransaction(ctx context.Context, b dto.TransactionDTO) entity.Transaction {
 	Transaction := entity.Transaction{}
 	err := smapping.FillStruct(&Transaction, smapping.MapFields(&b))
 	if err != nil {
-		log.Fatalf("Failed map %v", err)
+		logrus.Fatalf("Failed map %v", err)
 	}
-	res := service.TransactionRepository.InsertTransaction(&Transaction)
+	Transaction.Category = normalizeCategory(Transaction.Category)
+	res := service.TransactionRepository.InsertTransaction(ctx, &Transaction)
+	service.FeeLimitService.RecordFee(ctx, res.ID_User, entity.TrxTypeTransfer, strconv.FormatUint(res.ID, 10), res.Fee)
+	recordTransfer(metrics.KindTransfer, res.Amount)
 	return res
 }
 
-func (service *transactionService) TotalTransaction() int64 {
-	return service.TransactionRepository.TotalTransaction()
+func (service *transactionService) Inquiry(ctx context.Context, idUser uint64, accNumberFrom uint64, inquiry dto.TransferInquiryDTO) (dto.TransferInquiryResponse, error) {
+	if !inquiry.Amount.IsPositive() {
+		return dto.TransferInquiryResponse{}, apperror.ErrInvalidAmount
+	}
+	if inquiry.TransactionTo == accNumberFrom {
+		return dto.TransferInquiryResponse{}, apperror.ErrSameAccount
+	}
+	if len(inquiry.Note) > maxTransferNoteLength {
+		return dto.TransferInquiryResponse{}, apperror.ErrNoteTooLong.With(apperror.Params{"max": strconv.Itoa(maxTransferNoteLength)})
+	}
+	if len(inquiry.Category) > maxTransferCategoryLength {
+		return dto.TransferInquiryResponse{}, apperror.ErrCategoryTooLong.With(apperror.Params{"max": strconv.Itoa(maxTransferCategoryLength)})
+	}
+
+	recipient := service.TransactionRepository.FindUserByAccNumber(ctx, inquiry.TransactionTo)
+	if recipient.ID == 0 {
+		return dto.TransferInquiryResponse{}, apperror.ErrAccountNotFound
+	}
+
+	fee, err := service.FeeLimitService.Evaluate(ctx, idUser, entity.TrxTypeTransfer, "", inquiry.Amount)
+	if err != nil {
+		return dto.TransferInquiryResponse{}, err
+	}
+
+	expiresAt := time.Now().Add(transferQuoteTTL)
+	quote := entity.TransferQuote{
+		ID:              uuid.New().String(),
+		ID_User:         idUser,
+		TransactionFrom: accNumberFrom,
+		TransactionTo:   inquiry.TransactionTo,
+		RecipientName:   helper.MaskName(strings.TrimSpace(recipient.Namadepan + " " + recipient.Namabelakang)),
+		Amount:          inquiry.Amount,
+		Fee:             fee,
+		Note:            strings.TrimSpace(inquiry.Note),
+		Category:        normalizeCategory(inquiry.Category),
+		ExpiresAt:       expiresAt.Unix(),
+	}
+
+	total, err := quote.Amount.Add(quote.Fee)
+	if err != nil {
+		return dto.TransferInquiryResponse{}, err
+	}
+
+	err = service.TransferQuoteRepository.InsertQuote(ctx, quote, transferQuoteTTL)
+	if err != nil {
+		return dto.TransferInquiryResponse{}, err
+	}
+
+	return dto.TransferInquiryResponse{
+		QuoteID:         quote.ID,
+		AccountNumberTo: quote.TransactionTo,
+		RecipientName:   quote.RecipientName,
+		Amount:          quote.Amount,
+		Fee:             quote.Fee,
+		Total:           total,
+		Note:            quote.Note,
+		Category:        quote.Category,
+		ExpiresAt:       helper.ConvertUnixtime(quote.ExpiresAt).Format("2006-01-02 15:04:05"),
+	}, nil
 }
 
-func (service *transactionService) ValidateAccNumber(accNumber uint64) bool {
-	return service.TransactionRepository.ValidateAccNumber(accNumber)
+func (service *transactionService) FindQuote(ctx context.Context, quoteID string) (entity.TransferQuote, error) {
+	return service.TransferQuoteRepository.FindQuote(ctx, quoteID)
 }
 
-func (service *transactionService) TotalTransactionByUserID(idUser uint64) int64 {
-	return service.TransactionRepository.TotalTransactionByUserID(idUser)
+func (service *transactionService) ConfirmTransfer(ctx context.Context, quote entity.TransferQuote) (entity.Transaction, error) {
+	if quote.ExpiresAt < helper.GetCurrentTimeInLocation() {
+		return entity.Transaction{}, apperror.ErrQuoteExpired
+	}
+
+	// Limits are checked again because other transfers may have been made
+	// since the inquiry. The quoted fee is kept as shown to the user.
+	_, err := service.FeeLimitService.Evaluate(ctx, quote.ID_User, entity.TrxTypeTransfer, "", quote.Amount)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+
+	// Consume the quote before posting, only the confirmation that deletes it
+	// posts the transfer, so the same quote can never be executed twice
+	err = service.TransferQuoteRepository.ConsumeQuote(ctx, quote.ID)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+
+	Transaction := entity.Transaction{
+		ID_User:         quote.ID_User,
+		TransactionFrom: quote.TransactionFrom,
+		TransactionTo:   quote.TransactionTo,
+		Amount:          quote.Amount,
+		Fee:             quote.Fee,
+		Note:            quote.Note,
+		Category:        quote.Category,
+	}
+	res := service.TransactionRepository.InsertTransaction(ctx, &Transaction)
+	service.FeeLimitService.RecordFee(ctx, res.ID_User, entity.TrxTypeTransfer, strconv.FormatUint(res.ID, 10), res.Fee)
+	recordTransfer(metrics.KindTransfer, res.Amount)
+	return res, nil
 }
 
-func (service *transactionService) All(page int, pageSize int) ([]entity.Transaction, error) {
+// recordTransfer counts money leaving an account. Only rupiah amounts add to
+// the volume so the series stays in a single currency.
+func recordTransfer(kind string, amount money.Money) {
+	metrics.TransfersTotal.WithLabelValues(kind).Inc()
+	if amount.Currency == money.IDR {
+		metrics.TransferVolume.WithLabelValues(kind).Add(float64(amount.Amount))
+	}
+}
+
+func normalizeCategory(category string) string {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return defaultTransferCategory
+	}
+	return category
+}
+
+func (service *transactionService) TotalTransaction(ctx context.Context) int64 {
+	return service.TransactionRepository.TotalTransaction(ctx)
+}
+
+func (service *transactionService) ValidateAccNumber(ctx context.Context, accNumber uint64) bool {
+	return service.TransactionRepository.ValidateAccNumber(ctx, accNumber)
+}
+
+func (service *transactionService) TotalTransactionByUserID(ctx context.Context, idUser uint64) int64 {
+	return service.TransactionRepository.TotalTransactionByUserID(ctx, idUser)
+}
+
+func (service *transactionService) All(ctx context.Context, page int, pageSize int) ([]entity.Transaction, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
-	return service.TransactionRepository.All(page, pageSize)
+	return service.TransactionRepository.All(ctx, page, pageSize)
 }
 
-func (service *transactionService) FindTransactionByIDUser(idUser uint64, page int, pageSize int) ([]entity.Transaction, error) {
+func (service *transactionService) FindTransactionByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.Transaction, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
-	return service.TransactionRepository.FindTransactionByIDUser(idUser, page, pageSize)
+	return service.TransactionRepository.FindTransactionByIDUser(ctx, idUser, page, pageSize)
+}
+
+func (service *transactionService) RecentTransactions(ctx context.Context, idUser uint64, accNumber uint64, limit int) ([]entity.Transaction, error) {
+	return service.TransactionRepository.RecentTransactions(ctx, idUser, accNumber, limit)
 }
 
-func (service *transactionService) FindTransactionByID(id uint64) entity.Transaction {
-	return service.TransactionRepository.FindTransactionByID(id)
+func (service *transactionService) FindTransactionByID(ctx context.Context, id uint64) entity.Transaction {
+	return service.TransactionRepository.FindTransactionByID(ctx, id)
 }
 
-func (service *transactionService) UpdateTransactionStatus(orderID uint64, newStatus uint64) error {
+func (service *transactionService) UpdateTransactionStatus(ctx context.Context, orderID uint64, newStatus uint64) error {
 	// Fetch the MasterJual entity by order ID
-	masterJual := service.TransactionRepository.FindTransactionByID(orderID)
+	masterJual := service.TransactionRepository.FindTransactionByID(ctx, orderID)
 	if masterJual.ID == 0 {
-		return fmt.Errorf("MasterJual not found for order ID %s", orderID)
+		return apperror.ErrNotFound.WithDetail(fmt.Sprintf("MasterJual not found for order ID %d", orderID))
 	}
 
 	masterJual.Status = newStatus
 
-	err := service.TransactionRepository.UpdateTransactionStatus(masterJual.ID, newStatus)
+	err := service.TransactionRepository.UpdateTransactionStatus(ctx, masterJual.ID, newStatus)
 	if err != nil {
 		return err
 	}
@@ -113,7 +258,7 @@ func (service *transactionService) GenerateTransactionPDF(Transactions []entity.
 	pdf.SetFont("Arial", "", 12)
 	for _, transaction := range Transactions {
 		pdf.CellFormat(40, 10, fmt.Sprintf("%d", transaction.ID), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(40, 10, helper.Uint64ToString(transaction.Amount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(40, 10, transaction.Amount.Format(), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(50, 10, helper.ConvertUnixtime(transaction.Date).Format("2006-01-02 15:04:05"), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(60, 10, helper.Uint64ToString(transaction.TransactionTo), "1", 1, "C", false, 0, "")
 	}
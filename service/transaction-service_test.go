@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+	repomocks "github.com/IrvanWijayaSardam/SelfBank/repository/mocks"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+	"github.com/IrvanWijayaSardam/SelfBank/service/mocks"
+)
+
+func TestTransactionService_ConfirmTransfer(t *testing.T) {
+	quote := entity.TransferQuote{
+		ID:              "quote-1",
+		ID_User:         1,
+		TransactionFrom: 1000000018,
+		TransactionTo:   1000000026,
+		Amount:          money.Rupiah(50000),
+		Fee:             money.Rupiah(2500),
+		ExpiresAt:       time.Now().Add(time.Minute).Unix(),
+	}
+
+	t.Run("Concurrent Confirms Post Once", func(t *testing.T) {
+		transactionRepository := repomocks.NewTransactionRepository(t)
+		quoteRepository := repomocks.NewTransferQuoteRepository(t)
+		feeLimitService := mocks.NewFeeLimitService(t)
+		transactionService := service.NewTransactionService(transactionRepository, quoteRepository, feeLimitService)
+
+		feeLimitService.On("Evaluate", mock.Anything, quote.ID_User, entity.TrxTypeTransfer, "", quote.Amount).Return(quote.Fee, nil)
+		// Redis deletes the quote for the first confirmation only.
+		quoteRepository.On("ConsumeQuote", mock.Anything, quote.ID).Return(nil).Once()
+		quoteRepository.On("ConsumeQuote", mock.Anything, quote.ID).Return(apperror.ErrQuoteExpired)
+		transactionRepository.On("InsertTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(entity.Transaction{ID: 1, ID_User: quote.ID_User, Amount: quote.Amount, Fee: quote.Fee}).Once()
+		feeLimitService.On("RecordFee", mock.Anything, quote.ID_User, entity.TrxTypeTransfer, "1", quote.Fee).Return().Once()
+
+		const confirms = 10
+		var wg sync.WaitGroup
+		errs := make(chan error, confirms)
+		for i := 0; i < confirms; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := transactionService.ConfirmTransfer(context.Background(), quote)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		posted := 0
+		for err := range errs {
+			if err == nil {
+				posted++
+			} else {
+				assert.ErrorIs(t, err, apperror.ErrQuoteExpired)
+			}
+		}
+		assert.Equal(t, 1, posted)
+		transactionRepository.AssertNumberOfCalls(t, "InsertTransaction", 1)
+	})
+
+	t.Run("Expired Quote", func(t *testing.T) {
+		transactionRepository := repomocks.NewTransactionRepository(t)
+		quoteRepository := repomocks.NewTransferQuoteRepository(t)
+		feeLimitService := mocks.NewFeeLimitService(t)
+		transactionService := service.NewTransactionService(transactionRepository, quoteRepository, feeLimitService)
+
+		expired := quote
+		expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+		_, err := transactionService.ConfirmTransfer(context.Background(), expired)
+		assert.ErrorIs(t, err, apperror.ErrQuoteExpired)
+	})
+}
@@ -1,18 +1,20 @@
 package service
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
 	"github.com/IrvanWijayaSardam/SelfBank/repository"
 )
 
 type UserService interface {
-	All(page int, pageSize int) ([]entity.User, error)
-	FindUser(id uint64) entity.User
-	GetSaldo(idUser uint64) int64
-	UpdateUser(user entity.User) entity.User
-	DeleteUser(idUser uint64) bool
+	All(ctx context.Context, page int, pageSize int) ([]entity.User, error)
+	FindUser(ctx context.Context, id uint64) (entity.User, error)
+	GetSaldo(ctx context.Context, idUser uint64) (money.Money, error)
+	GetBalance(ctx context.Context, idUser uint64, currency string) (money.Money, error)
+	UpdateUser(ctx context.Context, user entity.User) entity.User
 }
 
 type userService struct {
@@ -25,30 +27,77 @@ func NewUserService(userRep repository.UserRepository) UserService {
 	}
 }
 
-func (service *userService) All(page int, pageSize int) ([]entity.User, error) {
-	return service.userRepository.All(page, pageSize)
+func (service *userService) All(ctx context.Context, page int, pageSize int) ([]entity.User, error) {
+	return service.userRepository.All(ctx, page, pageSize)
 }
 
-func (service *userService) FindUser(id uint64) entity.User {
-	return service.userRepository.ProfileUser(id)
+func (service *userService) FindUser(ctx context.Context, id uint64) (entity.User, error) {
+	return service.userRepository.ProfileUser(ctx, id)
 }
 
-func (service *userService) UpdateUser(user entity.User) entity.User {
-	return service.userRepository.UpdateUser(user)
+func (service *userService) UpdateUser(ctx context.Context, user entity.User) entity.User {
+	return service.userRepository.UpdateUser(ctx, user)
 }
 
-func (service *userService) GetSaldo(id uint64) int64 {
-	user := service.userRepository.ProfileUser(id)
+// GetSaldo returns the account balance in IDR. It fails when any of the
+// totals it is made of cannot be read rather than leaving that total out.
+func (service *userService) GetSaldo(ctx context.Context, id uint64) (money.Money, error) {
+	user, err := service.userRepository.ProfileUser(ctx, id)
+	if err != nil {
+		return money.Money{}, err
+	}
+	accountNumber := strconv.FormatUint(user.AccountNumber, 10)
 
-	totalDeposit := service.userRepository.TotalDepositByUserID(id)
-	totalWithdrawal := service.userRepository.TotalWithdrawalByUserID(id)
-	totalTransactionIn := service.userRepository.TotalTransactionInByAccountNumber(strconv.Itoa(int(user.AccountNumber)))
-	totalTransactionOut := service.userRepository.TotalTransactionFromByAccountNumber(strconv.Itoa(int(user.AccountNumber)))
-	balance := (totalDeposit + totalTransactionIn) - totalWithdrawal - totalTransactionOut
+	deposits, err := service.userRepository.TotalDepositByUserID(ctx, id)
+	if err != nil {
+		return money.Money{}, err
+	}
+	transfersIn, err := service.userRepository.TotalTransactionInByAccountNumber(ctx, accountNumber)
+	if err != nil {
+		return money.Money{}, err
+	}
+	walletIn, err := service.userRepository.TotalWalletInByUserID(ctx, id, money.IDR)
+	if err != nil {
+		return money.Money{}, err
+	}
+	withdrawals, err := service.userRepository.TotalWithdrawalByUserID(ctx, id)
+	if err != nil {
+		return money.Money{}, err
+	}
+	transfersOut, err := service.userRepository.TotalTransactionFromByAccountNumber(ctx, accountNumber)
+	if err != nil {
+		return money.Money{}, err
+	}
+	walletOut, err := service.userRepository.TotalWalletOutByUserID(ctx, id, money.IDR)
+	if err != nil {
+		return money.Money{}, err
+	}
 
-	return balance
+	credits, err := money.Sum(deposits, transfersIn, walletIn)
+	if err != nil {
+		return money.Money{}, err
+	}
+	debits, err := money.Sum(withdrawals, transfersOut, walletOut)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return credits.Sub(debits)
 }
 
-func (service *userService) DeleteUser(id uint64) bool {
-	return service.userRepository.DeleteUser(id)
+// GetBalance returns the balance of a wallet. The IDR wallet is the account
+// balance, other currencies are only funded by wallet transfers.
+func (service *userService) GetBalance(ctx context.Context, id uint64, currency string) (money.Money, error) {
+	if currency == money.IDR {
+		return service.GetSaldo(ctx, id)
+	}
+
+	credits, err := service.userRepository.TotalWalletInByUserID(ctx, id, currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	debits, err := service.userRepository.TotalWalletOutByUserID(ctx, id, currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return credits.Sub(debits)
 }
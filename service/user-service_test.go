@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+	repomocks "github.com/IrvanWijayaSardam/SelfBank/repository/mocks"
+	"github.com/IrvanWijayaSardam/SelfBank/service"
+)
+
+func TestUserService_GetSaldo(t *testing.T) {
+	errQuery := errors.New("connection reset")
+	totals := map[string]money.Money{
+		"TotalDepositByUserID":                money.Rupiah(500000),
+		"TotalTransactionInByAccountNumber":   money.Rupiah(150000),
+		"TotalWalletInByUserID":               money.Rupiah(25000),
+		"TotalWithdrawalByUserID":             money.Rupiah(100000),
+		"TotalTransactionFromByAccountNumber": money.Rupiah(200000),
+		"TotalWalletOutByUserID":              money.Rupiah(50000),
+	}
+
+	tests := []struct {
+		name        string
+		failing     string
+		wantBalance money.Money
+		wantErr     bool
+	}{
+		{
+			name:        "All Totals Read",
+			wantBalance: money.Rupiah(325000),
+		},
+		{name: "Deposits Fail", failing: "TotalDepositByUserID", wantErr: true},
+		{name: "Incoming Transfers Fail", failing: "TotalTransactionInByAccountNumber", wantErr: true},
+		{name: "Incoming Wallet Transfers Fail", failing: "TotalWalletInByUserID", wantErr: true},
+		{name: "Withdrawals Fail", failing: "TotalWithdrawalByUserID", wantErr: true},
+		{name: "Outgoing Transfers Fail", failing: "TotalTransactionFromByAccountNumber", wantErr: true},
+		{name: "Outgoing Wallet Transfers Fail", failing: "TotalWalletOutByUserID", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userRepository := repomocks.NewUserRepository(t)
+			userRepository.On("ProfileUser", mock.Anything, uint64(7)).Return(entity.User{ID: 7, AccountNumber: 1234567890}, nil)
+			for method, total := range totals {
+				var err error
+				if method == test.failing {
+					total, err = money.Money{}, errQuery
+				}
+				arguments := []interface{}{mock.Anything, mock.Anything}
+				if method == "TotalWalletInByUserID" || method == "TotalWalletOutByUserID" {
+					arguments = append(arguments, money.IDR)
+				}
+				userRepository.On(method, arguments...).Return(total, err).Maybe()
+			}
+
+			balance, err := service.NewUserService(userRepository).GetSaldo(context.Background(), 7)
+
+			if test.wantErr {
+				assert.ErrorIs(t, err, errQuery)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantBalance, balance)
+		})
+	}
+}
+
+func TestUserService_GetBalance(t *testing.T) {
+	errQuery := errors.New("connection reset")
+
+	userRepository := repomocks.NewUserRepository(t)
+	userRepository.On("TotalWalletInByUserID", mock.Anything, uint64(7), money.USD).Return(money.New(1500, money.USD), nil)
+	userRepository.On("TotalWalletOutByUserID", mock.Anything, uint64(7), money.USD).Return(money.Money{}, errQuery)
+
+	_, err := service.NewUserService(userRepository).GetBalance(context.Background(), 7, money.USD)
+
+	assert.ErrorIs(t, err, errQuery)
+}
@@ -1,58 +1,133 @@
 package service
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/smtp"
-	"os"
-	"strconv"
+	"time"
 
+	"github.com/IrvanWijayaSardam/SelfBank/config"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
 	"github.com/IrvanWijayaSardam/SelfBank/repository"
+	"github.com/IrvanWijayaSardam/SelfBank/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
+// mailTimeout bounds the whole SMTP conversation.
+const mailTimeout = 15 * time.Second
+
 type VerificationService interface {
-	SendVerificationEmail(email string) error
-	VerifyOtp(otp string) bool
+	SendVerificationEmail(ctx context.Context, email string) error
+	VerifyOtp(ctx context.Context, otp string) bool
 }
 
 type verificationService struct {
 	VerificationRepository repository.VerificationRepository
+	smtpConfig             config.SMTPConfig
 }
 
-func NewVerificationService(verifRepo repository.VerificationRepository) VerificationService {
+func NewVerificationService(verifRepo repository.VerificationRepository, smtpConfig config.SMTPConfig) VerificationService {
 	return &verificationService{
 		VerificationRepository: verifRepo,
+		smtpConfig:             smtpConfig,
 	}
 }
 
-func (service *verificationService) SendVerificationEmail(email string) error {
-	host := os.Getenv("SMTP_HOST")
-	port := os.Getenv("SMTP_PORT")
-	from := os.Getenv("SMTP_MAIL")
-	password := os.Getenv("SMTP_PASSWORD")
-	var otp = strconv.FormatUint(helper.GenerateRandomAccountNumber(), 10)
+func (service *verificationService) SendVerificationEmail(ctx context.Context, email string) error {
+	host := service.smtpConfig.Host
+	from := service.smtpConfig.Mail
+	password := service.smtpConfig.Password
+	otp, err := helper.GenerateOTP()
+	if err != nil {
+		return err
+	}
 
 	auth := smtp.PlainAuth("", from, password, host)
-	smtpAddr := fmt.Sprintf("%s:%s", host, port)
+	smtpAddr := fmt.Sprintf("%s:%d", host, service.smtpConfig.Port)
 
 	body := "From: " + from + "\n" +
 		"To: " + email + "\n" +
 		"Subject: " + "Email Verification" + "\n\n" +
 		otp
 
-	err := smtp.SendMail(smtpAddr, auth, from, []string{email}, []byte(body))
+	// Store the OTP first, there is no point mailing a code that cannot be
+	// validated while Redis is down.
+	err = service.VerificationRepository.InsertVerification(ctx, email, otp)
 	if err != nil {
+		metrics.OTPEvents.WithLabelValues("send", metrics.OutcomeUnavailable).Inc()
 		return err
 	}
 
-	err = service.VerificationRepository.InsertVerification(email, otp)
+	_, span := tracing.Start(ctx, "smtp SendMail", semconv.PeerService("smtp"), semconv.ServerAddress(host))
+	err = sendMail(ctx, smtpAddr, host, auth, from, email, []byte(body))
+	tracing.End(span, err)
 	if err != nil {
+		metrics.OTPEvents.WithLabelValues("send", metrics.OutcomeFailure).Inc()
 		return err
 	}
-
+	metrics.OTPEvents.WithLabelValues("send", metrics.OutcomeSuccess).Inc()
 	return nil
 }
 
-func (service *verificationService) VerifyOtp(token string) bool {
-	return service.VerificationRepository.ValidateVerification(token)
+func (service *verificationService) VerifyOtp(ctx context.Context, token string) bool {
+	verified := service.VerificationRepository.ValidateVerification(ctx, token)
+	outcome := metrics.OutcomeFailure
+	if verified {
+		outcome = metrics.OutcomeSuccess
+	}
+	metrics.OTPEvents.WithLabelValues("verify", outcome).Inc()
+	return verified
+}
+
+// sendMail does what smtp.SendMail does but gives up once ctx is done, so a
+// slow mail server cannot hold the request forever.
+func sendMail(ctx context.Context, addr string, host string, auth smtp.Auth, from string, to string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, mailTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(msg); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
 }
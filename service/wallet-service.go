@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/dto"
+	"github.com/IrvanWijayaSardam/SelfBank/entity"
+	"github.com/IrvanWijayaSardam/SelfBank/fx"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+	"github.com/IrvanWijayaSardam/SelfBank/repository"
+)
+
+type WalletService interface {
+	Wallets(ctx context.Context, idUser uint64) ([]entity.Wallet, error)
+	OpenWallet(ctx context.Context, idUser uint64, currency string) (entity.Wallet, error)
+	Transfer(ctx context.Context, idUser uint64, accNumberFrom uint64, transfer dto.WalletTransferDTO) (entity.WalletTransfer, error)
+	Transfers(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.WalletTransfer, error)
+	TotalTransfers(ctx context.Context, idUser uint64) int64
+	Quote(from string, to string, amount int64) (fx.Conversion, error)
+}
+
+type walletService struct {
+	WalletRepository      repository.WalletRepository
+	TransactionRepository repository.TransactionRepository
+	UserService           UserService
+	FeeLimitService       FeeLimitService
+	RateProvider          fx.RateProvider
+}
+
+func NewWalletService(walletRep repository.WalletRepository, trxRep repository.TransactionRepository, userService UserService, feeLimitService FeeLimitService, rateProvider fx.RateProvider) WalletService {
+	return &walletService{
+		WalletRepository:      walletRep,
+		TransactionRepository: trxRep,
+		UserService:           userService,
+		FeeLimitService:       feeLimitService,
+		RateProvider:          rateProvider,
+	}
+}
+
+// Wallets lists the wallets of a user with their balance. Every user has an
+// IDR wallet, it is created on first use.
+func (service *walletService) Wallets(ctx context.Context, idUser uint64) ([]entity.Wallet, error) {
+	if service.WalletRepository.FindWallet(ctx, idUser, money.IDR).ID == 0 {
+		_, err := service.WalletRepository.InsertWallet(ctx, entity.Wallet{ID_User: idUser, Currency: money.IDR})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wallets, err := service.WalletRepository.FindWalletsByIDUser(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	for i := range wallets {
+		wallets[i].Balance, err = service.UserService.GetBalance(ctx, idUser, wallets[i].Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return wallets, nil
+}
+
+func (service *walletService) OpenWallet(ctx context.Context, idUser uint64, currency string) (entity.Wallet, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !money.IsSupported(currency) {
+		return entity.Wallet{}, apperror.ErrCurrencyNotSupported.With(apperror.Params{"currency": currency})
+	}
+	if service.WalletRepository.FindWallet(ctx, idUser, currency).ID != 0 {
+		return entity.Wallet{}, apperror.ErrWalletExists.With(apperror.Params{"currency": currency})
+	}
+	return service.WalletRepository.InsertWallet(ctx, entity.Wallet{ID_User: idUser, Currency: currency, Balance: money.New(0, currency)})
+}
+
+// Transfer debits the FromCurrency wallet of the caller and credits the
+// ToCurrency wallet of the recipient. Different currencies are only allowed
+// when the caller asked for a conversion. Wallet transfers are not charged,
+// but their IDR value counts towards the transfer limits.
+func (service *walletService) Transfer(ctx context.Context, idUser uint64, accNumberFrom uint64, transfer dto.WalletTransferDTO) (entity.WalletTransfer, error) {
+	fromCurrency := strings.ToUpper(strings.TrimSpace(transfer.FromCurrency))
+	toCurrency := strings.ToUpper(strings.TrimSpace(transfer.ToCurrency))
+	if toCurrency == "" {
+		toCurrency = fromCurrency
+	}
+	if transfer.Amount <= 0 {
+		return entity.WalletTransfer{}, apperror.ErrInvalidAmount
+	}
+	if len(transfer.Note) > maxTransferNoteLength {
+		return entity.WalletTransfer{}, apperror.ErrNoteTooLong.With(apperror.Params{"max": strconv.Itoa(maxTransferNoteLength)})
+	}
+	if fromCurrency != toCurrency && !transfer.Convert {
+		return entity.WalletTransfer{}, apperror.ErrCurrencyMismatch
+	}
+
+	accNumberTo := transfer.TransactionTo
+	if accNumberTo == 0 {
+		accNumberTo = accNumberFrom
+	}
+	if accNumberTo == accNumberFrom && fromCurrency == toCurrency {
+		return entity.WalletTransfer{}, apperror.ErrSameWallet
+	}
+
+	if !service.hasWallet(ctx, idUser, fromCurrency) {
+		return entity.WalletTransfer{}, apperror.ErrWalletNotFound.With(apperror.Params{"currency": fromCurrency})
+	}
+
+	recipient := service.TransactionRepository.FindUserByAccNumber(ctx, accNumberTo)
+	if recipient.ID == 0 {
+		return entity.WalletTransfer{}, apperror.ErrAccountNotFound
+	}
+	if !service.hasWallet(ctx, recipient.ID, toCurrency) {
+		return entity.WalletTransfer{}, apperror.ErrRecipientWalletNotFound.With(apperror.Params{"currency": toCurrency})
+	}
+
+	amount := money.New(transfer.Amount, fromCurrency)
+	conversion, err := fx.Convert(service.RateProvider, amount, toCurrency)
+	if err != nil {
+		return entity.WalletTransfer{}, err
+	}
+	if !conversion.Result.IsPositive() {
+		return entity.WalletTransfer{}, apperror.ErrAmountTooSmall
+	}
+
+	amountIDR, err := fx.Convert(service.RateProvider, amount, money.IDR)
+	if err != nil {
+		return entity.WalletTransfer{}, err
+	}
+	if recipient.ID != idUser {
+		_, err = service.FeeLimitService.Evaluate(ctx, idUser, entity.TrxTypeTransfer, "", amountIDR.Result)
+		if err != nil {
+			return entity.WalletTransfer{}, err
+		}
+	}
+
+	balance, err := service.UserService.GetBalance(ctx, idUser, fromCurrency)
+	if err != nil {
+		return entity.WalletTransfer{}, apperror.Internal(err)
+	}
+	if balance.LessThan(amount) {
+		return entity.WalletTransfer{}, apperror.ErrInsufficientFunds
+	}
+
+	walletTransfer := entity.WalletTransfer{
+		ID_User:      idUser,
+		ToUser:       recipient.ID,
+		AccountFrom:  accNumberFrom,
+		AccountTo:    accNumberTo,
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		DebitAmount:  conversion.Amount,
+		CreditAmount: conversion.Result,
+		Rate:         conversion.RateString(),
+		AmountIDR:    amountIDR.Result,
+		Note:         strings.TrimSpace(transfer.Note),
+	}
+	err = service.WalletRepository.InsertTransfer(ctx, &walletTransfer)
+	if err == nil {
+		recordTransfer(metrics.KindWallet, walletTransfer.AmountIDR)
+	}
+	return walletTransfer, err
+}
+
+func (service *walletService) Transfers(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.WalletTransfer, error) {
+	return service.WalletRepository.FindTransfersByIDUser(ctx, idUser, page, pageSize)
+}
+
+func (service *walletService) TotalTransfers(ctx context.Context, idUser uint64) int64 {
+	return service.WalletRepository.TotalTransfersByIDUser(ctx, idUser)
+}
+
+func (service *walletService) Quote(from string, to string, amount int64) (fx.Conversion, error) {
+	return fx.Convert(service.RateProvider, money.New(amount, strings.ToUpper(from)), strings.ToUpper(to))
+}
+
+func (service *walletService) hasWallet(ctx context.Context, idUser uint64, currency string) bool {
+	return currency == money.IDR || service.WalletRepository.FindWallet(ctx, idUser, currency).ID != 0
+}
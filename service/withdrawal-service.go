@@ -2,128 +2,104 @@ package service
 
 import (
 	"bytes"
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"log"
 	"mime/multipart"
-	"os"
-	"path/filepath"
+	"strconv"
 
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
 	"github.com/IrvanWijayaSardam/SelfBank/dto"
 	"github.com/IrvanWijayaSardam/SelfBank/entity"
 	"github.com/IrvanWijayaSardam/SelfBank/helper"
+	"github.com/IrvanWijayaSardam/SelfBank/metrics"
 	"github.com/IrvanWijayaSardam/SelfBank/repository"
+	"github.com/IrvanWijayaSardam/SelfBank/storage"
 	"github.com/jung-kurt/gofpdf"
 
-	"github.com/google/uuid"
 	"github.com/mashingan/smapping"
+	"github.com/sirupsen/logrus"
 )
 
 type WithdrawalService interface {
-	InsertWithdrawal(Withdrawal dto.WithdrawalDTO) entity.Withdrawal
-	All(page int, pageSize int) ([]entity.Withdrawal, error)
-	FindWithdrawalByIDUser(idUiser uint64, int, pageSize int) ([]entity.Withdrawal, error)
-	FindWithdrawalByID(id uint64) *entity.Withdrawal
-	SaveFile(file *multipart.FileHeader) (string, error)
-	TotalWithdrawal() int64
-	TotalWithdrawalByUserID(idUser uint64) int64
-	UpdateWithdrawalStatus(orderID uint64, newStatus uint64) error
+	InsertWithdrawal(ctx context.Context, Withdrawal dto.WithdrawalDTO) entity.Withdrawal
+	All(ctx context.Context, page int, pageSize int) ([]entity.Withdrawal, error)
+	FindWithdrawalByIDUser(ctx context.Context, idUiser uint64, int, pageSize int) ([]entity.Withdrawal, error)
+	FindWithdrawalByID(ctx context.Context, id uint64) *entity.Withdrawal
+	SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error)
+	TotalWithdrawal(ctx context.Context) int64
+	TotalWithdrawalByUserID(ctx context.Context, idUser uint64) int64
+	UpdateWithdrawalStatus(ctx context.Context, orderID uint64, newStatus uint64) error
 	GenerateWithdrawalPDF(Transactions []entity.Withdrawal) (*bytes.Buffer, error)
 }
 
 type withdrawalService struct {
 	WithdrawalRepository repository.WithdrawalRepository
+	FeeLimitService      FeeLimitService
+	BlobStore            storage.BlobStore
 }
 
-func NewWithdrawalService(fundRep repository.WithdrawalRepository) WithdrawalService {
+func NewWithdrawalService(fundRep repository.WithdrawalRepository, feeLimitService FeeLimitService, blobStore storage.BlobStore) WithdrawalService {
 	return &withdrawalService{
 		WithdrawalRepository: fundRep,
+		FeeLimitService:      feeLimitService,
+		BlobStore:            blobStore,
 	}
 }
 
-func (service *withdrawalService) InsertWithdrawal(b dto.WithdrawalDTO) entity.Withdrawal {
+func (service *withdrawalService) InsertWithdrawal(ctx context.Context, b dto.WithdrawalDTO) entity.Withdrawal {
 	Withdrawal := entity.Withdrawal{}
 	err := smapping.FillStruct(&Withdrawal, smapping.MapFields(&b))
 	if err != nil {
-		log.Fatalf("Failed map %v", err)
+		logrus.Fatalf("Failed map %v", err)
 	}
-	res := service.WithdrawalRepository.InsertWithdrawal(&Withdrawal)
+	res := service.WithdrawalRepository.InsertWithdrawal(ctx, &Withdrawal)
+	service.FeeLimitService.RecordFee(ctx, res.ID_User, entity.TrxTypeWithdrawal, strconv.FormatUint(res.ID, 10), res.Fee)
+	recordTransfer(metrics.KindWithdrawal, res.Amount)
 	return res
 }
 
-func (service *withdrawalService) TotalWithdrawal() int64 {
-	return service.WithdrawalRepository.TotalWithdrawal()
+func (service *withdrawalService) TotalWithdrawal(ctx context.Context) int64 {
+	return service.WithdrawalRepository.TotalWithdrawal(ctx)
 }
 
-func (service *withdrawalService) TotalWithdrawalByUserID(idUser uint64) int64 {
-	return service.WithdrawalRepository.TotalWithdrawalByUserID(idUser)
+func (service *withdrawalService) TotalWithdrawalByUserID(ctx context.Context, idUser uint64) int64 {
+	return service.WithdrawalRepository.TotalWithdrawalByUserID(ctx, idUser)
 }
 
-func (service *withdrawalService) All(page int, pageSize int) ([]entity.Withdrawal, error) {
+func (service *withdrawalService) All(ctx context.Context, page int, pageSize int) ([]entity.Withdrawal, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
-	return service.WithdrawalRepository.All(page, pageSize)
+	return service.WithdrawalRepository.All(ctx, page, pageSize)
 }
 
-func (service *withdrawalService) FindWithdrawalByIDUser(idUser uint64, page int, pageSize int) ([]entity.Withdrawal, error) {
+func (service *withdrawalService) FindWithdrawalByIDUser(ctx context.Context, idUser uint64, page int, pageSize int) ([]entity.Withdrawal, error) {
 	if page <= 0 || pageSize <= 0 {
-		return nil, errors.New("Invalid page or pageSize values")
+		return nil, apperror.ErrInvalidPagination
 	}
 
-	return service.WithdrawalRepository.FindWithdrawalByIDUser(idUser, page, pageSize)
+	return service.WithdrawalRepository.FindWithdrawalByIDUser(ctx, idUser, page, pageSize)
 }
 
-func (service *withdrawalService) FindWithdrawalByID(id uint64) *entity.Withdrawal {
-	return service.WithdrawalRepository.FindWithdrawalByID(id)
+func (service *withdrawalService) FindWithdrawalByID(ctx context.Context, id uint64) *entity.Withdrawal {
+	return service.WithdrawalRepository.FindWithdrawalByID(ctx, id)
 }
 
-func (service *withdrawalService) SaveFile(file *multipart.FileHeader) (string, error) {
-	src, err := file.Open()
-	if err != nil {
-		return "", err
-	}
-	defer src.Close()
-
-	cdnDir := "cdn" // Specify the desired directory name
-
-	// Create the cdn directory if it doesn't exist in the current working directory
-	err = os.MkdirAll(cdnDir, 0755)
-	if err != nil {
-		return "", err
-	}
-
-	// Generate a random file name using UUID and append the original file extension
-	fileExt := filepath.Ext(file.Filename)
-	fileName := uuid.New().String() + fileExt
-	filePath := filepath.Join(cdnDir, fileName)
-
-	dst, err := os.Create(filePath)
-	if err != nil {
-		return "", err
-	}
-	defer dst.Close()
-
-	_, err = io.Copy(dst, src)
-	if err != nil {
-		return "", err
-	}
-
-	return fileName, nil
+func (service *withdrawalService) SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	return saveUpload(ctx, service.BlobStore, file)
 }
 
-func (service *withdrawalService) UpdateWithdrawalStatus(orderID uint64, newStatus uint64) error {
+func (service *withdrawalService) UpdateWithdrawalStatus(ctx context.Context, orderID uint64, newStatus uint64) error {
 	// Fetch the MasterJual entity by order ID
-	masterJual := service.WithdrawalRepository.FindWithdrawalByID(orderID)
+	masterJual := service.WithdrawalRepository.FindWithdrawalByID(ctx, orderID)
 	if masterJual.ID == 0 {
-		return fmt.Errorf("MasterJual not found for order ID %s", orderID)
+		return apperror.ErrNotFound.WithDetail(fmt.Sprintf("MasterJual not found for order ID %d", orderID))
 	}
 
 	masterJual.Status = newStatus
 
-	err := service.WithdrawalRepository.UpdateWithdrawalStatus(masterJual.ID, newStatus)
+	err := service.WithdrawalRepository.UpdateWithdrawalStatus(ctx, masterJual.ID, newStatus)
 	if err != nil {
 		return err
 	}
@@ -151,7 +127,7 @@ func (service *withdrawalService) GenerateWithdrawalPDF(Transactions []entity.Wi
 		pdf.CellFormat(40, 10, fmt.Sprintf("%d", transaction.ID), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(40, 10, fmt.Sprintf("%d", transaction.ID_User), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(50, 10, helper.ConvertUnixtime(transaction.Date).Format("2006-01-02 15:04:05"), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(40, 10, helper.Uint64ToString(transaction.Amount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(40, 10, transaction.Amount.Format(), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(60, 10, transaction.To, "1", 1, "C", false, 0, "")
 	}
 
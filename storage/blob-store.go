@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// PublicPrefix holds files anyone may fetch, such as profile pictures.
+	PublicPrefix = "public/"
+	// PrivatePrefix holds files that are only handed out through SignedURL,
+	// such as KYC documents.
+	PrivatePrefix = "private/"
+)
+
+var ErrNotFound = errors.New("File not found")
+
+// BlobStore stores uploaded files. Keys are slash separated paths starting
+// with PublicPrefix or PrivatePrefix.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the permanent address of a public file.
+	URL(key string) string
+	// SignedURL returns an address that grants access to any file until
+	// expiry has passed.
+	SignedURL(key string, expiry time.Duration) (string, error)
+	// Ping checks that the backend can be reached, for readiness probes.
+	Ping(ctx context.Context) error
+}
+
+// IsPublic reports whether key may be served without a signature.
+func IsPublic(key string) bool {
+	return strings.HasPrefix(key, PublicPrefix)
+}
+
+// cleanKey rejects keys that are empty, absolute or that try to leave the
+// store root.
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") || strings.HasPrefix(key, "/") {
+		return "", errors.New("Invalid storage key")
+	}
+	clean := path.Clean(key)
+	if !strings.HasPrefix(clean, PublicPrefix) && !strings.HasPrefix(clean, PrivatePrefix) {
+		return "", errors.New("Storage key must start with public/ or private/")
+	}
+	return clean, nil
+}
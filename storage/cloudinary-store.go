@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/uploader"
+)
+
+type cloudinaryStore struct {
+	cld    *cloudinary.Cloudinary
+	folder string
+	signer *URLSigner
+}
+
+// NewCloudinaryStore keeps images on Cloudinary. Private files are uploaded
+// with the authenticated delivery type, and since Cloudinary signatures never
+// expire, SignedURL hands out links to the API proxy signed by signer instead.
+func NewCloudinaryStore(cloudName string, apiKey string, apiSecret string, folder string, signer *URLSigner) (BlobStore, error) {
+	cld, err := cloudinary.NewFromParams(cloudName, apiKey, apiSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &cloudinaryStore{cld: cld, folder: folder, signer: signer}, nil
+}
+
+func (s *cloudinaryStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.cld.Upload.Upload(ctx, content, uploader.UploadParams{
+		PublicID:  s.publicID(key),
+		Overwrite: true,
+		Type:      s.deliveryType(key),
+	})
+	return err
+}
+
+func (s *cloudinaryStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	assetURL, err := s.assetURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, assetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Cloudinary responded with status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (s *cloudinaryStore) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID: s.publicID(key),
+		Type:     string(s.deliveryType(key)),
+	})
+	return err
+}
+
+func (s *cloudinaryStore) URL(key string) string {
+	assetURL, err := s.assetURL(key)
+	if err != nil {
+		return ""
+	}
+	return assetURL
+}
+
+func (s *cloudinaryStore) SignedURL(key string, expiry time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.Sign(key, expiry), nil
+}
+
+func (s *cloudinaryStore) Ping(ctx context.Context) error {
+	result, err := s.cld.Admin.Ping(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Error.Message != "" {
+		return errors.New(result.Error.Message)
+	}
+	return nil
+}
+
+func (s *cloudinaryStore) assetURL(key string) (string, error) {
+	image, err := s.cld.Image(s.publicID(key))
+	if err != nil {
+		return "", err
+	}
+
+	image.DeliveryType = s.deliveryType(key)
+	image.Config.URL.Secure = true
+	image.Config.URL.SignURL = !IsPublic(key)
+	return image.String()
+}
+
+// publicID drops the extension, Cloudinary stores the format separately.
+func (s *cloudinaryStore) publicID(key string) string {
+	publicID := strings.TrimSuffix(key, path.Ext(key))
+	if s.folder != "" {
+		publicID = s.folder + "/" + publicID
+	}
+	return publicID
+}
+
+func (s *cloudinaryStore) deliveryType(key string) api.DeliveryType {
+	if IsPublic(key) {
+		return api.Upload
+	}
+	return api.Authenticated
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+)
+
+// ResizeImage scales an image down to fit within maxWidth x maxHeight,
+// keeping its aspect ratio, and re-encodes it as JPEG. Smaller images are
+// only re-encoded, which also strips any embedded metadata.
+func ResizeImage(content []byte, maxWidth int, maxHeight int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxWidth || height > maxHeight {
+		scale := float64(maxWidth) / float64(width)
+		if heightScale := float64(maxHeight) / float64(height); heightScale < scale {
+			scale = heightScale
+		}
+		width = int(float64(width) * scale)
+		height = int(float64(height) * scale)
+		if width < 1 {
+			width = 1
+		}
+		if height < 1 {
+			height = 1
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	var buf bytes.Buffer
+	err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
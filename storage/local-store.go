@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PublicFilesPath is where the API serves public files of a local store.
+const PublicFilesPath = "/cdn/"
+
+type localStore struct {
+	root    string
+	baseURL string
+	signer  *URLSigner
+}
+
+// NewLocalStore keeps files below root on the local disk. Public files are
+// served by the API under PublicFilesPath and private ones through signer.
+func NewLocalStore(root string, baseURL string, signer *URLSigner) BlobStore {
+	return &localStore{root: root, baseURL: baseURL, signer: signer}
+}
+
+func (s *localStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0750)
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, content)
+	return err
+}
+
+func (s *localStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (s *localStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *localStore) URL(key string) string {
+	return s.baseURL + PublicFilesPath + strings.TrimPrefix(key, PublicPrefix)
+}
+
+func (s *localStore) SignedURL(key string, expiry time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.Sign(key, expiry), nil
+}
+
+func (s *localStore) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *localStore) Ping(ctx context.Context) error {
+	if err := os.MkdirAll(s.root, 0750); err != nil {
+		return err
+	}
+
+	probe, err := os.CreateTemp(s.root, ".ping-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
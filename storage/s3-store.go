@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type s3Store struct {
+	client   *minio.Client
+	bucket   string
+	endpoint string
+	useSSL   bool
+}
+
+// NewS3Store stores files in a bucket of any S3 compatible service (AWS S3,
+// MinIO, ...). The bucket should only allow anonymous reads below
+// PublicPrefix.
+func NewS3Store(endpoint string, region string, accessKey string, secretKey string, bucket string, useSSL bool) (BlobStore, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Store{client: client, bucket: bucket, endpoint: endpoint, useSSL: useSSL}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObject(ctx, s.bucket, key, content, -1, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// GetObject is lazy, Stat surfaces a missing key before the caller reads
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *s3Store) URL(key string) string {
+	scheme := "http://"
+	if s.useSSL {
+		scheme = "https://"
+	}
+	return scheme + strings.TrimSuffix(s.endpoint, "/") + "/" + s.bucket + "/" + key
+}
+
+func (s *s3Store) SignedURL(key string, expiry time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	signed, err := s.client.PresignedGetObject(context.Background(), s.bucket, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return signed.String(), nil
+}
+
+func (s *s3Store) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Bucket %s does not exist", s.bucket)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/IrvanWijayaSardam/SelfBank/storage")
+
+// tracedStore opens a span around every call that reaches the backend.
+type tracedStore struct {
+	store  BlobStore
+	driver string
+}
+
+// Traced wraps store so its calls show up in the trace of the request that
+// made them.
+func Traced(store BlobStore, driver string) BlobStore {
+	return &tracedStore{store: store, driver: driver}
+}
+
+func (s *tracedStore) start(ctx context.Context, operation string, key string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "storage "+operation, trace.WithAttributes(
+		attribute.String("storage.driver", s.driver),
+		attribute.String("storage.key", key),
+	))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (s *tracedStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	ctx, span := s.start(ctx, "Put", key)
+	err := s.store.Put(ctx, key, content, contentType)
+	endSpan(span, err)
+	return err
+}
+
+func (s *tracedStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	ctx, span := s.start(ctx, "Get", key)
+	content, err := s.store.Get(ctx, key)
+	endSpan(span, err)
+	return content, err
+}
+
+func (s *tracedStore) Delete(ctx context.Context, key string) error {
+	ctx, span := s.start(ctx, "Delete", key)
+	err := s.store.Delete(ctx, key)
+	endSpan(span, err)
+	return err
+}
+
+func (s *tracedStore) URL(key string) string {
+	return s.store.URL(key)
+}
+
+func (s *tracedStore) SignedURL(key string, expiry time.Duration) (string, error) {
+	return s.store.SignedURL(key, expiry)
+}
+
+func (s *tracedStore) Ping(ctx context.Context) error {
+	ctx, span := s.start(ctx, "Ping", "")
+	err := s.store.Ping(ctx)
+	endSpan(span, err)
+	return err
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+)
+
+// UploadPolicy describes which files an upload accepts. ContentTypes maps
+// each accepted sniffed content type to the extension files are stored with.
+type UploadPolicy struct {
+	MaxSize      int64
+	ContentTypes map[string]string
+}
+
+var (
+	ImagePolicy = UploadPolicy{
+		MaxSize: 5 << 20,
+		ContentTypes: map[string]string{
+			"image/jpeg": ".jpg",
+			"image/png":  ".png",
+		},
+	}
+	DocumentPolicy = UploadPolicy{
+		MaxSize: 10 << 20,
+		ContentTypes: map[string]string{
+			"image/jpeg":      ".jpg",
+			"image/png":       ".png",
+			"application/pdf": ".pdf",
+		},
+	}
+)
+
+// ValidatedFile is an upload that passed an UploadPolicy.
+type ValidatedFile struct {
+	Content     []byte
+	ContentType string
+	Extension   string
+}
+
+func (f ValidatedFile) Reader() io.Reader {
+	return bytes.NewReader(f.Content)
+}
+
+// Validate reads at most MaxSize bytes from content and sniffs its type
+// rather than trusting the name or header sent by the client. Files the
+// policy rejects are reported as ErrInvalidFile.
+func (p UploadPolicy) Validate(content io.Reader) (ValidatedFile, error) {
+	reader := bufio.NewReader(io.LimitReader(content, p.MaxSize+1))
+	head, _ := reader.Peek(512)
+
+	contentType := http.DetectContentType(head)
+	extension, ok := p.ContentTypes[contentType]
+	if !ok {
+		return ValidatedFile{}, apperror.ErrInvalidFile.WithDetail(fmt.Sprintf("File type %s is not allowed", contentType))
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return ValidatedFile{}, err
+	}
+	if int64(len(data)) > p.MaxSize {
+		return ValidatedFile{}, apperror.ErrInvalidFile.WithDetail(fmt.Sprintf("File must be at most %dMB", p.MaxSize>>20))
+	}
+
+	return ValidatedFile{Content: data, ContentType: contentType, Extension: extension}, nil
+}
@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/IrvanWijayaSardam/SelfBank/helper"
+)
+
+// Indonesian mobile numbers start with 08, 628 or +628 followed by 7 to 11
+// more digits.
+var indonesianPhonePattern = regexp.MustCompile(`^(?:\+62|62|0)8[1-9][0-9]{6,10}$`)
+
+func isIndonesianPhone(fl validator.FieldLevel) bool {
+	phone := strings.NewReplacer(" ", "", "-", "").Replace(fl.Field().String())
+	return indonesianPhonePattern.MatchString(phone)
+}
+
+// isAccountNumber checks the length and check digit of an account number, so
+// a mistyped one is refused before it is looked up.
+func isAccountNumber(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return helper.ValidAccountNumber(field.Uint())
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return field.Int() > 0 && helper.ValidAccountNumber(uint64(field.Int()))
+	}
+	return false
+}
+
+func isPositiveAmount(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return field.Int() > 0
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return field.Uint() > 0
+	}
+	return false
+}
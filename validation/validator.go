@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/IrvanWijayaSardam/SelfBank/apperror"
+	"github.com/IrvanWijayaSardam/SelfBank/money"
+)
+
+// Validator checks request DTOs against their validate tags. It is
+// registered as the Echo validator, handlers call Validate right after Bind.
+type Validator struct {
+	validate *validator.Validate
+}
+
+func New() *Validator {
+	validate := validator.New()
+
+	// Report fields by the name clients send them with.
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	// Amounts are validated by their minor units, the currency is checked by
+	// the services.
+	validate.RegisterCustomTypeFunc(func(value reflect.Value) interface{} {
+		return value.Interface().(money.Money).Amount
+	}, money.Money{})
+
+	validate.RegisterValidation("phone_id", isIndonesianPhone)
+	validate.RegisterValidation("account_number", isAccountNumber)
+	validate.RegisterValidation("positive_amount", isPositiveAmount)
+
+	return &Validator{validate: validate}
+}
+
+// Validate returns apperror.ErrValidation listing every field that broke a
+// rule, or nil when i is valid.
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	fields := make([]apperror.FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, apperror.FieldError{
+			Field: fieldErr.Field(),
+			Rule:  fieldErr.Tag(),
+			Param: fieldErr.Param(),
+		})
+	}
+	return apperror.ErrValidation.WithFields(fields)
+}